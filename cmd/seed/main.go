@@ -99,7 +99,7 @@ func run() error {
 		log.Printf("Organization created with ID: %s", orgID)
 
 		// Add user as owner
-		if err := orgRepo.AddMember(ctx, org.ID, user.ID, "owner"); err != nil {
+		if err := orgRepo.AddMember(ctx, org.ID, user.ID, domain.OrgRoleOwner); err != nil {
 			return fmt.Errorf("failed to add user to organization: %w", err)
 		}
 		log.Printf("User added as owner to organization")
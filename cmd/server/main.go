@@ -9,14 +9,22 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
+	_ "time/tzdata" // organization timezones must resolve in minimal images
 
-	"github.com/wsciaroni/opsdeck/internal/adapter/auth/google"
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/adapter/auth/oidc"
+	"github.com/wsciaroni/opsdeck/internal/adapter/email"
+	"github.com/wsciaroni/opsdeck/internal/adapter/jobs"
+	"github.com/wsciaroni/opsdeck/internal/adapter/sms"
 	"github.com/wsciaroni/opsdeck/internal/adapter/storage"
 	"github.com/wsciaroni/opsdeck/internal/adapter/storage/postgres"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/adapter/webhook"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
 	"github.com/wsciaroni/opsdeck/internal/core/service"
 )
 
@@ -56,12 +64,10 @@ func main() {
 	defer pool.Close()
 	log.Println("Connected to database")
 
-	// Initialize River (Job Queue) - just ensuring it works, not running workers yet
-	_, err = storage.InitRiver(ctx, pool)
-	if err != nil {
-		log.Fatalf("Failed to initialize River: %v", err)
+	// Run River (Job Queue) Migrations
+	if err := storage.RunRiverMigrations(ctx, pool); err != nil {
+		log.Fatalf("Failed to run River migrations: %v", err)
 	}
-	log.Println("Initialized River client")
 
 	// Prepare Static FS
 	// dist is the root of the embedded FS, but we are inside cmd/server so it is relative to that?
@@ -73,14 +79,11 @@ func main() {
 	}
 
 	// Config
-	googleClientID := os.Getenv("GOOGLE_CLIENT_ID")
-	googleClientSecret := os.Getenv("GOOGLE_CLIENT_SECRET")
-	googleCallbackURL := os.Getenv("GOOGLE_CALLBACK_URL")
-	sessionSecret := os.Getenv("SESSION_SECRET")
-
-	if googleClientID == "" || googleClientSecret == "" || googleCallbackURL == "" {
-		log.Fatal("Missing required environment variables: GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET, GOOGLE_CALLBACK_URL")
+	oidcConfigs, err := oidc.ConfigsFromEnv(os.Getenv)
+	if err != nil {
+		log.Fatalf("Invalid OIDC configuration: %v", err)
 	}
+	sessionSecret := os.Getenv("SESSION_SECRET")
 
 	if sessionSecret == "" {
 		if os.Getenv("APP_ENV") == "production" {
@@ -92,42 +95,214 @@ func main() {
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
+	// Init Email
+	mailFrom := os.Getenv("SMTP_FROM")
+	if mailFrom == "" {
+		mailFrom = "OpsDeck <noreply@localhost>"
+	}
+	var notifier port.Notifier
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		smtpPort := 587
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			if smtpPort, err = strconv.Atoi(v); err != nil {
+				log.Fatalf("Invalid SMTP_PORT: %v", err)
+			}
+		}
+		notifier, err = email.NewSMTPNotifier(email.SMTPConfig{
+			Host:     smtpHost,
+			Port:     smtpPort,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     mailFrom,
+		})
+	} else {
+		log.Println("WARNING: SMTP_HOST is not set. Emails will be logged instead of sent.")
+		notifier, err = email.NewLogNotifier(logger, mailFrom, os.Getenv("MAIL_DIR"))
+	}
+	if err != nil {
+		log.Fatalf("Failed to configure email: %v", err)
+	}
+
+	// Init SMS
+	var smsSender port.SMSSender
+	if smsURL := os.Getenv("SMS_API_URL"); smsURL != "" {
+		smsSender, err = sms.NewHTTPSender(sms.HTTPConfig{
+			URL:      smsURL,
+			Username: os.Getenv("SMS_API_USERNAME"),
+			Password: os.Getenv("SMS_API_PASSWORD"),
+			From:     os.Getenv("SMS_FROM"),
+		})
+		if err != nil {
+			log.Fatalf("Failed to configure SMS: %v", err)
+		}
+	} else {
+		log.Println("WARNING: SMS_API_URL is not set. Text messages will be logged instead of sent.")
+		smsSender = sms.NewLogSender(logger)
+	}
+
 	// Init Auth
 	repo := postgres.NewUserRepository(pool)
 	orgRepo := postgres.NewOrganizationRepository(pool)
-	oidcProvider, err := google.NewGoogleProvider(ctx, googleClientID, googleClientSecret, googleCallbackURL)
+	oidcProviders := make([]port.OIDCProvider, 0, len(oidcConfigs))
+	for _, cfg := range oidcConfigs {
+		provider, err := oidc.NewProvider(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to create OIDC provider: %v", err)
+		}
+		oidcProviders = append(oidcProviders, provider)
+	}
+	if len(oidcProviders) == 0 {
+		log.Println("WARNING: No OIDC providers are configured. Users can only sign in with email links, if APP_URL is set.")
+	}
+	authService := service.NewAuthService(repo, orgRepo, oidcProviders, logger)
+
+	// Init Inbox
+	inboxRepo := postgres.NewInboxRepository(pool)
+	inboxService := service.NewInboxService(inboxRepo)
+	inboxHandler := handler.NewInboxHandler(inboxService, logger)
+	userService := service.NewUserService(repo)
+	sessionService := service.NewSessionService(postgres.NewSessionRepository(pool), repo)
+	apiKeyService := service.NewAPIKeyService(postgres.NewAPIKeyRepository(pool), repo)
+	txManager := postgres.NewTxManager(pool)
+
+	// Jobs are enqueued through an insert-only client because the worker
+	// client below needs the services that enqueue them.
+	riverInserter, err := storage.InitRiverInserter(pool)
 	if err != nil {
-		log.Fatalf("Failed to create OIDC provider: %v", err)
+		log.Fatalf("Failed to initialize River inserter: %v", err)
 	}
-	authService := service.NewAuthService(repo, orgRepo, oidcProvider, logger)
-	authHandler := handler.NewAuthHandler(authService, orgRepo, logger, sessionSecret)
+	notificationQueue := jobs.NewNotificationQueue(riverInserter)
+
+	// Email sign-in links point at APP_URL, so they are off without it.
+	var magicLinkService *service.MagicLinkService
+	if appURL := os.Getenv("APP_URL"); appURL != "" {
+		magicLinkService = service.NewMagicLinkService(postgres.NewLoginTokenRepository(pool), authService, notificationQueue, txManager, service.MagicLinkConfig{
+			BaseURL: appURL,
+			Secret:  []byte(sessionSecret),
+		})
+	} else {
+		log.Println("WARNING: APP_URL is not set. Email sign-in links are disabled.")
+	}
+	var magicLinks port.MagicLinkService
+	if magicLinkService != nil {
+		magicLinks = magicLinkService
+	}
+	authHandler := handler.NewAuthHandler(authService, sessionService, magicLinks, orgRepo, inboxService, userService, logger, sessionSecret)
+
+	// Init Ticket Statuses
+	ticketStatusRepo := postgres.NewTicketStatusRepository(pool)
+	ticketStatusService := service.NewTicketStatusService(ticketStatusRepo, txManager)
+	ticketStatusHandler := handler.NewTicketStatusHandler(ticketStatusService, logger)
+
+	// Init Ticket Priorities
+	ticketPriorityRepo := postgres.NewTicketPriorityRepository(pool)
+	ticketPriorityService := service.NewTicketPriorityService(ticketPriorityRepo, txManager)
+	ticketPriorityHandler := handler.NewTicketPriorityHandler(ticketPriorityService, logger)
+
+	// Init Ticket Categories
+	ticketCategoryRepo := postgres.NewTicketCategoryRepository(pool)
+	ticketCategoryService := service.NewTicketCategoryService(ticketCategoryRepo, txManager)
+	ticketCategoryHandler := handler.NewTicketCategoryHandler(ticketCategoryService, orgRepo, logger)
+
+	// Init Authorization
+	authorizer := service.NewAuthorizer(orgRepo)
 
 	// Init Ticket
 	ticketRepo := postgres.NewTicketRepository(pool)
-	ticketService := service.NewTicketService(ticketRepo)
-	ticketHandler := handler.NewTicketHandler(ticketService, orgRepo, repo, logger)
+	ticketService := service.NewTicketService(ticketRepo, ticketStatusRepo, ticketPriorityRepo, ticketCategoryRepo, notificationQueue, txManager)
+	ticketHandler := handler.NewTicketHandler(ticketService, orgRepo, repo, authorizer, logger)
 
 	// Init Comment
 	commentRepo := postgres.NewCommentRepository(pool)
-	commentService := service.NewCommentService(commentRepo)
-	commentHandler := handler.NewCommentHandler(commentService, ticketService, repo, orgRepo, logger)
-
-	// Init Org
-	orgHandler := handler.NewOrgHandler(orgRepo, repo, logger)
+	commentService := service.NewCommentService(commentRepo, notificationQueue, txManager)
+	commentHandler := handler.NewCommentHandler(commentService, ticketService, repo, authorizer, logger)
 
 	// Init Public View
-	publicViewHandler := handler.NewPublicViewHandler(orgRepo, ticketService, commentService, repo, logger)
+	publicViewHandler := handler.NewPublicViewHandler(orgRepo, ticketService, commentService, ticketStatusService, ticketPriorityService, repo, logger)
 
 	// Init Scheduled Tasks
 	scheduledTaskRepo := postgres.NewScheduledTaskRepository(pool)
-	scheduledTaskService := service.NewScheduledTaskService(scheduledTaskRepo)
-	scheduledTaskHandler := handler.NewScheduledTaskHandler(scheduledTaskService, orgRepo, logger)
+	scheduledTaskService := service.NewScheduledTaskService(scheduledTaskRepo, orgRepo, ticketPriorityRepo, ticketCategoryRepo, ticketService, txManager)
+	scheduledTaskHandler := handler.NewScheduledTaskHandler(scheduledTaskService, authorizer, logger)
+
+	// Init Org
+	auditRepo := postgres.NewAuditRepository(pool)
+	auditService := service.NewAuditService(auditRepo)
+	orgHandler := handler.NewOrgHandler(orgRepo, repo, auditService, scheduledTaskService, authorizer, txManager, logger)
+
+	// Init Timeline
+	timelineService := service.NewTimelineService(ticketRepo, commentRepo, scheduledTaskRepo, repo)
+	timelineHandler := handler.NewTimelineHandler(timelineService, ticketService, orgRepo, authorizer, logger)
+
+	// Init Notifications
+	notificationPreferenceRepo := postgres.NewNotificationPreferenceRepository(pool)
+	notificationPreferenceService := service.NewNotificationPreferenceService(notificationPreferenceRepo, txManager)
+	notificationPreferenceHandler := handler.NewNotificationPreferenceHandler(notificationPreferenceService, logger)
+	emailTemplateRepo := postgres.NewEmailTemplateRepository(pool)
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateRepo, orgRepo)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(emailTemplateService, logger)
+	notificationService := service.NewNotificationService(notificationQueue, notificationPreferenceService, inboxRepo, emailTemplateService, ticketRepo, commentRepo, ticketStatusRepo, repo, orgRepo, txManager)
+
+	// Init Digests
+	digestService := service.NewDigestService(postgres.NewDigestRepository(pool), repo, orgRepo, ticketRepo, ticketStatusRepo, scheduledTaskRepo, notificationQueue, txManager)
+	digestHandler := handler.NewDigestHandler(digestService, logger)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService, logger)
+
+	// Init Webhooks
+	webhookRepo := postgres.NewWebhookRepository(pool)
+	webhookSender := webhook.NewSender(webhook.SenderConfig{
+		AllowPrivateNetworks: os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true",
+	})
+	webhookService := service.NewWebhookService(webhookRepo, jobs.NewWebhookQueue(riverInserter), webhookSender, ticketRepo, commentRepo, ticketStatusRepo, ticketPriorityRepo, ticketCategoryRepo, repo, txManager)
+	webhookHandler := handler.NewWebhookHandler(webhookService, logger)
+
+	// Init Inbound Email
+	var receiver *email.Receiver
+	if addr := os.Getenv("INBOUND_SMTP_ADDR"); addr != "" {
+		inboundEmailService := service.NewInboundEmailService(orgRepo, repo, ticketService, ticketRepo, ticketPriorityRepo, commentService, txManager)
+		receiver, err = email.NewReceiver(email.ReceiverConfig{
+			Addr:   addr,
+			Domain: os.Getenv("INBOUND_EMAIL_DOMAIN"),
+		}, inboundEmailService, logger)
+		if err != nil {
+			log.Fatalf("Failed to configure inbound email: %v", err)
+		}
+	}
+
+	// Init River (Job Queue)
+	workers := river.NewWorkers()
+	river.AddWorker(workers, jobs.NewRunScheduledTasksWorker(scheduledTaskService, logger))
+	river.AddWorker(workers, jobs.NewDispatchNotificationWorker(notificationService))
+	river.AddWorker(workers, jobs.NewSendEmailWorker(notifier))
+	river.AddWorker(workers, jobs.NewSendSMSWorker(smsSender))
+	river.AddWorker(workers, jobs.NewSendDigestsWorker(digestService, logger))
+	river.AddWorker(workers, jobs.NewPurgeSessionsWorker(sessionService, logger))
+	river.AddWorker(workers, jobs.NewPublishWebhooksWorker(webhookService))
+	river.AddWorker(workers, jobs.NewDeliverWebhookWorker(webhookService))
+	periodicJobs := []*river.PeriodicJob{
+		jobs.NewRunScheduledTasksPeriodicJob(),
+		jobs.NewSendDigestsPeriodicJob(),
+		jobs.NewPurgeSessionsPeriodicJob(),
+	}
+	if magicLinkService != nil {
+		river.AddWorker(workers, jobs.NewPurgeLoginTokensWorker(magicLinkService, logger))
+		periodicJobs = append(periodicJobs, jobs.NewPurgeLoginTokensPeriodicJob())
+	}
+
+	riverClient, err := storage.InitRiver(ctx, pool, workers, periodicJobs)
+	if err != nil {
+		log.Fatalf("Failed to initialize River: %v", err)
+	}
+	if err := riverClient.Start(ctx); err != nil {
+		log.Fatalf("Failed to start River: %v", err)
+	}
+	log.Println("Started River client")
 
 	// Init Middleware
-	authMiddleware := middleware.NewAuthMiddleware(repo, logger, sessionSecret)
+	authMiddleware := middleware.NewAuthMiddleware(sessionService, apiKeyService, logger, sessionSecret)
 
 	// Setup Router
-	router := web.NewRouter(pool, staticFS, authHandler, ticketHandler, orgHandler, commentHandler, publicViewHandler, scheduledTaskHandler, authMiddleware)
+	router := web.NewRouter(pool, staticFS, authHandler, ticketHandler, orgHandler, commentHandler, publicViewHandler, scheduledTaskHandler, timelineHandler, ticketStatusHandler, ticketPriorityHandler, ticketCategoryHandler, notificationPreferenceHandler, emailTemplateHandler, inboxHandler, webhookHandler, digestHandler, apiKeyHandler, authMiddleware, authorizer, logger)
 
 	// Start Server
 	srv := &http.Server{
@@ -142,6 +317,15 @@ func main() {
 		}
 	}()
 
+	if receiver != nil {
+		go func() {
+			log.Printf("Receiving email on %s", os.Getenv("INBOUND_SMTP_ADDR"))
+			if err := receiver.ListenAndServe(); err != nil {
+				log.Fatalf("Inbound email failed: %v", err)
+			}
+		}()
+	}
+
 	<-ctx.Done()
 	log.Println("Shutting down...")
 
@@ -151,5 +335,13 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if receiver != nil {
+		if err := receiver.Close(); err != nil {
+			log.Printf("Inbound email failed to stop cleanly: %v", err)
+		}
+	}
+	if err := riverClient.Stop(shutdownCtx); err != nil {
+		log.Printf("River failed to stop cleanly: %v", err)
+	}
 	log.Println("Server exited properly")
 }
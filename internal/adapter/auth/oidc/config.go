@@ -0,0 +1,111 @@
+package oidc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GoogleIssuerURL is used for a provider named "google" that sets no issuer.
+const GoogleIssuerURL = "https://accounts.google.com"
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ConfigsFromEnv reads the providers listed in OIDC_PROVIDERS, in order. Each
+// provider NAME is configured by OIDC_<NAME>_ISSUER_URL, _CLIENT_ID,
+// _CLIENT_SECRET, _REDIRECT_URL, and optionally _DISPLAY_NAME, _SCOPES
+// (space or comma separated), _EMAIL_CLAIM, _NAME_CLAIM, _PICTURE_CLAIM and
+// _TRUST_EMAIL. Mapping the email claim requires _TRUST_EMAIL, since only
+// the standard claim is covered by email_verified.
+// Without OIDC_PROVIDERS, GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET and
+// GOOGLE_CALLBACK_URL configure a single Google provider. No configuration
+// at all yields no providers.
+func ConfigsFromEnv(getenv func(string) string) ([]Config, error) {
+	list := getenv("OIDC_PROVIDERS")
+	if strings.TrimSpace(list) == "" {
+		if getenv("GOOGLE_CLIENT_ID") == "" {
+			return nil, nil
+		}
+		return []Config{{
+			Name:         "google",
+			DisplayName:  "Google",
+			IssuerURL:    GoogleIssuerURL,
+			ClientID:     getenv("GOOGLE_CLIENT_ID"),
+			ClientSecret: getenv("GOOGLE_CLIENT_SECRET"),
+			RedirectURL:  getenv("GOOGLE_CALLBACK_URL"),
+		}}, nil
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range splitList(list) {
+		name = strings.ToLower(name)
+		if !providerNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid OIDC provider name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("OIDC provider %q is listed twice", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	var configs []Config
+	for _, name := range names {
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		env := func(key string) string { return strings.TrimSpace(getenv(prefix + key)) }
+		cfg := Config{
+			Name:         name,
+			DisplayName:  env("DISPLAY_NAME"),
+			IssuerURL:    env("ISSUER_URL"),
+			ClientID:     env("CLIENT_ID"),
+			ClientSecret: env("CLIENT_SECRET"),
+			RedirectURL:  env("REDIRECT_URL"),
+			Scopes:       splitList(env("SCOPES")),
+			Claims: ClaimMapping{
+				Email:   env("EMAIL_CLAIM"),
+				Name:    env("NAME_CLAIM"),
+				Picture: env("PICTURE_CLAIM"),
+			},
+		}
+		if trust := env("TRUST_EMAIL"); trust != "" {
+			var err error
+			if cfg.TrustEmail, err = strconv.ParseBool(trust); err != nil {
+				return nil, fmt.Errorf("invalid %sTRUST_EMAIL %q", prefix, trust)
+			}
+		}
+		if cfg.Claims.Email != "" && cfg.Claims.Email != "email" && !cfg.TrustEmail {
+			return nil, fmt.Errorf("OIDC provider %q maps its email claim, which requires %sTRUST_EMAIL", name, prefix)
+		}
+		if cfg.IssuerURL == "" && name == "google" {
+			cfg.IssuerURL = GoogleIssuerURL
+		}
+
+		var missing []string
+		for _, required := range []struct{ key, value string }{
+			{"ISSUER_URL", cfg.IssuerURL},
+			{"CLIENT_ID", cfg.ClientID},
+			{"REDIRECT_URL", cfg.RedirectURL},
+		} {
+			if required.value == "" {
+				missing = append(missing, prefix+required.key)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("OIDC provider %q is missing %s", name, strings.Join(missing, ", "))
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+func splitList(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
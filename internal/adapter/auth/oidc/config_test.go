@@ -0,0 +1,115 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigsFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []Config
+		wantErr string
+	}{
+		{
+			name: "Nothing configured",
+		},
+		{
+			name: "Google variables alone",
+			env: map[string]string{
+				"GOOGLE_CLIENT_ID":     "id",
+				"GOOGLE_CLIENT_SECRET": "secret",
+				"GOOGLE_CALLBACK_URL":  "https://opsdeck.example.org/auth/callback",
+			},
+			want: []Config{{
+				Name:         "google",
+				DisplayName:  "Google",
+				IssuerURL:    GoogleIssuerURL,
+				ClientID:     "id",
+				ClientSecret: "secret",
+				RedirectURL:  "https://opsdeck.example.org/auth/callback",
+			}},
+		},
+		{
+			name: "Several providers in order",
+			env: map[string]string{
+				"OIDC_PROVIDERS":             "Entra, keycloak",
+				"OIDC_ENTRA_DISPLAY_NAME":    "Microsoft",
+				"OIDC_ENTRA_ISSUER_URL":      "https://login.microsoftonline.com/tenant/v2.0",
+				"OIDC_ENTRA_CLIENT_ID":       "entra-id",
+				"OIDC_ENTRA_CLIENT_SECRET":   "entra-secret",
+				"OIDC_ENTRA_REDIRECT_URL":    "https://opsdeck.example.org/auth/callback",
+				"OIDC_ENTRA_EMAIL_CLAIM":     "preferred_username",
+				"OIDC_ENTRA_TRUST_EMAIL":     "true",
+				"OIDC_KEYCLOAK_ISSUER_URL":   "https://sso.example.org/realms/diocese",
+				"OIDC_KEYCLOAK_CLIENT_ID":    "opsdeck",
+				"OIDC_KEYCLOAK_REDIRECT_URL": "https://opsdeck.example.org/auth/callback",
+				"OIDC_KEYCLOAK_SCOPES":       "openid,email profile",
+				"GOOGLE_CLIENT_ID":           "ignored",
+			},
+			want: []Config{
+				{
+					Name:         "entra",
+					DisplayName:  "Microsoft",
+					IssuerURL:    "https://login.microsoftonline.com/tenant/v2.0",
+					ClientID:     "entra-id",
+					ClientSecret: "entra-secret",
+					RedirectURL:  "https://opsdeck.example.org/auth/callback",
+					Claims:       ClaimMapping{Email: "preferred_username"},
+					TrustEmail:   true,
+				},
+				{
+					Name:        "keycloak",
+					IssuerURL:   "https://sso.example.org/realms/diocese",
+					ClientID:    "opsdeck",
+					RedirectURL: "https://opsdeck.example.org/auth/callback",
+					Scopes:      []string{"openid", "email", "profile"},
+				},
+			},
+		},
+		{
+			name: "Missing settings",
+			env: map[string]string{
+				"OIDC_PROVIDERS":          "keycloak",
+				"OIDC_KEYCLOAK_CLIENT_ID": "opsdeck",
+			},
+			wantErr: "OIDC_KEYCLOAK_ISSUER_URL, OIDC_KEYCLOAK_REDIRECT_URL",
+		},
+		{
+			name: "Mapped email claim without trust",
+			env: map[string]string{
+				"OIDC_PROVIDERS":          "entra",
+				"OIDC_ENTRA_ISSUER_URL":   "https://login.microsoftonline.com/tenant/v2.0",
+				"OIDC_ENTRA_CLIENT_ID":    "entra-id",
+				"OIDC_ENTRA_REDIRECT_URL": "https://opsdeck.example.org/auth/callback",
+				"OIDC_ENTRA_EMAIL_CLAIM":  "preferred_username",
+			},
+			wantErr: "requires OIDC_ENTRA_TRUST_EMAIL",
+		},
+		{
+			name:    "Bad name",
+			env:     map[string]string{"OIDC_PROVIDERS": "key.cloak"},
+			wantErr: "invalid OIDC provider name",
+		},
+		{
+			name:    "Listed twice",
+			env:     map[string]string{"OIDC_PROVIDERS": "google,Google"},
+			wantErr: "listed twice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs, err := ConfigsFromEnv(func(key string) string { return tt.env[key] })
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, configs)
+		})
+	}
+}
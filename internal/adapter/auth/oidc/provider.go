@@ -0,0 +1,160 @@
+// Package oidc signs users in with any OpenID Connect provider, such as
+// Google, Microsoft Entra ID or Keycloak.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	coreoidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"golang.org/x/oauth2"
+)
+
+// Ensure Provider implements port.OIDCProvider
+var _ port.OIDCProvider = (*Provider)(nil)
+
+// DefaultScopes are requested when a provider is configured without scopes.
+var DefaultScopes = []string{coreoidc.ScopeOpenID, "profile", "email"}
+
+// ClaimMapping names the ID token claims user details are read from. Empty
+// fields use the standard claims: "email", "name" and "picture".
+type ClaimMapping struct {
+	Email   string
+	Name    string
+	Picture string
+}
+
+// Config describes one provider.
+type Config struct {
+	// Name identifies the provider in /auth/login?provider=.
+	Name string
+	// DisplayName is shown on the sign-in button. It defaults to Name.
+	DisplayName  string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is this server's /auth/callback as registered with the
+	// provider.
+	RedirectURL string
+	Scopes      []string
+	Claims      ClaimMapping
+	// TrustEmail accepts the email claim without email_verified. Accounts
+	// are matched by email, so set it only for an issuer that controls
+	// every address it hands out, such as a single-tenant Entra ID.
+	TrustEmail bool
+}
+
+type Provider struct {
+	name        string
+	displayName string
+	claims      ClaimMapping
+	trustEmail  bool
+	verifier    *coreoidc.IDTokenVerifier
+	oauthConfig *oauth2.Config
+}
+
+// NewProvider discovers the provider's endpoints from its issuer URL.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.Name == "" || cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc provider %q: name, issuer URL, client ID and redirect URL are required", cfg.Name)
+	}
+	provider, err := coreoidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc provider %q: %w", cfg.Name, err)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	claims := cfg.Claims
+	if claims.Email == "" {
+		claims.Email = "email"
+	}
+	if claims.Name == "" {
+		claims.Name = "name"
+	}
+	if claims.Picture == "" {
+		claims.Picture = "picture"
+	}
+	displayName := cfg.DisplayName
+	if displayName == "" {
+		displayName = cfg.Name
+	}
+
+	return &Provider{
+		name:        cfg.Name,
+		displayName: displayName,
+		claims:      claims,
+		trustEmail:  cfg.TrustEmail,
+		verifier:    provider.Verifier(&coreoidc.Config{ClientID: cfg.ClientID}),
+		oauthConfig: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+	}, nil
+}
+
+func (p *Provider) Name() string        { return p.name }
+func (p *Provider) DisplayName() string { return p.displayName }
+
+func (p *Provider) AuthCodeURL(state string) string {
+	return p.oauthConfig.AuthCodeURL(state)
+}
+
+// ExchangeCode redeems the code and reads the user from the verified ID
+// token. Accounts are matched by email, so unless the provider is
+// configured to be trusted, the email must come from the standard claim
+// with email_verified set. Otherwise one provider could sign in as the
+// account another provider verified.
+func (p *Provider) ExchangeCode(ctx context.Context, code string) (*port.UserInfo, error) {
+	oauth2Token, err := p.oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("no id_token field in oauth2 token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %w", err)
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claims: %w", err)
+	}
+
+	if !p.trustEmail {
+		if p.claims.Email != "email" {
+			return nil, fmt.Errorf("%q claim is not a verified email address", p.claims.Email)
+		}
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return nil, errors.New("email address is not verified")
+		}
+	}
+	email := strings.TrimSpace(stringClaim(claims, p.claims.Email))
+	if email == "" {
+		return nil, fmt.Errorf("id token has no %q claim", p.claims.Email)
+	}
+
+	return &port.UserInfo{
+		Email:     email,
+		Name:      stringClaim(claims, p.claims.Name),
+		AvatarURL: stringClaim(claims, p.claims.Picture),
+	}, nil
+}
+
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
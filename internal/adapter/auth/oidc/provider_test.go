@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	coreoidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/coreos/go-oidc/v3/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// mockIssuer is a local OIDC issuer. It serves discovery and keys through
+// oidctest and answers the token endpoint with an ID token carrying claims.
+type mockIssuer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any
+	codes  []string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockIssuer{key: key}
+	discovery := &oidctest.Server{
+		PublicKeys: []oidctest.PublicKey{{PublicKey: key.Public(), KeyID: "test-key", Algorithm: coreoidc.RS256}},
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", discovery)
+	mux.HandleFunc("/token", m.serveToken)
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	discovery.SetIssuer(m.URL)
+	return m
+}
+
+func (m *mockIssuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.codes = append(m.codes, r.PostForm.Get("code"))
+
+	claims := map[string]any{
+		"iss": m.URL,
+		"aud": "opsdeck",
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+	raw, _ := json.Marshal(claims)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     oidctest.SignIDToken(m.key, "test-key", coreoidc.RS256, string(raw)),
+	})
+}
+
+func (m *mockIssuer) config() Config {
+	return Config{
+		Name:         "keycloak",
+		IssuerURL:    m.URL,
+		ClientID:     "opsdeck",
+		ClientSecret: "secret",
+		RedirectURL:  "https://opsdeck.example.org/auth/callback",
+	}
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Login URL points at the issuer", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		cfg := issuer.config()
+		cfg.Scopes = []string{"openid", "email", "offline_access"}
+		p, err := NewProvider(ctx, cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "keycloak", p.Name())
+		assert.Equal(t, "keycloak", p.DisplayName())
+
+		u, err := url.Parse(p.AuthCodeURL("keycloak.abc"))
+		require.NoError(t, err)
+		assert.Equal(t, issuer.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+		q := u.Query()
+		assert.Equal(t, "keycloak.abc", q.Get("state"))
+		assert.Equal(t, "opsdeck", q.Get("client_id"))
+		assert.Equal(t, "openid email offline_access", q.Get("scope"))
+		assert.Equal(t, cfg.RedirectURL, q.Get("redirect_uri"))
+	})
+
+	tests := []struct {
+		name    string
+		claims  ClaimMapping
+		trust   bool
+		token   map[string]any
+		want    *port.UserInfo
+		wantErr string
+	}{
+		{
+			name:  "Standard claims",
+			token: map[string]any{"email": "sam@example.com", "email_verified": true, "name": "Sam", "picture": "https://example.com/sam.png"},
+			want:  &port.UserInfo{Email: "sam@example.com", Name: "Sam", AvatarURL: "https://example.com/sam.png"},
+		},
+		{
+			name:   "Mapped claims from a trusted provider",
+			claims: ClaimMapping{Email: "preferred_username", Name: "name"},
+			trust:  true,
+			token:  map[string]any{"preferred_username": "sam@diocese.org", "name": "Sam"},
+			want:   &port.UserInfo{Email: "sam@diocese.org", Name: "Sam"},
+		},
+		{
+			name:    "Mapped claims from an untrusted provider",
+			claims:  ClaimMapping{Email: "preferred_username"},
+			token:   map[string]any{"preferred_username": "admin@parish.org", "email_verified": true},
+			wantErr: "not a verified email address",
+		},
+		{
+			name:    "No email_verified",
+			token:   map[string]any{"email": "admin@parish.org"},
+			wantErr: "not verified",
+		},
+		{
+			name:  "No email_verified from a trusted provider",
+			trust: true,
+			token: map[string]any{"email": "sam@diocese.org"},
+			want:  &port.UserInfo{Email: "sam@diocese.org"},
+		},
+		{
+			name:    "Unverified email",
+			token:   map[string]any{"email": "sam@example.com", "email_verified": false},
+			wantErr: "not verified",
+		},
+		{
+			name:    "No email",
+			trust:   true,
+			token:   map[string]any{"name": "Sam"},
+			wantErr: `no "email" claim`,
+		},
+		{
+			name:    "Token for another client",
+			token:   map[string]any{"email": "sam@example.com", "aud": "someone-else"},
+			wantErr: "failed to verify id token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			issuer.claims = tt.token
+			cfg := issuer.config()
+			cfg.Claims = tt.claims
+			cfg.TrustEmail = tt.trust
+			p, err := NewProvider(ctx, cfg)
+			require.NoError(t, err)
+
+			info, err := p.ExchangeCode(ctx, "test-code")
+			assert.Equal(t, []string{"test-code"}, issuer.codes)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, info)
+		})
+	}
+
+	t.Run("Unreachable issuer", func(t *testing.T) {
+		issuer := newMockIssuer(t)
+		cfg := issuer.config()
+		issuer.Close()
+		_, err := NewProvider(ctx, cfg)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/adapter/email/smtptest"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+func readMessage(t *testing.T, data []byte) (*mail.Message, string) {
+	t.Helper()
+	m, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+	require.NoError(t, err)
+	return m, string(body)
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	ctx := context.Background()
+	server := smtptest.NewServer(t)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{
+		Host: server.Host(),
+		Port: server.Port(),
+		From: "OpsDeck <noreply@opsdeck.test>",
+	})
+	require.NoError(t, err)
+
+	t.Run("Delivers message", func(t *testing.T) {
+		err := notifier.Send(ctx, domain.EmailMessage{
+			To:      "reporter@example.com",
+			Subject: "[Parish] Status changed to Done: Leaky faucet",
+			Body:    "The status changed from New to Done.\n.\nTicket ID: 1\n",
+		})
+		require.NoError(t, err)
+
+		messages := server.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "noreply@opsdeck.test", messages[0].From)
+		assert.Equal(t, []string{"reporter@example.com"}, messages[0].To)
+
+		m, body := readMessage(t, messages[0].Data)
+		subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "[Parish] Status changed to Done: Leaky faucet", subject)
+		assert.Equal(t, "auto-generated", m.Header.Get("Auto-Submitted"))
+		assert.Equal(t, "The status changed from New to Done.\r\n.\r\nTicket ID: 1\r\n", body)
+	})
+
+	t.Run("HTML is sent as an alternative part", func(t *testing.T) {
+		err := notifier.Send(ctx, domain.EmailMessage{
+			To:       "reporter@example.com",
+			Subject:  "New comment",
+			Body:     "Plumber booked for Tuesday",
+			HTMLBody: "<p>Plumber booked for <strong>Tuesday</strong></p>",
+		})
+		require.NoError(t, err)
+
+		messages := server.Messages()
+		m, err := mail.ReadMessage(bytes.NewReader(messages[len(messages)-1].Data))
+		require.NoError(t, err)
+		mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		var parts []string
+		r := multipart.NewReader(m.Body, params["boundary"])
+		for {
+			p, err := r.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(p)
+			require.NoError(t, err)
+			parts = append(parts, p.Header.Get("Content-Type")+": "+string(body))
+		}
+		assert.Equal(t, []string{
+			`text/plain; charset="utf-8": Plumber booked for Tuesday`,
+			`text/html; charset="utf-8": <p>Plumber booked for <strong>Tuesday</strong></p>`,
+		}, parts)
+	})
+
+	t.Run("Header injection is neutralized", func(t *testing.T) {
+		err := notifier.Send(ctx, domain.EmailMessage{
+			To:      "reporter@example.com",
+			Subject: "Hello\r\nBcc: victim@example.com",
+			Body:    "body",
+		})
+		require.NoError(t, err)
+
+		messages := server.Messages()
+		m, _ := readMessage(t, messages[len(messages)-1].Data)
+		assert.Empty(t, m.Header.Get("Bcc"))
+	})
+
+	t.Run("Rejected recipient is an error", func(t *testing.T) {
+		server.RejectRecipients(true)
+		defer server.RejectRecipients(false)
+
+		err := notifier.Send(ctx, domain.EmailMessage{To: "reporter@example.com", Subject: "s", Body: "b"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid recipient is an error", func(t *testing.T) {
+		err := notifier.Send(ctx, domain.EmailMessage{To: "not an address", Subject: "s", Body: "b"})
+		assert.Error(t, err)
+	})
+}
+
+func TestLogNotifier_WritesEML(t *testing.T) {
+	dir := t.TempDir()
+	notifier, err := NewLogNotifier(slog.New(slog.NewTextHandler(io.Discard, nil)), "noreply@opsdeck.test", dir)
+	require.NoError(t, err)
+
+	err = notifier.Send(context.Background(), domain.EmailMessage{
+		To:      "staff@example.com",
+		Subject: "Assigned to you",
+		Body:    "Please take a look.",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	m, body := readMessage(t, data)
+	assert.Equal(t, "<staff@example.com>", m.Header.Get("To"))
+	assert.Equal(t, "Please take a look.", body)
+}
@@ -0,0 +1,55 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// LogNotifier implements port.Notifier for local development. It logs every
+// email and, when dir is set, also writes it there as an .eml file that any
+// mail client can open.
+type LogNotifier struct {
+	logger *slog.Logger
+	from   *mail.Address
+	dir    string
+}
+
+func NewLogNotifier(logger *slog.Logger, from string, dir string) (*LogNotifier, error) {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", from, err)
+	}
+	return &LogNotifier{logger: logger, from: addr, dir: dir}, nil
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg domain.EmailMessage) error {
+	now := time.Now()
+	data, err := buildMessage(n.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if n.dir == "" {
+		n.logger.Info("email", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+		return nil
+	}
+
+	if err := os.MkdirAll(n.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.New())
+	path := filepath.Join(n.dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	n.logger.Info("email", "to", msg.To, "subject", msg.Subject, "file", path)
+	return nil
+}
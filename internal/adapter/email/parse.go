@@ -0,0 +1,178 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// maxMIMEDepth bounds how deeply multipart bodies may nest.
+const maxMIMEDepth = 10
+
+// parseMessage decodes a raw RFC 5322 message into an InboundEmail without a
+// mailbox. The first text/plain part becomes the text; when there is none the
+// first text/html part is converted instead. Parts with a filename or an
+// attachment disposition become attachments.
+func parseMessage(data []byte) (domain.InboundEmail, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return domain.InboundEmail{}, fmt.Errorf("failed to read message: %w", err)
+	}
+
+	var msg domain.InboundEmail
+	if from, err := m.Header.AddressList("From"); err == nil && len(from) > 0 {
+		msg.From = strings.ToLower(from[0].Address)
+		msg.FromName = validUTF8(from[0].Name)
+	}
+	msg.Subject = decodeHeader(m.Header.Get("Subject"))
+	msg.AutoSubmitted = isAutoSubmitted(m.Header)
+
+	p := &mimeParts{}
+	if err := p.walk(textproto.MIMEHeader(m.Header), m.Body, 0); err != nil {
+		return domain.InboundEmail{}, err
+	}
+	msg.Text = p.text
+	if msg.Text == "" && p.html != "" {
+		msg.Text = htmlToText(p.html)
+	}
+	msg.Attachments = p.files
+	return msg, nil
+}
+
+type mimeParts struct {
+	text  string
+	html  string
+	files []domain.File
+}
+
+func (p *mimeParts) walk(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxMIMEDepth {
+		return fmt.Errorf("message nests too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		r := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := r.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read part: %w", err)
+			}
+			if err := p.walk(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("failed to decode part: %w", err)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if disposition == "attachment" || filename != "" {
+		if filename == "" {
+			filename = "attachment"
+		}
+		p.files = append(p.files, domain.File{
+			Filename:    validUTF8(decodeHeader(filename)),
+			ContentType: mediaType,
+			Size:        int64(len(data)),
+			Data:        data,
+		})
+		return nil
+	}
+
+	switch {
+	case mediaType == "text/plain" && p.text == "":
+		p.text = validUTF8(string(data))
+	case mediaType == "text/html" && p.html == "":
+		p.html = validUTF8(string(data))
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// decodeHeader decodes RFC 2047 encoded words, leaving the value as it is if
+// it uses a charset we cannot read.
+func decodeHeader(s string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(s)
+	if err != nil {
+		return validUTF8(s)
+	}
+	return validUTF8(decoded)
+}
+
+// isAutoSubmitted recognizes auto-replies, bounces and mailing-list traffic
+// (RFC 3834), which must not be answered.
+func isAutoSubmitted(h mail.Header) bool {
+	if v := strings.ToLower(h.Get("Auto-Submitted")); v != "" && v != "no" {
+		return true
+	}
+	switch strings.ToLower(h.Get("Precedence")) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	if h.Get("List-Id") != "" || h.Get("X-Autoreply") != "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType == "multipart/report"
+}
+
+var (
+	htmlHiddenPattern = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces an HTML body to readable plain text.
+func htmlToText(s string) string {
+	s = htmlHiddenPattern.ReplaceAllString(s, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	s = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(s)
+}
+
+func validUTF8(s string) string {
+	return strings.ToValidUTF8(s, "�")
+}
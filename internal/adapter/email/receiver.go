@@ -0,0 +1,346 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// DefaultMaxMessageBytes matches the attachment limits of common mail
+	// providers.
+	DefaultMaxMessageBytes = 25 << 20
+	// DefaultMaxConnections bounds open sessions. Further connections are
+	// turned away with a 421 so that the sender retries later.
+	DefaultMaxConnections = 100
+	// DefaultMaxConcurrentMessages bounds how many messages are buffered at
+	// once, each up to MaxMessageBytes.
+	DefaultMaxConcurrentMessages = 4
+	maxCommandLine               = 4096
+	commandTimeout               = 5 * time.Minute
+	busyReplyTimeout             = 5 * time.Second
+)
+
+// ReceiverConfig configures the inbound SMTP listener. Mail is accepted for
+// <slug>@Domain, where slug is an organization's slug.
+type ReceiverConfig struct {
+	Addr                  string
+	Domain                string
+	MaxMessageBytes       int64
+	MaxConnections        int
+	MaxConcurrentMessages int
+}
+
+// Receiver is a minimal SMTP server that hands mail for organization
+// mailboxes to an InboundEmailService. It does not relay and offers neither
+// STARTTLS nor AUTH; run it behind an MTA when it must face the internet
+// directly.
+// Open sessions and buffered messages are capped, so unauthenticated
+// senders cannot exhaust memory.
+type Receiver struct {
+	cfg     ReceiverConfig
+	service port.InboundEmailService
+	logger  *slog.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// conns and messages are semaphores for open sessions and messages
+	// being buffered.
+	conns    chan struct{}
+	messages chan struct{}
+
+	mu       sync.Mutex
+	listener net.Listener
+}
+
+func NewReceiver(cfg ReceiverConfig, service port.InboundEmailService, logger *slog.Logger) (*Receiver, error) {
+	cfg.Domain = strings.ToLower(strings.TrimSpace(cfg.Domain))
+	if cfg.Domain == "" {
+		return nil, errors.New("inbound email domain is required")
+	}
+	if cfg.MaxMessageBytes <= 0 {
+		cfg.MaxMessageBytes = DefaultMaxMessageBytes
+	}
+	if cfg.MaxConnections <= 0 {
+		cfg.MaxConnections = DefaultMaxConnections
+	}
+	if cfg.MaxConcurrentMessages <= 0 {
+		cfg.MaxConcurrentMessages = DefaultMaxConcurrentMessages
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Receiver{
+		cfg:      cfg,
+		service:  service,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		conns:    make(chan struct{}, cfg.MaxConnections),
+		messages: make(chan struct{}, cfg.MaxConcurrentMessages),
+	}, nil
+}
+
+// ListenAndServe listens on cfg.Addr and serves until Close is called.
+func (r *Receiver) ListenAndServe() error {
+	ln, err := net.Listen("tcp", r.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return r.Serve(ln)
+}
+
+// Serve accepts connections on ln until Close is called.
+func (r *Receiver) Serve(ln net.Listener) error {
+	r.mu.Lock()
+	r.listener = ln
+	r.mu.Unlock()
+	if r.ctx.Err() != nil {
+		return ln.Close()
+	}
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if r.ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept: %w", err)
+		}
+		r.wg.Add(1)
+		select {
+		case r.conns <- struct{}{}:
+			go func() {
+				defer r.wg.Done()
+				defer func() { <-r.conns }()
+				defer conn.Close() //nolint:errcheck // the session is over either way
+				r.handle(conn)
+			}()
+		default:
+			go func() {
+				defer r.wg.Done()
+				defer conn.Close() //nolint:errcheck // the session is over either way
+				_ = conn.SetWriteDeadline(time.Now().Add(busyReplyTimeout))
+				_, _ = fmt.Fprintf(conn, "421 4.3.2 %s too many connections, try again later\r\n", r.cfg.Domain)
+			}()
+		}
+	}
+}
+
+// Close stops accepting mail and waits for open sessions to finish.
+func (r *Receiver) Close() error {
+	r.cancel()
+	r.mu.Lock()
+	ln := r.listener
+	r.mu.Unlock()
+	var err error
+	if ln != nil {
+		err = ln.Close()
+	}
+	r.wg.Wait()
+	return err
+}
+
+// session is the state of one SMTP transaction. It carries a single
+// mailbox: the reply to DATA covers every recipient, so delivering to a
+// second mailbox after the first had committed could not be retried
+// without duplicating the first ticket.
+type session struct {
+	from    string
+	mailbox string
+}
+
+func (r *Receiver) handle(conn net.Conn) {
+	br := bufio.NewReaderSize(conn, maxCommandLine)
+	reply := func(format string, args ...any) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(commandTimeout))
+		_, err := fmt.Fprintf(conn, format+"\r\n", args...)
+		return err == nil
+	}
+
+	if !reply("220 %s ESMTP OpsDeck", r.cfg.Domain) {
+		return
+	}
+
+	var s *session
+	for {
+		if r.ctx.Err() != nil {
+			reply("421 4.3.2 shutting down")
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(commandTimeout))
+		line, err := br.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			reply("500 5.5.2 line too long")
+			return
+		}
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(string(line), "\r\n")
+		verb, arg, _ := strings.Cut(cmd, " ")
+		verb = strings.ToUpper(verb)
+
+		switch verb {
+		case "EHLO":
+			reply("250-%s", r.cfg.Domain)
+			reply("250-SIZE %d", r.cfg.MaxMessageBytes)
+			reply("250-8BITMIME")
+			reply("250 PIPELINING")
+		case "HELO":
+			reply("250 %s", r.cfg.Domain)
+		case "MAIL":
+			addr, ok := pathArg(arg, "FROM:")
+			if !ok {
+				reply("501 5.5.4 syntax: MAIL FROM:<address>")
+				continue
+			}
+			s = &session{from: addr}
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			if s == nil {
+				reply("503 5.5.1 need MAIL first")
+				continue
+			}
+			addr, ok := pathArg(arg, "TO:")
+			if !ok {
+				reply("501 5.5.4 syntax: RCPT TO:<address>")
+				continue
+			}
+			mailbox, ok := r.mailbox(addr)
+			if !ok {
+				reply("550 5.7.1 relaying denied")
+				continue
+			}
+			// The sender retries deferred recipients in a later
+			// transaction, so each mailbox gets its own.
+			if s.mailbox != "" && s.mailbox != mailbox {
+				reply("452 4.5.3 one mailbox per message, try again for this recipient")
+				continue
+			}
+			s.mailbox = mailbox
+			reply("250 2.1.5 OK")
+		case "DATA":
+			if s == nil || s.mailbox == "" {
+				reply("503 5.5.1 need RCPT first")
+				continue
+			}
+			select {
+			case r.messages <- struct{}{}:
+			default:
+				s = nil
+				reply("451 4.3.2 too busy, try again later")
+				continue
+			}
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				<-r.messages
+				return
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(commandTimeout))
+			code, ok := r.receive(br, s)
+			<-r.messages
+			s = nil
+			if !reply("%s", code) || !ok {
+				return
+			}
+		case "RSET":
+			s = nil
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "VRFY":
+			reply("252 2.1.5 cannot verify")
+		case "QUIT":
+			reply("221 2.0.0 bye")
+			return
+		default:
+			reply("502 5.5.2 command not implemented")
+		}
+	}
+}
+
+// receive reads the message body and delivers it to the session's
+// mailbox. It returns the reply and whether the connection can continue.
+func (r *Receiver) receive(br *bufio.Reader, s *session) (string, bool) {
+	dot := textproto.NewReader(br).DotReader()
+	data, err := io.ReadAll(io.LimitReader(dot, r.cfg.MaxMessageBytes+1))
+	if err != nil {
+		return "451 4.3.0 failed to read message", false
+	}
+	if int64(len(data)) > r.cfg.MaxMessageBytes {
+		if _, err := io.Copy(io.Discard, dot); err != nil {
+			return "451 4.3.0 failed to read message", false
+		}
+		return "552 5.3.4 message too large", true
+	}
+
+	msg, err := parseMessage(data)
+	if err != nil {
+		return "554 5.6.0 malformed message", true
+	}
+	if msg.From == "" {
+		msg.From = strings.ToLower(s.from)
+	}
+	// Bounces are sent with a null return path.
+	if s.from == "" {
+		msg.AutoSubmitted = true
+	}
+
+	msg.Mailbox = s.mailbox
+	if err := r.service.Receive(r.ctx, msg); err != nil {
+		switch {
+		case errors.Is(err, port.ErrUnknownMailbox):
+			return "550 5.1.1 no such mailbox", true
+		case errors.Is(err, port.ErrSenderRejected):
+			return "550 5.7.1 sender not accepted", true
+		}
+		r.logger.Error("failed to receive email", "mailbox", s.mailbox, "error", err)
+		return "451 4.3.0 temporary failure, try again later", true
+	}
+	return "250 2.0.0 OK", true
+}
+
+// mailbox returns the organization slug addr is for, ignoring any +tag, or
+// false if addr is not in the receiver's domain.
+func (r *Receiver) mailbox(addr string) (string, bool) {
+	local, domain, ok := strings.Cut(addr, "@")
+	if !ok || local == "" || strings.ToLower(domain) != r.cfg.Domain {
+		return "", false
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return strings.ToLower(local), true
+}
+
+// pathArg extracts the address from "FROM:<addr> PARAMS". The null path "<>"
+// yields an empty address.
+func pathArg(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	path := strings.TrimSpace(arg[len(prefix):])
+	if i := strings.IndexByte(path, ' '); i >= 0 {
+		path = path[:i]
+	}
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+	path = path[1 : len(path)-1]
+	if path == "" {
+		return "", prefix == "FROM:"
+	}
+	if _, err := mail.ParseAddress(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
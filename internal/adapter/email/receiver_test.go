@@ -0,0 +1,212 @@
+package email
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const multipartMessage = "From: =?utf-8?q?Jos=C3=A9_Parishioner?= <Jose@Example.com>\r\n" +
+	"To: st-mark@tickets.example.org\r\n" +
+	"Subject: =?utf-8?q?Broken_light_in_the_narthex?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"The light by the door flickers=E2=80=94it has for a week.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>The light by the door flickers</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png; name=\"light.png\"\r\n" +
+	"Content-Disposition: attachment; filename=\"light.png\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgo=\r\n" +
+	"--outer--\r\n"
+
+func TestParseMessage(t *testing.T) {
+	t.Run("Multipart with attachment", func(t *testing.T) {
+		msg, err := parseMessage([]byte(multipartMessage))
+		require.NoError(t, err)
+		assert.Equal(t, "jose@example.com", msg.From)
+		assert.Equal(t, "José Parishioner", msg.FromName)
+		assert.Equal(t, "Broken light in the narthex", msg.Subject)
+		assert.Equal(t, "The light by the door flickers—it has for a week.", strings.TrimSpace(msg.Text))
+		assert.False(t, msg.AutoSubmitted)
+		if assert.Len(t, msg.Attachments, 1) {
+			assert.Equal(t, "light.png", msg.Attachments[0].Filename)
+			assert.Equal(t, "image/png", msg.Attachments[0].ContentType)
+			assert.Equal(t, []byte("\x89PNG\r\n\x1a\n"), msg.Attachments[0].Data)
+			assert.Equal(t, int64(8), msg.Attachments[0].Size)
+		}
+	})
+
+	t.Run("HTML only", func(t *testing.T) {
+		msg, err := parseMessage([]byte("From: pat@example.com\r\n" +
+			"Subject: Heat\r\n" +
+			"Content-Type: text/html\r\n" +
+			"\r\n" +
+			"<html><head><style>p{}</style></head><body><p>No heat in the office.</p><p>Since Monday &amp; still cold.</p></body></html>\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "No heat in the office.\nSince Monday & still cold.", msg.Text)
+	})
+
+	t.Run("Auto-replies are flagged", func(t *testing.T) {
+		msg, err := parseMessage([]byte("From: pat@example.com\r\n" +
+			"Subject: Out of office\r\n" +
+			"Auto-Submitted: auto-replied\r\n" +
+			"\r\n" +
+			"I am away.\r\n"))
+		require.NoError(t, err)
+		assert.True(t, msg.AutoSubmitted)
+	})
+}
+
+// fakeInboundService records what the receiver delivers.
+type fakeInboundService struct {
+	mu       sync.Mutex
+	received []domain.InboundEmail
+}
+
+func (s *fakeInboundService) Receive(ctx context.Context, msg domain.InboundEmail) error {
+	switch msg.Mailbox {
+	case "unknown":
+		return port.ErrUnknownMailbox
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received = append(s.received, msg)
+	return nil
+}
+
+func (s *fakeInboundService) messages() []domain.InboundEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.InboundEmail(nil), s.received...)
+}
+
+func TestReceiver(t *testing.T) {
+	service := &fakeInboundService{}
+	receiver, err := NewReceiver(ReceiverConfig{Domain: "Tickets.Example.org", MaxMessageBytes: 4096}, service, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go receiver.Serve(ln) //nolint:errcheck // stopped by Close
+	t.Cleanup(func() { _ = receiver.Close() })
+	addr := ln.Addr().String()
+
+	t.Run("Delivers to the organization mailbox", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jose@example.com", []string{"St-Mark+office@tickets.example.org"}, []byte(multipartMessage))
+		require.NoError(t, err)
+
+		received := service.messages()
+		require.Len(t, received, 1)
+		assert.Equal(t, "st-mark", received[0].Mailbox)
+		assert.Equal(t, "Broken light in the narthex", received[0].Subject)
+		assert.Len(t, received[0].Attachments, 1)
+	})
+
+	t.Run("Other domains are not relayed", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jose@example.com", []string{"someone@example.net"}, []byte(multipartMessage))
+		assert.ErrorContains(t, err, "550")
+	})
+
+	t.Run("Unknown mailboxes are rejected", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jose@example.com", []string{"unknown@tickets.example.org"}, []byte(multipartMessage))
+		assert.ErrorContains(t, err, "550")
+	})
+
+	t.Run("Each message is delivered to one mailbox", func(t *testing.T) {
+		before := len(service.messages())
+		conn := dialSMTP(t, addr, 220)
+		smtpCmd(t, conn, 250, "MAIL FROM:<jose@example.com>")
+		smtpCmd(t, conn, 250, "RCPT TO:<st-mark@tickets.example.org>")
+		smtpCmd(t, conn, 452, "RCPT TO:<st-luke@tickets.example.org>")
+		smtpCmd(t, conn, 250, "RCPT TO:<st-mark+office@tickets.example.org>")
+		smtpCmd(t, conn, 354, "DATA")
+		w := conn.DotWriter()
+		_, err := w.Write([]byte(multipartMessage))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		_, _, err = conn.ReadResponse(250)
+		require.NoError(t, err)
+
+		received := service.messages()[before:]
+		require.Len(t, received, 1)
+		assert.Equal(t, "st-mark", received[0].Mailbox)
+	})
+
+	t.Run("Oversized messages are rejected", func(t *testing.T) {
+		big := "From: jose@example.com\r\nSubject: Big\r\n\r\n" + strings.Repeat("x", 5000) + "\r\n"
+		err := smtp.SendMail(addr, nil, "jose@example.com", []string{"st-mark@tickets.example.org"}, []byte(big))
+		assert.ErrorContains(t, err, "552")
+	})
+}
+
+// dialSMTP connects to the receiver and reads its greeting.
+func dialSMTP(t *testing.T, addr string, wantCode int) *textproto.Conn {
+	t.Helper()
+	conn, err := textproto.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	_, _, err = conn.ReadResponse(wantCode)
+	require.NoError(t, err)
+	return conn
+}
+
+// smtpCmd sends one command and checks the reply code.
+func smtpCmd(t *testing.T, conn *textproto.Conn, wantCode int, line string) {
+	t.Helper()
+	id, err := conn.Cmd("%s", line)
+	require.NoError(t, err)
+	conn.StartResponse(id)
+	defer conn.EndResponse(id)
+	_, _, err = conn.ReadResponse(wantCode)
+	require.NoError(t, err)
+}
+
+func TestReceiverLimits(t *testing.T) {
+	receiver, err := NewReceiver(ReceiverConfig{Domain: "tickets.example.org", MaxConnections: 2, MaxConcurrentMessages: 1}, &fakeInboundService{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go receiver.Serve(ln) //nolint:errcheck // stopped by Close
+	t.Cleanup(func() { _ = receiver.Close() })
+	addr := ln.Addr().String()
+
+	// The first session holds the only message slot by starting DATA.
+	first := dialSMTP(t, addr, 220)
+	smtpCmd(t, first, 250, "MAIL FROM:<jose@example.com>")
+	smtpCmd(t, first, 250, "RCPT TO:<st-mark@tickets.example.org>")
+	smtpCmd(t, first, 354, "DATA")
+
+	// A second session is allowed in but cannot buffer a message too.
+	second := dialSMTP(t, addr, 220)
+	smtpCmd(t, second, 250, "MAIL FROM:<jose@example.com>")
+	smtpCmd(t, second, 250, "RCPT TO:<st-mark@tickets.example.org>")
+	smtpCmd(t, second, 451, "DATA")
+
+	// A third session is turned away.
+	dialSMTP(t, addr, 421)
+}
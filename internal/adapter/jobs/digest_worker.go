@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// DigestPollInterval is how often due digests are checked. Digests go out at
+// most this long after the hour they are due.
+const DigestPollInterval = 15 * time.Minute
+
+// SendDigestsArgs is the periodic job that enqueues the digests that have
+// fallen due.
+type SendDigestsArgs struct{}
+
+func (SendDigestsArgs) Kind() string { return "send_digests" }
+
+type SendDigestsWorker struct {
+	river.WorkerDefaults[SendDigestsArgs]
+	service port.DigestService
+	logger  *slog.Logger
+}
+
+func NewSendDigestsWorker(service port.DigestService, logger *slog.Logger) *SendDigestsWorker {
+	return &SendDigestsWorker{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (w *SendDigestsWorker) Work(ctx context.Context, job *river.Job[SendDigestsArgs]) error {
+	sent, err := w.service.SendDue(ctx, time.Now())
+	if sent > 0 {
+		w.logger.Info("sent digests", "count", sent)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send digests: %w", err)
+	}
+	return nil
+}
+
+// NewSendDigestsPeriodicJob returns the periodic job definition for
+// SendDigestsArgs. Each digest is claimed in the transaction that enqueues
+// it, so overlapping runs never send one twice.
+func NewSendDigestsPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(DigestPollInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return SendDigestsArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
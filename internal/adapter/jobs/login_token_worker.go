@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// LoginTokenPurgeInterval is how often old sign-in links are deleted.
+const LoginTokenPurgeInterval = time.Hour
+
+// PurgeLoginTokensArgs is the periodic job that deletes old sign-in links.
+type PurgeLoginTokensArgs struct{}
+
+func (PurgeLoginTokensArgs) Kind() string { return "purge_login_tokens" }
+
+type PurgeLoginTokensWorker struct {
+	river.WorkerDefaults[PurgeLoginTokensArgs]
+	service port.MagicLinkService
+	logger  *slog.Logger
+}
+
+func NewPurgeLoginTokensWorker(service port.MagicLinkService, logger *slog.Logger) *PurgeLoginTokensWorker {
+	return &PurgeLoginTokensWorker{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (w *PurgeLoginTokensWorker) Work(ctx context.Context, job *river.Job[PurgeLoginTokensArgs]) error {
+	purged, err := w.service.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to purge login tokens: %w", err)
+	}
+	if purged > 0 {
+		w.logger.Info("purged old login tokens", "count", purged)
+	}
+	return nil
+}
+
+// NewPurgeLoginTokensPeriodicJob returns the periodic job definition for
+// PurgeLoginTokensArgs.
+func NewPurgeLoginTokensPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(LoginTokenPurgeInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return PurgeLoginTokensArgs{}, nil
+		},
+		nil,
+	)
+}
@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/adapter/storage/postgres"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// DispatchNotificationArgs is the job that resolves the recipients of a notification.
+type DispatchNotificationArgs struct {
+	Notification domain.Notification `json:"notification"`
+}
+
+func (DispatchNotificationArgs) Kind() string { return "dispatch_notification" }
+
+type DispatchNotificationWorker struct {
+	river.WorkerDefaults[DispatchNotificationArgs]
+	service port.NotificationService
+}
+
+func NewDispatchNotificationWorker(service port.NotificationService) *DispatchNotificationWorker {
+	return &DispatchNotificationWorker{service: service}
+}
+
+func (w *DispatchNotificationWorker) Work(ctx context.Context, job *river.Job[DispatchNotificationArgs]) error {
+	if err := w.service.Dispatch(ctx, job.Args.Notification); err != nil {
+		return fmt.Errorf("failed to dispatch notification: %w", err)
+	}
+	return nil
+}
+
+// SendEmailArgs is the job that delivers a single email. Failed deliveries are
+// retried with River's default backoff.
+type SendEmailArgs struct {
+	Message domain.EmailMessage `json:"message"`
+}
+
+func (SendEmailArgs) Kind() string { return "send_email" }
+
+type SendEmailWorker struct {
+	river.WorkerDefaults[SendEmailArgs]
+	notifier port.Notifier
+}
+
+func NewSendEmailWorker(notifier port.Notifier) *SendEmailWorker {
+	return &SendEmailWorker{notifier: notifier}
+}
+
+func (w *SendEmailWorker) Work(ctx context.Context, job *river.Job[SendEmailArgs]) error {
+	if err := w.notifier.Send(ctx, job.Args.Message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// SendSMSArgs is the job that delivers a single text message. Failed
+// deliveries are retried with River's default backoff.
+type SendSMSArgs struct {
+	Message domain.SMSMessage `json:"message"`
+}
+
+func (SendSMSArgs) Kind() string { return "send_sms" }
+
+type SendSMSWorker struct {
+	river.WorkerDefaults[SendSMSArgs]
+	sender port.SMSSender
+}
+
+func NewSendSMSWorker(sender port.SMSSender) *SendSMSWorker {
+	return &SendSMSWorker{sender: sender}
+}
+
+func (w *SendSMSWorker) Work(ctx context.Context, job *river.Job[SendSMSArgs]) error {
+	if err := w.sender.Send(ctx, job.Args.Message); err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	return nil
+}
+
+// NotificationQueue implements port.NotificationQueue on top of River. Jobs
+// enqueued inside a TxManager transaction are inserted in that transaction.
+type NotificationQueue struct {
+	client *river.Client[pgx.Tx]
+}
+
+func NewNotificationQueue(client *river.Client[pgx.Tx]) *NotificationQueue {
+	return &NotificationQueue{client: client}
+}
+
+// Enqueue schedules both the dispatch to recipients and the publication to
+// webhook endpoints, so each is retried on its own.
+func (q *NotificationQueue) Enqueue(ctx context.Context, n domain.Notification) error {
+	if err := insertJob(ctx, q.client, DispatchNotificationArgs{Notification: n}); err != nil {
+		return err
+	}
+	return insertJob(ctx, q.client, PublishWebhooksArgs{Notification: n})
+}
+
+func (q *NotificationQueue) EnqueueEmail(ctx context.Context, msg domain.EmailMessage) error {
+	return insertJob(ctx, q.client, SendEmailArgs{Message: msg})
+}
+
+func (q *NotificationQueue) EnqueueSMS(ctx context.Context, msg domain.SMSMessage) error {
+	return insertJob(ctx, q.client, SendSMSArgs{Message: msg})
+}
+
+// insertJob inserts args in the TxManager transaction carried by ctx, if any.
+func insertJob(ctx context.Context, client *river.Client[pgx.Tx], args river.JobArgs) error {
+	var err error
+	if tx, ok := postgres.TxFromContext(ctx); ok {
+		_, err = client.InsertTx(ctx, tx, args, nil)
+	} else {
+		_, err = client.Insert(ctx, args, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert %s job: %w", args.Kind(), err)
+	}
+	return nil
+}
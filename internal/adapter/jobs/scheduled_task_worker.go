@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// ScheduledTaskPollInterval is how often due scheduled tasks are checked.
+const ScheduledTaskPollInterval = time.Minute
+
+// RunScheduledTasksArgs is the periodic job that turns due scheduled tasks into tickets.
+type RunScheduledTasksArgs struct{}
+
+func (RunScheduledTasksArgs) Kind() string { return "run_scheduled_tasks" }
+
+type RunScheduledTasksWorker struct {
+	river.WorkerDefaults[RunScheduledTasksArgs]
+	service port.ScheduledTaskService
+	logger  *slog.Logger
+}
+
+func NewRunScheduledTasksWorker(service port.ScheduledTaskService, logger *slog.Logger) *RunScheduledTasksWorker {
+	return &RunScheduledTasksWorker{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (w *RunScheduledTasksWorker) Work(ctx context.Context, job *river.Job[RunScheduledTasksArgs]) error {
+	created, err := w.service.RunDueTasks(ctx, time.Now())
+	if created > 0 {
+		w.logger.Info("generated tickets from scheduled tasks", "count", created)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run scheduled tasks: %w", err)
+	}
+	return nil
+}
+
+// NewRunScheduledTasksPeriodicJob returns the periodic job definition for RunScheduledTasksArgs.
+// River only enqueues periodic jobs on the elected leader, and RunDueTasks locks
+// each task row, so running several replicas is safe.
+func NewRunScheduledTasksPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(ScheduledTaskPollInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return RunScheduledTasksArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
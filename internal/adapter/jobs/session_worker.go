@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// SessionPurgeInterval is how often expired sessions are deleted. Expired
+// sessions are already refused, so this only keeps the table small.
+const SessionPurgeInterval = time.Hour
+
+// PurgeSessionsArgs is the periodic job that deletes expired sessions.
+type PurgeSessionsArgs struct{}
+
+func (PurgeSessionsArgs) Kind() string { return "purge_sessions" }
+
+type PurgeSessionsWorker struct {
+	river.WorkerDefaults[PurgeSessionsArgs]
+	service port.SessionService
+	logger  *slog.Logger
+}
+
+func NewPurgeSessionsWorker(service port.SessionService, logger *slog.Logger) *PurgeSessionsWorker {
+	return &PurgeSessionsWorker{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (w *PurgeSessionsWorker) Work(ctx context.Context, job *river.Job[PurgeSessionsArgs]) error {
+	purged, err := w.service.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to purge sessions: %w", err)
+	}
+	if purged > 0 {
+		w.logger.Info("purged expired sessions", "count", purged)
+	}
+	return nil
+}
+
+// NewPurgeSessionsPeriodicJob returns the periodic job definition for
+// PurgeSessionsArgs.
+func NewPurgeSessionsPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(SessionPurgeInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return PurgeSessionsArgs{}, nil
+		},
+		nil,
+	)
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// WebhookMaxAttempts spans a little over four hours of retries with
+	// WebhookRetryDelay.
+	WebhookMaxAttempts    = 10
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 6 * time.Hour
+)
+
+// PublishWebhooksArgs is the job that fans a notification out to the
+// organization's webhook endpoints.
+type PublishWebhooksArgs struct {
+	Notification domain.Notification `json:"notification"`
+}
+
+func (PublishWebhooksArgs) Kind() string { return "publish_webhooks" }
+
+type PublishWebhooksWorker struct {
+	river.WorkerDefaults[PublishWebhooksArgs]
+	service port.WebhookService
+}
+
+func NewPublishWebhooksWorker(service port.WebhookService) *PublishWebhooksWorker {
+	return &PublishWebhooksWorker{service: service}
+}
+
+func (w *PublishWebhooksWorker) Work(ctx context.Context, job *river.Job[PublishWebhooksArgs]) error {
+	if err := w.service.Publish(ctx, job.Args.Notification, job.CreatedAt); err != nil {
+		return fmt.Errorf("failed to publish webhooks: %w", err)
+	}
+	return nil
+}
+
+// DeliverWebhookArgs is the job that sends one webhook delivery. Failed
+// attempts are retried with exponential backoff.
+type DeliverWebhookArgs struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+func (DeliverWebhookArgs) Kind() string { return "deliver_webhook" }
+
+func (DeliverWebhookArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: WebhookMaxAttempts}
+}
+
+type DeliverWebhookWorker struct {
+	river.WorkerDefaults[DeliverWebhookArgs]
+	service port.WebhookService
+}
+
+func NewDeliverWebhookWorker(service port.WebhookService) *DeliverWebhookWorker {
+	return &DeliverWebhookWorker{service: service}
+}
+
+func (w *DeliverWebhookWorker) Work(ctx context.Context, job *river.Job[DeliverWebhookArgs]) error {
+	return w.service.Deliver(ctx, job.Args.DeliveryID)
+}
+
+// NextRetry waits 30s after the first failure and doubles the wait after
+// each one after that.
+func (w *DeliverWebhookWorker) NextRetry(job *river.Job[DeliverWebhookArgs]) time.Time {
+	return time.Now().Add(WebhookRetryDelay(job.Attempt))
+}
+
+// WebhookRetryDelay returns how long to wait after the given failed attempt.
+func WebhookRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// WebhookQueue implements port.WebhookQueue on top of River.
+type WebhookQueue struct {
+	client *river.Client[pgx.Tx]
+}
+
+func NewWebhookQueue(client *river.Client[pgx.Tx]) *WebhookQueue {
+	return &WebhookQueue{client: client}
+}
+
+func (q *WebhookQueue) EnqueueDelivery(ctx context.Context, deliveryID uuid.UUID) error {
+	return insertJob(ctx, q.client, DeliverWebhookArgs{DeliveryID: deliveryID})
+}
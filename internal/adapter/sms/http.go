@@ -0,0 +1,85 @@
+// Package sms delivers text messages, either through an SMS provider's HTTP
+// API or, in development, to the log.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxErrorBody bounds how much of a failed response ends up in the error.
+	maxErrorBody = 512
+)
+
+// HTTPConfig configures HTTPSender. URL is the provider's message endpoint,
+// e.g. https://api.twilio.com/2010-04-01/Accounts/<sid>/Messages.json.
+// Username and Password are sent as HTTP basic auth, which is the account SID
+// and auth token for Twilio and the API key and secret for most others.
+type HTTPConfig struct {
+	URL      string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// HTTPSender implements port.SMSSender for Twilio-style APIs: the message is
+// POSTed as a form with To, From and Body fields, and any 2xx response means
+// the provider accepted it.
+type HTTPSender struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+func NewHTTPSender(cfg HTTPConfig) (*HTTPSender, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid sms api url %q", cfg.URL)
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("sms from number is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &HTTPSender{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}, nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, msg domain.SMSMessage) error {
+	form := url.Values{
+		"To":   {msg.To},
+		"From": {s.cfg.From},
+		"Body": {msg.Body},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "OpsDeck-SMS/1")
+	if s.cfg.Username != "" {
+		req.SetBasicAuth(s.cfg.Username, s.cfg.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach sms api: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only body
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("sms api responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,62 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+func TestHTTPSender(t *testing.T) {
+	ctx := context.Background()
+	msg := domain.SMSMessage{To: "+15551234567", Body: "[St. Mark] Leaky faucet: New ticket submitted"}
+
+	t.Run("Posts a Twilio-style form", func(t *testing.T) {
+		var got *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			got = r
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		sender, err := NewHTTPSender(HTTPConfig{URL: server.URL + "/Messages.json", Username: "AC123", Password: "token", From: "+15550000000"})
+		require.NoError(t, err)
+		require.NoError(t, sender.Send(ctx, msg))
+
+		require.NotNil(t, got)
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Equal(t, "/Messages.json", got.URL.Path)
+		assert.Equal(t, "application/x-www-form-urlencoded", got.Header.Get("Content-Type"))
+		user, pass, ok := got.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "token", pass)
+		assert.Equal(t, msg.To, got.PostForm.Get("To"))
+		assert.Equal(t, "+15550000000", got.PostForm.Get("From"))
+		assert.Equal(t, msg.Body, got.PostForm.Get("Body"))
+	})
+
+	t.Run("Rejections are errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"The 'To' number is not a valid phone number."}`, http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		sender, err := NewHTTPSender(HTTPConfig{URL: server.URL, From: "+15550000000"})
+		require.NoError(t, err)
+		err = sender.Send(ctx, msg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Contains(t, err.Error(), "not a valid phone number")
+	})
+
+	t.Run("Requires a from number", func(t *testing.T) {
+		_, err := NewHTTPSender(HTTPConfig{URL: "https://api.example.com/messages"})
+		assert.Error(t, err)
+	})
+}
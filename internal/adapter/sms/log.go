@@ -0,0 +1,23 @@
+package sms
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// LogSender implements port.SMSSender for local development by logging every
+// message instead of sending it.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg domain.SMSMessage) error {
+	s.logger.Info("sms", "to", msg.To, "body", msg.Body)
+	return nil
+}
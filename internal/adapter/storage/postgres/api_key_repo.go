@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+type APIKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, user_id, organization_id, name, token_prefix, scopes, created_at, last_used_at`
+
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	var k domain.APIKey
+	var scopes []string
+	err := row.Scan(&k.ID, &k.UserID, &k.OrganizationID, &k.Name, &k.Prefix, &scopes, &k.CreatedAt, &k.LastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	k.Scopes = make([]domain.APIKeyScope, len(scopes))
+	for i, s := range scopes {
+		k.Scopes[i] = domain.APIKeyScope(s)
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey, tokenHash []byte) error {
+	scopes := make([]string, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = string(s)
+	}
+	query := `
+		INSERT INTO api_keys (user_id, organization_id, name, token_prefix, token_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		key.UserID, key.OrganizationID, key.Name, key.Prefix, tokenHash, scopes, key.CreatedAt,
+	).Scan(&key.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE token_hash = $1`
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) ListPersonal(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE user_id = $1 AND organization_id IS NULL
+		ORDER BY created_at DESC
+	`
+	return r.list(ctx, query, userID)
+}
+
+func (r *APIKeyRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.list(ctx, query, orgID)
+}
+
+func (r *APIKeyRepository) list(ctx context.Context, query string, args ...any) ([]domain.APIKey, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, id, at); err != nil {
+		return fmt.Errorf("failed to mark api key used: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) DeletePersonal(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	query := `DELETE FROM api_keys WHERE id = $1 AND user_id = $2 AND organization_id IS NULL`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete api key: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *APIKeyRepository) DeleteForOrganization(ctx context.Context, orgID, id uuid.UUID) (bool, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM api_keys WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete api key: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
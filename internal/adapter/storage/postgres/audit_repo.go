@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	query := `
+		INSERT INTO org_audit_log (
+			organization_id, actor_id, action, target_type, target_id,
+			before, after, ip_address, user_agent
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		entry.OrganizationID,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Before,
+		entry.After,
+		entry.IPAddress,
+		entry.UserAgent,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter port.AuditFilter) ([]domain.AuditEntry, error) {
+	query := `
+		SELECT a.id, a.organization_id, a.actor_id, u.name, a.action, a.target_type, a.target_id,
+		       a.before, a.after, a.ip_address, a.user_agent, a.created_at
+		FROM org_audit_log a
+		LEFT JOIN users u ON u.id = a.actor_id
+		WHERE a.organization_id = $1
+	`
+	args := []interface{}{filter.OrganizationID}
+	argIdx := 2
+
+	if filter.Action != "" {
+		query += fmt.Sprintf(" AND a.action = $%d", argIdx)
+		args = append(args, filter.Action)
+		argIdx++
+	}
+
+	if filter.ActorID != nil {
+		query += fmt.Sprintf(" AND a.actor_id = $%d", argIdx)
+		args = append(args, *filter.ActorID)
+		argIdx++
+	}
+
+	if filter.TargetID != nil {
+		query += fmt.Sprintf(" AND a.target_id = $%d", argIdx)
+		args = append(args, *filter.TargetID)
+		argIdx++
+	}
+
+	if filter.From != nil {
+		query += fmt.Sprintf(" AND a.created_at >= $%d", argIdx)
+		args = append(args, *filter.From)
+		argIdx++
+	}
+
+	if filter.To != nil {
+		query += fmt.Sprintf(" AND a.created_at < $%d", argIdx)
+		args = append(args, *filter.To)
+		argIdx++
+	}
+
+	if filter.BeforeCreatedAt != nil {
+		query += fmt.Sprintf(" AND (a.created_at, a.id) < ($%d, $%d)", argIdx, argIdx+1)
+		args = append(args, *filter.BeforeCreatedAt, filter.BeforeID)
+		argIdx += 2
+	}
+
+	query += " ORDER BY a.created_at DESC, a.id DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.AuditEntry, 0)
+	for rows.Next() {
+		var e domain.AuditEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.OrganizationID,
+			&e.ActorID,
+			&e.ActorName,
+			&e.Action,
+			&e.TargetType,
+			&e.TargetID,
+			&e.Before,
+			&e.After,
+			&e.IPAddress,
+			&e.UserAgent,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return entries, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
 )
@@ -24,7 +25,7 @@ func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment)
 		RETURNING id, created_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		comment.TicketID,
 		comment.UserID,
 		comment.Body,
@@ -38,6 +39,32 @@ func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment)
 	return nil
 }
 
+func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	query := `
+		SELECT id, ticket_id, user_id, body, sensitive, created_at
+		FROM comments
+		WHERE id = $1
+	`
+
+	var c domain.Comment
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&c.ID,
+		&c.TicketID,
+		&c.UserID,
+		&c.Body,
+		&c.Sensitive,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get comment by id: %w", err)
+	}
+
+	return &c, nil
+}
+
 func (r *CommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID, includeSensitive bool) ([]domain.Comment, error) {
 	query := `
 		SELECT id, ticket_id, user_id, body, sensitive, created_at
@@ -52,7 +79,7 @@ func (r *CommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID
 
 	query += " ORDER BY created_at ASC"
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
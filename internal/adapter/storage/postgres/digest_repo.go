@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+type DigestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDigestRepository(db *pgxpool.Pool) *DigestRepository {
+	return &DigestRepository{db: db}
+}
+
+func (r *DigestRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.DigestSettings, error) {
+	query := `
+		SELECT user_id, frequency, timezone, last_sent_at
+		FROM digest_settings
+		WHERE user_id = $1
+	`
+	var s domain.DigestSettings
+	err := conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(&s.UserID, &s.Frequency, &s.Timezone, &s.LastSentAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get digest settings: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *DigestRepository) Upsert(ctx context.Context, settings *domain.DigestSettings) error {
+	query := `
+		INSERT INTO digest_settings (user_id, frequency, timezone, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET frequency = EXCLUDED.frequency,
+			timezone = EXCLUDED.timezone,
+			updated_at = NOW()
+		RETURNING last_sent_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, settings.UserID, settings.Frequency, settings.Timezone).Scan(&settings.LastSentAt)
+	if err != nil {
+		return fmt.Errorf("failed to save digest settings: %w", err)
+	}
+	return nil
+}
+
+func (r *DigestRepository) ListEnabled(ctx context.Context) ([]domain.DigestSettings, error) {
+	query := `
+		SELECT user_id, frequency, timezone, last_sent_at
+		FROM digest_settings
+		WHERE frequency <> 'none'
+		ORDER BY user_id
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []domain.DigestSettings
+	for rows.Next() {
+		var s domain.DigestSettings
+		if err := rows.Scan(&s.UserID, &s.Frequency, &s.Timezone, &s.LastSentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest settings: %w", err)
+		}
+		settings = append(settings, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return settings, nil
+}
+
+func (r *DigestRepository) MarkSent(ctx context.Context, userID uuid.UUID, dueAt, sentAt time.Time) (bool, error) {
+	query := `
+		UPDATE digest_settings
+		SET last_sent_at = $3
+		WHERE user_id = $1 AND (last_sent_at IS NULL OR last_sent_at < $2)
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, userID, dueAt, sentAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
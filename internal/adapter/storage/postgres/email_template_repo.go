@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+type EmailTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmailTemplateRepository(db *pgxpool.Pool) *EmailTemplateRepository {
+	return &EmailTemplateRepository{db: db}
+}
+
+func (r *EmailTemplateRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.EmailTemplate, error) {
+	query := `
+		SELECT organization_id, event, subject, text_body, html_body, updated_at
+		FROM organization_email_templates
+		WHERE organization_id = $1
+		ORDER BY event ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]domain.EmailTemplate, 0)
+	for rows.Next() {
+		t := domain.EmailTemplate{Custom: true}
+		if err := rows.Scan(&t.OrganizationID, &t.Event, &t.Subject, &t.TextBody, &t.HTMLBody, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *EmailTemplateRepository) Get(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (*domain.EmailTemplate, error) {
+	query := `
+		SELECT organization_id, event, subject, text_body, html_body, updated_at
+		FROM organization_email_templates
+		WHERE organization_id = $1 AND event = $2
+	`
+	t := domain.EmailTemplate{Custom: true}
+	err := conn(ctx, r.db).QueryRow(ctx, query, orgID, event).Scan(&t.OrganizationID, &t.Event, &t.Subject, &t.TextBody, &t.HTMLBody, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *EmailTemplateRepository) Upsert(ctx context.Context, tmpl *domain.EmailTemplate) error {
+	query := `
+		INSERT INTO organization_email_templates (organization_id, event, subject, text_body, html_body, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (organization_id, event) DO UPDATE
+		SET subject = EXCLUDED.subject,
+			text_body = EXCLUDED.text_body,
+			html_body = EXCLUDED.html_body,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, tmpl.OrganizationID, tmpl.Event, tmpl.Subject, tmpl.TextBody, tmpl.HTMLBody).Scan(&tmpl.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save email template: %w", err)
+	}
+	tmpl.Custom = true
+	return nil
+}
+
+func (r *EmailTemplateRepository) Delete(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) error {
+	query := `DELETE FROM organization_email_templates WHERE organization_id = $1 AND event = $2`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, orgID, event); err != nil {
+		return fmt.Errorf("failed to delete email template: %w", err)
+	}
+	return nil
+}
+
+func (r *EmailTemplateRepository) GetBranding(ctx context.Context, orgID uuid.UUID) (*domain.EmailBranding, error) {
+	query := `
+		SELECT logo_url, footer
+		FROM organization_email_branding
+		WHERE organization_id = $1
+	`
+	b := domain.EmailBranding{OrganizationID: orgID}
+	err := conn(ctx, r.db).QueryRow(ctx, query, orgID).Scan(&b.LogoURL, &b.Footer)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get email branding: %w", err)
+	}
+	return &b, nil
+}
+
+func (r *EmailTemplateRepository) UpsertBranding(ctx context.Context, branding *domain.EmailBranding) error {
+	query := `
+		INSERT INTO organization_email_branding (organization_id, logo_url, footer, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (organization_id) DO UPDATE
+		SET logo_url = EXCLUDED.logo_url,
+			footer = EXCLUDED.footer,
+			updated_at = NOW()
+	`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, branding.OrganizationID, branding.LogoURL, branding.Footer); err != nil {
+		return fmt.Errorf("failed to save email branding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type InboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInboxRepository(db *pgxpool.Pool) *InboxRepository {
+	return &InboxRepository{db: db}
+}
+
+func (r *InboxRepository) Create(ctx context.Context, n *domain.InboxNotification) error {
+	query := `
+		INSERT INTO inbox_notifications (user_id, organization_id, ticket_id, event, actor_id, summary)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		n.UserID,
+		n.OrganizationID,
+		n.TicketID,
+		n.Event,
+		n.ActorID,
+		n.Summary,
+	).Scan(&n.ID, &n.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create inbox notification: %w", err)
+	}
+	return nil
+}
+
+func (r *InboxRepository) List(ctx context.Context, filter port.InboxFilter) ([]domain.InboxNotification, error) {
+	query := `
+		SELECT n.id, n.user_id, n.organization_id, n.ticket_id, t.title, n.event,
+		       n.actor_id, u.name, n.summary, n.read_at, n.created_at
+		FROM inbox_notifications n
+		JOIN tickets t ON t.id = n.ticket_id
+		LEFT JOIN users u ON u.id = n.actor_id
+		WHERE n.user_id = $1
+	`
+	args := []interface{}{filter.UserID}
+	argIdx := 2
+
+	if filter.UnreadOnly {
+		query += " AND n.read_at IS NULL"
+	}
+
+	if filter.BeforeCreatedAt != nil {
+		query += fmt.Sprintf(" AND (n.created_at, n.id) < ($%d, $%d)", argIdx, argIdx+1)
+		args = append(args, *filter.BeforeCreatedAt, filter.BeforeID)
+		argIdx += 2
+	}
+
+	query += " ORDER BY n.created_at DESC, n.id DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbox notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]domain.InboxNotification, 0)
+	for rows.Next() {
+		var n domain.InboxNotification
+		err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.OrganizationID,
+			&n.TicketID,
+			&n.TicketTitle,
+			&n.Event,
+			&n.ActorID,
+			&n.ActorName,
+			&n.Summary,
+			&n.ReadAt,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inbox notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return notifications, nil
+}
+
+func (r *InboxRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM inbox_notifications WHERE user_id = $1 AND read_at IS NULL`
+	var count int
+	if err := conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (r *InboxRepository) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		UPDATE inbox_notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrInboxNotificationNotFound
+	}
+	return nil
+}
+
+func (r *InboxRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE inbox_notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LoginTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginTokenRepository(db *pgxpool.Pool) *LoginTokenRepository {
+	return &LoginTokenRepository{db: db}
+}
+
+func (r *LoginTokenRepository) Create(ctx context.Context, email, ipAddress string, tokenHash []byte, createdAt, expiresAt time.Time) error {
+	query := `
+		INSERT INTO login_tokens (email, ip_address, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, email, ipAddress, tokenHash, createdAt, expiresAt); err != nil {
+		return fmt.Errorf("failed to create login token: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginTokenRepository) Consume(ctx context.Context, tokenHash []byte, now time.Time) (string, error) {
+	query := `
+		UPDATE login_tokens
+		SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING email
+	`
+	var email string
+	err := conn(ctx, r.db).QueryRow(ctx, query, tokenHash, now).Scan(&email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to consume login token: %w", err)
+	}
+	return email, nil
+}
+
+// Advisory lock classes for CountSince. The email is always locked before
+// the IP address, so two requests cannot wait on each other.
+const (
+	loginTokenEmailLock = 1
+	loginTokenIPLock    = 2
+)
+
+func (r *LoginTokenRepository) CountSince(ctx context.Context, email, ipAddress string, since time.Time) (int, int, error) {
+	db := conn(ctx, r.db)
+	if _, err := db.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, loginTokenEmailLock, email); err != nil {
+		return 0, 0, fmt.Errorf("failed to lock login tokens: %w", err)
+	}
+	if ipAddress != "" {
+		if _, err := db.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, loginTokenIPLock, ipAddress); err != nil {
+			return 0, 0, fmt.Errorf("failed to lock login tokens: %w", err)
+		}
+	}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE email = $1),
+			COUNT(*) FILTER (WHERE ip_address = $2)
+		FROM login_tokens
+		WHERE created_at > $3 AND (email = $1 OR ip_address = $2)
+	`
+	var byEmail, byIP int
+	if err := db.QueryRow(ctx, query, email, ipAddress, since).Scan(&byEmail, &byIP); err != nil {
+		return 0, 0, fmt.Errorf("failed to count login tokens: %w", err)
+	}
+	return byEmail, byIP, nil
+}
+
+func (r *LoginTokenRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM login_tokens WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete login tokens: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
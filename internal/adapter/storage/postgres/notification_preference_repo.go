@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+type NotificationPreferenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationPreferenceRepository(db *pgxpool.Pool) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) ListByUser(ctx context.Context, userID uuid.UUID) (domain.NotificationPreferences, error) {
+	query := `
+		SELECT event, channel
+		FROM user_notification_preferences
+		WHERE user_id = $1
+		ORDER BY event ASC
+	`
+	return r.list(ctx, query, userID)
+}
+
+func (r *NotificationPreferenceRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, event, channel
+		FROM user_notification_preferences
+		WHERE user_id = ANY($1)
+		ORDER BY user_id, event ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	prefs := make(map[uuid.UUID]domain.NotificationPreferences)
+	for rows.Next() {
+		var userID uuid.UUID
+		var p domain.NotificationPreference
+		if err := rows.Scan(&userID, &p.Event, &p.Channel); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		prefs[userID] = append(prefs[userID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return prefs, nil
+}
+
+func (r *NotificationPreferenceRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, prefs domain.NotificationPreferences) error {
+	q := conn(ctx, r.db)
+	if _, err := q.Exec(ctx, `DELETE FROM user_notification_preferences WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to clear notification preferences: %w", err)
+	}
+	for _, p := range prefs {
+		query := `
+			INSERT INTO user_notification_preferences (user_id, event, channel)
+			VALUES ($1, $2, $3)
+		`
+		if _, err := q.Exec(ctx, query, userID, p.Event, p.Channel); err != nil {
+			return fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.NotificationPreferences, error) {
+	query := `
+		SELECT event, channel
+		FROM organization_notification_preferences
+		WHERE organization_id = $1
+		ORDER BY event ASC
+	`
+	return r.list(ctx, query, orgID)
+}
+
+func (r *NotificationPreferenceRepository) ReplaceForOrganization(ctx context.Context, orgID uuid.UUID, prefs domain.NotificationPreferences) error {
+	q := conn(ctx, r.db)
+	if _, err := q.Exec(ctx, `DELETE FROM organization_notification_preferences WHERE organization_id = $1`, orgID); err != nil {
+		return fmt.Errorf("failed to clear notification preferences: %w", err)
+	}
+	for _, p := range prefs {
+		query := `
+			INSERT INTO organization_notification_preferences (organization_id, event, channel)
+			VALUES ($1, $2, $3)
+		`
+		if _, err := q.Exec(ctx, query, orgID, p.Event, p.Channel); err != nil {
+			return fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) list(ctx context.Context, query string, id uuid.UUID) (domain.NotificationPreferences, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	prefs := make(domain.NotificationPreferences, 0)
+	for rows.Next() {
+		var p domain.NotificationPreference
+		if err := rows.Scan(&p.Event, &p.Channel); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		prefs = append(prefs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return prefs, nil
+}
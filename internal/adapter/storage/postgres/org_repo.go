@@ -21,12 +21,12 @@ func NewOrganizationRepository(db *pgxpool.Pool) *OrganizationRepository {
 
 func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
 	query := `
-		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, created_at, updated_at
+		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, timezone, created_at, updated_at
 		FROM organizations
 		WHERE id = $1
 	`
 	var org domain.Organization
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&org.ID,
 		&org.Name,
 		&org.Slug,
@@ -34,6 +34,7 @@ func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 		&org.ShareLinkToken,
 		&org.PublicViewEnabled,
 		&org.PublicViewToken,
+		&org.Timezone,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -45,12 +46,12 @@ func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 
 func (r *OrganizationRepository) GetByShareToken(ctx context.Context, token string) (*domain.Organization, error) {
 	query := `
-		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, created_at, updated_at
+		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, timezone, created_at, updated_at
 		FROM organizations
 		WHERE share_link_token = $1
 	`
 	var org domain.Organization
-	err := r.db.QueryRow(ctx, query, token).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, token).Scan(
 		&org.ID,
 		&org.Name,
 		&org.Slug,
@@ -58,6 +59,7 @@ func (r *OrganizationRepository) GetByShareToken(ctx context.Context, token stri
 		&org.ShareLinkToken,
 		&org.PublicViewEnabled,
 		&org.PublicViewToken,
+		&org.Timezone,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -72,12 +74,12 @@ func (r *OrganizationRepository) GetByShareToken(ctx context.Context, token stri
 
 func (r *OrganizationRepository) GetByPublicViewToken(ctx context.Context, token string) (*domain.Organization, error) {
 	query := `
-		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, created_at, updated_at
+		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, timezone, created_at, updated_at
 		FROM organizations
 		WHERE public_view_token = $1
 	`
 	var org domain.Organization
-	err := r.db.QueryRow(ctx, query, token).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, token).Scan(
 		&org.ID,
 		&org.Name,
 		&org.Slug,
@@ -85,6 +87,7 @@ func (r *OrganizationRepository) GetByPublicViewToken(ctx context.Context, token
 		&org.ShareLinkToken,
 		&org.PublicViewEnabled,
 		&org.PublicViewToken,
+		&org.Timezone,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -97,13 +100,57 @@ func (r *OrganizationRepository) GetByPublicViewToken(ctx context.Context, token
 	return &org, nil
 }
 
+func (r *OrganizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	query := `
+		SELECT id, name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, timezone, created_at, updated_at
+		FROM organizations
+		WHERE slug = $1
+	`
+	var org domain.Organization
+	err := conn(ctx, r.db).QueryRow(ctx, query, slug).Scan(
+		&org.ID,
+		&org.Name,
+		&org.Slug,
+		&org.ShareLinkEnabled,
+		&org.ShareLinkToken,
+		&org.PublicViewEnabled,
+		&org.PublicViewToken,
+		&org.Timezone,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
+	}
+	return &org, nil
+}
+
 func (r *OrganizationRepository) Create(ctx context.Context, org *domain.Organization) error {
+	if org.Timezone == "" {
+		org.Timezone = domain.DefaultTimezone
+	}
+	// The default ticket statuses and priorities are copied in the same
+	// statement so an organization never exists without any.
 	query := `
-		INSERT INTO organizations (name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at
+		WITH org AS (
+			INSERT INTO organizations (name, slug, share_link_enabled, share_link_token, public_view_enabled, public_view_token, timezone)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at, updated_at
+		), statuses AS (
+			INSERT INTO organization_ticket_statuses (organization_id, id, label, position, color, state)
+			SELECT org.id, s.id, s.label, s.level, s.color, s.state
+			FROM org CROSS JOIN ticket_statuses s
+		), priorities AS (
+			INSERT INTO organization_ticket_priorities (organization_id, id, label, level, description, color)
+			SELECT org.id, p.id, p.label, p.level, p.description, p.color
+			FROM org CROSS JOIN ticket_priorities p
+		)
+		SELECT id, created_at, updated_at FROM org
 	`
-	err := r.db.QueryRow(ctx, query, org.Name, org.Slug, org.ShareLinkEnabled, org.ShareLinkToken, org.PublicViewEnabled, org.PublicViewToken).Scan(&org.ID, &org.CreatedAt, &org.UpdatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, org.Name, org.Slug, org.ShareLinkEnabled, org.ShareLinkToken, org.PublicViewEnabled, org.PublicViewToken, org.Timezone).Scan(&org.ID, &org.CreatedAt, &org.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create organization: %w", err)
 	}
@@ -113,23 +160,23 @@ func (r *OrganizationRepository) Create(ctx context.Context, org *domain.Organiz
 func (r *OrganizationRepository) Update(ctx context.Context, org *domain.Organization) error {
 	query := `
 		UPDATE organizations
-		SET name = $1, slug = $2, share_link_enabled = $3, share_link_token = $4, public_view_enabled = $5, public_view_token = $6, updated_at = NOW()
-		WHERE id = $7
+		SET name = $1, slug = $2, share_link_enabled = $3, share_link_token = $4, public_view_enabled = $5, public_view_token = $6, timezone = $7, updated_at = NOW()
+		WHERE id = $8
 		RETURNING updated_at
 	`
-	err := r.db.QueryRow(ctx, query, org.Name, org.Slug, org.ShareLinkEnabled, org.ShareLinkToken, org.PublicViewEnabled, org.PublicViewToken, org.ID).Scan(&org.UpdatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, org.Name, org.Slug, org.ShareLinkEnabled, org.ShareLinkToken, org.PublicViewEnabled, org.PublicViewToken, org.Timezone, org.ID).Scan(&org.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to update organization: %w", err)
 	}
 	return nil
 }
 
-func (r *OrganizationRepository) AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+func (r *OrganizationRepository) AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error {
 	query := `
 		INSERT INTO organization_members (organization_id, user_id, role)
 		VALUES ($1, $2, $3)
 	`
-	_, err := r.db.Exec(ctx, query, orgID, userID, role)
+	_, err := conn(ctx, r.db).Exec(ctx, query, orgID, userID, role)
 	if err != nil {
 		return fmt.Errorf("failed to add member to organization: %w", err)
 	}
@@ -138,12 +185,13 @@ func (r *OrganizationRepository) AddMember(ctx context.Context, orgID uuid.UUID,
 
 func (r *OrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.UserMembership, error) {
 	query := `
-		SELECT o.id, o.name, o.slug, o.share_link_enabled, o.share_link_token, o.public_view_enabled, o.public_view_token, o.created_at, o.updated_at, om.role
+		SELECT o.id, o.name, o.slug, o.share_link_enabled, o.share_link_token, o.public_view_enabled, o.public_view_token, o.timezone, o.created_at, o.updated_at, om.role
 		FROM organizations o
 		JOIN organization_members om ON o.id = om.organization_id
 		WHERE om.user_id = $1
+		ORDER BY om.joined_at, o.id
 	`
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list organizations for user: %w", err)
 	}
@@ -160,6 +208,7 @@ func (r *OrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUI
 			&m.Organization.ShareLinkToken,
 			&m.Organization.PublicViewEnabled,
 			&m.Organization.PublicViewToken,
+			&m.Organization.Timezone,
 			&m.Organization.CreatedAt,
 			&m.Organization.UpdatedAt,
 			&m.Role,
@@ -184,7 +233,7 @@ func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUI
 		JOIN organization_members om ON u.id = om.user_id
 		WHERE om.organization_id = $1
 	`
-	rows, err := r.db.Query(ctx, query, orgID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list organization members: %w", err)
 	}
@@ -222,20 +271,20 @@ func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID uuid.UU
 		DELETE FROM organization_members
 		WHERE organization_id = $1 AND user_id = $2
 	`
-	_, err := r.db.Exec(ctx, query, orgID, userID)
+	_, err := conn(ctx, r.db).Exec(ctx, query, orgID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to remove member from organization: %w", err)
 	}
 	return nil
 }
 
-func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error {
 	query := `
 		UPDATE organization_members
 		SET role = $1
 		WHERE organization_id = $2 AND user_id = $3
 	`
-	_, err := r.db.Exec(ctx, query, role, orgID, userID)
+	_, err := conn(ctx, r.db).Exec(ctx, query, role, orgID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to update member role: %w", err)
 	}
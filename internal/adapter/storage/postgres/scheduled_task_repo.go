@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -20,46 +21,34 @@ func NewScheduledTaskRepository(db *pgxpool.Pool) *ScheduledTaskRepository {
 
 func (r *ScheduledTaskRepository) Get(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
 	query := `
-		SELECT id, organization_id, title, description, frequency, start_date, next_run_at,
-		       created_by, assignee_user_id, priority_id, location, enabled, created_at, updated_at
-		FROM scheduled_tasks
-		WHERE id = $1
+		SELECT st.id, st.organization_id, st.title, st.description, st.rrule, st.start_date, st.next_run_at,
+		       st.created_by, st.assignee_user_id, st.priority_id, st.category_id, st.location, st.enabled, st.created_at, st.updated_at,
+		       o.timezone
+		FROM scheduled_tasks st
+		JOIN organizations o ON o.id = st.organization_id
+		WHERE st.id = $1
 	`
-	var t domain.ScheduledTask
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&t.ID,
-		&t.OrganizationID,
-		&t.Title,
-		&t.Description,
-		&t.Frequency,
-		&t.StartDate,
-		&t.NextRunAt,
-		&t.CreatedBy,
-		&t.AssigneeUserID,
-		&t.PriorityID,
-		&t.Location,
-		&t.Enabled,
-		&t.CreatedAt,
-		&t.UpdatedAt,
-	)
+	t, err := scanScheduledTask(conn(ctx, r.db).QueryRow(ctx, query, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get scheduled task: %w", err)
 	}
-	return &t, nil
+	return t, nil
 }
 
 func (r *ScheduledTaskRepository) List(ctx context.Context, organizationID uuid.UUID) ([]domain.ScheduledTask, error) {
 	query := `
-		SELECT id, organization_id, title, description, frequency, start_date, next_run_at,
-		       created_by, assignee_user_id, priority_id, location, enabled, created_at, updated_at
-		FROM scheduled_tasks
-		WHERE organization_id = $1
-		ORDER BY next_run_at ASC
+		SELECT st.id, st.organization_id, st.title, st.description, st.rrule, st.start_date, st.next_run_at,
+		       st.created_by, st.assignee_user_id, st.priority_id, st.category_id, st.location, st.enabled, st.created_at, st.updated_at,
+		       o.timezone
+		FROM scheduled_tasks st
+		JOIN organizations o ON o.id = st.organization_id
+		WHERE st.organization_id = $1
+		ORDER BY st.next_run_at ASC
 	`
-	rows, err := r.db.Query(ctx, query, organizationID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, organizationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list scheduled tasks: %w", err)
 	}
@@ -67,27 +56,11 @@ func (r *ScheduledTaskRepository) List(ctx context.Context, organizationID uuid.
 
 	tasks := make([]domain.ScheduledTask, 0)
 	for rows.Next() {
-		var t domain.ScheduledTask
-		err := rows.Scan(
-			&t.ID,
-			&t.OrganizationID,
-			&t.Title,
-			&t.Description,
-			&t.Frequency,
-			&t.StartDate,
-			&t.NextRunAt,
-			&t.CreatedBy,
-			&t.AssigneeUserID,
-			&t.PriorityID,
-			&t.Location,
-			&t.Enabled,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-		)
+		t, err := scanScheduledTask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan scheduled task: %w", err)
 		}
-		tasks = append(tasks, t)
+		tasks = append(tasks, *t)
 	}
 	return tasks, nil
 }
@@ -95,22 +68,23 @@ func (r *ScheduledTaskRepository) List(ctx context.Context, organizationID uuid.
 func (r *ScheduledTaskRepository) Create(ctx context.Context, task *domain.ScheduledTask) error {
 	query := `
 		INSERT INTO scheduled_tasks (
-			organization_id, title, description, frequency, start_date, next_run_at,
-			created_by, assignee_user_id, priority_id, location, enabled
+			organization_id, title, description, rrule, start_date, next_run_at,
+			created_by, assignee_user_id, priority_id, category_id, location, enabled
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		task.OrganizationID,
 		task.Title,
 		task.Description,
-		task.Frequency,
+		task.RRule,
 		task.StartDate,
 		task.NextRunAt,
 		task.CreatedBy,
 		task.AssigneeUserID,
 		task.PriorityID,
+		task.CategoryID,
 		task.Location,
 		task.Enabled,
 	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
@@ -124,19 +98,20 @@ func (r *ScheduledTaskRepository) Create(ctx context.Context, task *domain.Sched
 func (r *ScheduledTaskRepository) Update(ctx context.Context, task *domain.ScheduledTask) error {
 	query := `
 		UPDATE scheduled_tasks
-		SET title = $1, description = $2, frequency = $3, start_date = $4, next_run_at = $5,
-		    assignee_user_id = $6, priority_id = $7, location = $8, enabled = $9, updated_at = NOW()
-		WHERE id = $10
+		SET title = $1, description = $2, rrule = $3, start_date = $4, next_run_at = $5,
+		    assignee_user_id = $6, priority_id = $7, category_id = $8, location = $9, enabled = $10, updated_at = NOW()
+		WHERE id = $11
 		RETURNING updated_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		task.Title,
 		task.Description,
-		task.Frequency,
+		task.RRule,
 		task.StartDate,
 		task.NextRunAt,
 		task.AssigneeUserID,
 		task.PriorityID,
+		task.CategoryID,
 		task.Location,
 		task.Enabled,
 		task.ID,
@@ -153,7 +128,7 @@ func (r *ScheduledTaskRepository) Update(ctx context.Context, task *domain.Sched
 
 func (r *ScheduledTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM scheduled_tasks WHERE id = $1`
-	tag, err := r.db.Exec(ctx, query, id)
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete scheduled task: %w", err)
 	}
@@ -162,3 +137,141 @@ func (r *ScheduledTaskRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	}
 	return nil
 }
+
+func (r *ScheduledTaskRepository) ListDueIDs(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM scheduled_tasks
+		WHERE enabled = TRUE AND next_run_at <= $1
+		ORDER BY next_run_at ASC
+		LIMIT $2
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due scheduled tasks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled task id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return ids, nil
+}
+
+// GetForUpdate must be called inside a transaction. SKIP LOCKED makes a
+// concurrent replica see the row as absent instead of blocking on it.
+func (r *ScheduledTaskRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
+	query := `
+		SELECT st.id, st.organization_id, st.title, st.description, st.rrule, st.start_date, st.next_run_at,
+		       st.created_by, st.assignee_user_id, st.priority_id, st.category_id, st.location, st.enabled, st.created_at, st.updated_at,
+		       o.timezone
+		FROM scheduled_tasks st
+		JOIN organizations o ON o.id = st.organization_id
+		WHERE st.id = $1
+		FOR UPDATE OF st SKIP LOCKED
+	`
+	t, err := scanScheduledTask(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock scheduled task: %w", err)
+	}
+	return t, nil
+}
+
+func (r *ScheduledTaskRepository) CreateRun(ctx context.Context, run *domain.ScheduledTaskRun) error {
+	query := `
+		INSERT INTO scheduled_task_runs (scheduled_task_id, run_at, ticket_id, outcome, error)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		run.ScheduledTaskID,
+		run.RunAt,
+		run.TicketID,
+		run.Outcome,
+		run.Error,
+	).Scan(&run.ID, &run.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled task run: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduledTaskRepository) ListRuns(ctx context.Context, taskID uuid.UUID, limit int) ([]domain.ScheduledTaskRun, error) {
+	query := `
+		SELECT r.id, r.scheduled_task_id, r.run_at, r.ticket_id, r.outcome, r.error, r.created_at,
+		       t.status_id, t.completed_at
+		FROM scheduled_task_runs r
+		LEFT JOIN tickets t ON t.id = r.ticket_id
+		WHERE r.scheduled_task_id = $1
+		ORDER BY r.run_at DESC, r.created_at DESC
+		LIMIT $2
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, taskID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled task runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]domain.ScheduledTaskRun, 0)
+	for rows.Next() {
+		var run domain.ScheduledTaskRun
+		err := rows.Scan(
+			&run.ID,
+			&run.ScheduledTaskID,
+			&run.RunAt,
+			&run.TicketID,
+			&run.Outcome,
+			&run.Error,
+			&run.CreatedAt,
+			&run.TicketStatusID,
+			&run.TicketCompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled task run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return runs, nil
+}
+
+func scanScheduledTask(row pgx.Row) (*domain.ScheduledTask, error) {
+	var t domain.ScheduledTask
+	err := row.Scan(
+		&t.ID,
+		&t.OrganizationID,
+		&t.Title,
+		&t.Description,
+		&t.RRule,
+		&t.StartDate,
+		&t.NextRunAt,
+		&t.CreatedBy,
+		&t.AssigneeUserID,
+		&t.PriorityID,
+		&t.CategoryID,
+		&t.Location,
+		&t.Enabled,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.Timezone,
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.Frequency = domain.FrequencyFromRRule(t.RRule)
+	return &t, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+type SessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `id, user_id, created_at, last_seen_at, expires_at, ip_address, user_agent`
+
+func scanSession(row pgx.Row) (*domain.Session, error) {
+	var s domain.Session
+	err := row.Scan(&s.ID, &s.UserID, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.IPAddress, &s.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session, tokenHash []byte) error {
+	query := `
+		INSERT INTO sessions (user_id, token_hash, created_at, last_seen_at, expires_at, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		session.UserID, tokenHash, session.CreatedAt, session.LastSeenAt, session.ExpiresAt, session.IPAddress, session.UserAgent,
+	).Scan(&session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE token_hash = $1`
+	session, err := scanSession(conn(ctx, r.db).QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) ListByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+	query := `UPDATE sessions SET last_seen_at = $2, expires_at = $3 WHERE id = $1`
+	if _, err := conn(ctx, r.db).Exec(ctx, query, id, lastSeenAt, expiresAt); err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete session: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *SessionRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, keep uuid.UUID) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM sessions WHERE user_id = $1 AND id <> $2`, userID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *SessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM sessions WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TicketCategoryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTicketCategoryRepository(db *pgxpool.Pool) *TicketCategoryRepository {
+	return &TicketCategoryRepository{db: db}
+}
+
+func (r *TicketCategoryRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketCategories, error) {
+	query := `
+		SELECT organization_id, id, label, description
+		FROM organization_ticket_categories
+		WHERE organization_id = $1
+		ORDER BY label ASC, id ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make(domain.TicketCategories, 0)
+	for rows.Next() {
+		var c domain.TicketCategory
+		if err := rows.Scan(&c.OrganizationID, &c.ID, &c.Label, &c.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket category: %w", err)
+		}
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return categories, nil
+}
+
+func (r *TicketCategoryRepository) Create(ctx context.Context, category *domain.TicketCategory) error {
+	query := `
+		INSERT INTO organization_ticket_categories (organization_id, id, label, description)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := conn(ctx, r.db).Exec(ctx, query, category.OrganizationID, category.ID, category.Label, category.Description)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket category: %w", err)
+	}
+	return nil
+}
+
+func (r *TicketCategoryRepository) Update(ctx context.Context, category *domain.TicketCategory) error {
+	query := `
+		UPDATE organization_ticket_categories
+		SET label = $1, description = $2, updated_at = NOW()
+		WHERE organization_id = $3 AND id = $4
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, category.Label, category.Description, category.OrganizationID, category.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket category: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketCategoryNotFound
+	}
+	return nil
+}
+
+func (r *TicketCategoryRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	query := `DELETE FROM organization_ticket_categories WHERE organization_id = $1 AND id = $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, orgID, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return port.ErrTicketCategoryInUse
+		}
+		return fmt.Errorf("failed to delete ticket category: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketCategoryNotFound
+	}
+	return nil
+}
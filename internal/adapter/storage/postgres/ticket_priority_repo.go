@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TicketPriorityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTicketPriorityRepository(db *pgxpool.Pool) *TicketPriorityRepository {
+	return &TicketPriorityRepository{db: db}
+}
+
+func (r *TicketPriorityRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketPriorities, error) {
+	query := `
+		SELECT organization_id, id, label, level, description, color
+		FROM organization_ticket_priorities
+		WHERE organization_id = $1
+		ORDER BY level ASC, id ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket priorities: %w", err)
+	}
+	defer rows.Close()
+
+	priorities := make(domain.TicketPriorities, 0)
+	for rows.Next() {
+		var p domain.TicketPriority
+		if err := rows.Scan(&p.OrganizationID, &p.ID, &p.Label, &p.Level, &p.Description, &p.Color); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket priority: %w", err)
+		}
+		priorities = append(priorities, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return priorities, nil
+}
+
+func (r *TicketPriorityRepository) Create(ctx context.Context, priority *domain.TicketPriority) error {
+	query := `
+		INSERT INTO organization_ticket_priorities (organization_id, id, label, level, description, color)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := conn(ctx, r.db).Exec(ctx, query, priority.OrganizationID, priority.ID, priority.Label, priority.Level, priority.Description, priority.Color)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket priority: %w", err)
+	}
+	return nil
+}
+
+func (r *TicketPriorityRepository) Update(ctx context.Context, priority *domain.TicketPriority) error {
+	query := `
+		UPDATE organization_ticket_priorities
+		SET label = $1, level = $2, description = $3, color = $4, updated_at = NOW()
+		WHERE organization_id = $5 AND id = $6
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, priority.Label, priority.Level, priority.Description, priority.Color, priority.OrganizationID, priority.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket priority: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketPriorityNotFound
+	}
+	return nil
+}
+
+func (r *TicketPriorityRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	query := `DELETE FROM organization_ticket_priorities WHERE organization_id = $1 AND id = $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, orgID, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return port.ErrTicketPriorityInUse
+		}
+		return fmt.Errorf("failed to delete ticket priority: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketPriorityNotFound
+	}
+	return nil
+}
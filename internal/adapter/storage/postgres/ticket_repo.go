@@ -26,24 +26,26 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 
 	query := `
 		INSERT INTO tickets (
-			organization_id, reporter_id, assignee_user_id, status_id, priority_id,
-			title, description, location, completed_at, sensitive
+			organization_id, reporter_id, assignee_user_id, status_id, priority_id, category_id,
+			title, description, location, completed_at, sensitive, scheduled_task_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		ticket.OrganizationID,
 		ticket.ReporterID,
 		ticket.AssigneeUserID,
 		ticket.StatusID,
 		ticket.PriorityID,
+		ticket.CategoryID,
 		ticket.Title,
 		ticket.Description,
 		ticket.Location,
 		ticket.CompletedAt,
 		ticket.Sensitive,
+		ticket.ScheduledTaskID,
 	).Scan(&ticket.ID, &ticket.CreatedAt, &ticket.UpdatedAt)
 
 	if err != nil {
@@ -54,21 +56,31 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 }
 
 func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate must be called inside a transaction for the lock to last.
+func (r *TicketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	return r.getByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *TicketRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*domain.Ticket, error) {
 	query := `
-		SELECT id, organization_id, reporter_id, assignee_user_id, status_id, priority_id,
-		       title, description, location, created_at, updated_at, completed_at, sensitive
+		SELECT id, organization_id, reporter_id, assignee_user_id, status_id, priority_id, category_id,
+		       title, description, location, created_at, updated_at, completed_at, sensitive, scheduled_task_id
 		FROM tickets
 		WHERE id = $1
-	`
+	` + lock
 
 	var t domain.Ticket
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&t.ID,
 		&t.OrganizationID,
 		&t.ReporterID,
 		&t.AssigneeUserID,
 		&t.StatusID,
 		&t.PriorityID,
+		&t.CategoryID,
 		&t.Title,
 		&t.Description,
 		&t.Location,
@@ -76,6 +88,7 @@ func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.T
 		&t.UpdatedAt,
 		&t.CompletedAt,
 		&t.Sensitive,
+		&t.ScheduledTaskID,
 	)
 
 	if err != nil {
@@ -95,8 +108,8 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, organization_id, reporter_id, assignee_user_id, status_id, priority_id,
-		       title, %s, location, created_at, updated_at, completed_at, sensitive
+		SELECT id, organization_id, reporter_id, assignee_user_id, status_id, priority_id, category_id,
+		       title, %s, location, created_at, updated_at, completed_at, sensitive, scheduled_task_id
 		FROM tickets
 		WHERE 1=1
 	`, descriptionField)
@@ -119,6 +132,15 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 		argIdx++
 	}
 
+	if filter.StatusState != "" {
+		query += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM organization_ticket_statuses s
+			WHERE s.organization_id = tickets.organization_id AND s.id = tickets.status_id AND s.state = $%d
+		)`, argIdx)
+		args = append(args, filter.StatusState)
+		argIdx++
+	}
+
 	if filter.AssigneeID != nil {
 		query += fmt.Sprintf(" AND assignee_user_id = $%d", argIdx)
 		args = append(args, *filter.AssigneeID)
@@ -143,6 +165,24 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 		argIdx++
 	}
 
+	if len(filter.CategoryIDs) > 0 {
+		query += fmt.Sprintf(" AND category_id = ANY($%d)", argIdx)
+		args = append(args, filter.CategoryIDs)
+		argIdx++
+	}
+
+	if filter.CreatedAfter != nil {
+		query += fmt.Sprintf(" AND created_at > $%d", argIdx)
+		args = append(args, *filter.CreatedAfter)
+		argIdx++
+	}
+
+	if filter.UpdatedAfter != nil {
+		query += fmt.Sprintf(" AND updated_at > $%d", argIdx)
+		args = append(args, *filter.UpdatedAfter)
+		argIdx++
+	}
+
 	if filter.Keyword != nil && *filter.Keyword != "" {
 		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", argIdx, argIdx)
 		keyword := fmt.Sprintf("%%%s%%", *filter.Keyword)
@@ -164,28 +204,21 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 		case "title":
 			orderBy = fmt.Sprintf("title %s", direction)
 		case "priority":
-			orderBy = fmt.Sprintf(`CASE priority_id
-				WHEN 'critical' THEN 4
-				WHEN 'high' THEN 3
-				WHEN 'medium' THEN 2
-				WHEN 'low' THEN 1
-				ELSE 0
-			END %s`, direction)
+			orderBy = fmt.Sprintf(`(
+				SELECT p.level FROM organization_ticket_priorities p
+				WHERE p.organization_id = tickets.organization_id AND p.id = tickets.priority_id
+			) %s`, direction)
 		case "status":
-			orderBy = fmt.Sprintf(`CASE status_id
-				WHEN 'new' THEN 1
-				WHEN 'in_progress' THEN 2
-				WHEN 'on_hold' THEN 3
-				WHEN 'done' THEN 4
-				WHEN 'canceled' THEN 5
-				ELSE 6
-			END %s`, direction)
+			orderBy = fmt.Sprintf(`(
+				SELECT s.position FROM organization_ticket_statuses s
+				WHERE s.organization_id = tickets.organization_id AND s.id = tickets.status_id
+			) %s`, direction)
 		}
 	}
 
 	query += " ORDER BY " + orderBy
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tickets: %w", err)
 	}
@@ -201,6 +234,7 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 			&t.AssigneeUserID,
 			&t.StatusID,
 			&t.PriorityID,
+			&t.CategoryID,
 			&t.Title,
 			&t.Description,
 			&t.Location,
@@ -208,6 +242,7 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 			&t.UpdatedAt,
 			&t.CompletedAt,
 			&t.Sensitive,
+			&t.ScheduledTaskID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ticket: %w", err)
@@ -225,15 +260,16 @@ func (r *TicketRepository) List(ctx context.Context, filter port.TicketFilter) (
 func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
 	query := `
 		UPDATE tickets
-		SET status_id = $1, priority_id = $2, assignee_user_id = $3,
-		    title = $4, description = $5, location = $6,
-		    updated_at = $7, completed_at = $8, sensitive = $9
-		WHERE id = $10
+		SET status_id = $1, priority_id = $2, category_id = $3, assignee_user_id = $4,
+		    title = $5, description = $6, location = $7,
+		    updated_at = $8, completed_at = $9, sensitive = $10
+		WHERE id = $11
 	`
 
-	tag, err := r.db.Exec(ctx, query,
+	tag, err := conn(ctx, r.db).Exec(ctx, query,
 		ticket.StatusID,
 		ticket.PriorityID,
+		ticket.CategoryID,
 		ticket.AssigneeUserID,
 		ticket.Title,
 		ticket.Description,
@@ -261,7 +297,7 @@ func (r *TicketRepository) AddFile(ctx context.Context, file *domain.File) error
 		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at
 	`
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		file.TicketID,
 		file.Filename,
 		file.ContentType,
@@ -282,7 +318,7 @@ func (r *TicketRepository) GetFile(ctx context.Context, id uuid.UUID) (*domain.F
 		WHERE id = $1
 	`
 	var f domain.File
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&f.ID,
 		&f.TicketID,
 		&f.Filename,
@@ -309,7 +345,7 @@ func (r *TicketRepository) ListFiles(ctx context.Context, ticketID uuid.UUID) ([
 	`
 	// Note: NOT selecting data.
 
-	rows, err := r.db.Query(ctx, query, ticketID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, ticketID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list files: %w", err)
 	}
@@ -333,3 +369,65 @@ func (r *TicketRepository) ListFiles(ctx context.Context, ticketID uuid.UUID) ([
 	}
 	return files, nil
 }
+
+func (r *TicketRepository) CreateEvents(ctx context.Context, events []domain.TicketEvent) error {
+	query := `
+		INSERT INTO ticket_events (ticket_id, actor_id, event_type, field, old_value, new_value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	for i := range events {
+		e := &events[i]
+		err := conn(ctx, r.db).QueryRow(ctx, query,
+			e.TicketID,
+			e.ActorID,
+			e.EventType,
+			e.Field,
+			e.OldValue,
+			e.NewValue,
+		).Scan(&e.ID, &e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create ticket event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *TicketRepository) ListEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error) {
+	query := `
+		SELECT e.id, e.ticket_id, e.actor_id, u.name, e.event_type, e.field, e.old_value, e.new_value, e.created_at
+		FROM ticket_events e
+		LEFT JOIN users u ON u.id = e.actor_id
+		WHERE e.ticket_id = $1
+		ORDER BY e.created_at ASC, e.id ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.TicketEvent, 0)
+	for rows.Next() {
+		var e domain.TicketEvent
+		err := rows.Scan(
+			&e.ID,
+			&e.TicketID,
+			&e.ActorID,
+			&e.ActorName,
+			&e.EventType,
+			&e.Field,
+			&e.OldValue,
+			&e.NewValue,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// foreignKeyViolation is the SQLSTATE Postgres reports when a row is still
+// referenced.
+const foreignKeyViolation = "23503"
+
+type TicketStatusRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTicketStatusRepository(db *pgxpool.Pool) *TicketStatusRepository {
+	return &TicketStatusRepository{db: db}
+}
+
+func (r *TicketStatusRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketStatuses, error) {
+	query := `
+		SELECT organization_id, id, label, position, color, state
+		FROM organization_ticket_statuses
+		WHERE organization_id = $1
+		ORDER BY position ASC, id ASC
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket statuses: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(domain.TicketStatuses, 0)
+	for rows.Next() {
+		var s domain.TicketStatus
+		if err := rows.Scan(&s.OrganizationID, &s.ID, &s.Label, &s.Position, &s.Color, &s.State); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket status: %w", err)
+		}
+		statuses = append(statuses, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return statuses, nil
+}
+
+func (r *TicketStatusRepository) Create(ctx context.Context, status *domain.TicketStatus) error {
+	query := `
+		INSERT INTO organization_ticket_statuses (organization_id, id, label, position, color, state)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := conn(ctx, r.db).Exec(ctx, query, status.OrganizationID, status.ID, status.Label, status.Position, status.Color, status.State)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket status: %w", err)
+	}
+	return nil
+}
+
+func (r *TicketStatusRepository) Update(ctx context.Context, status *domain.TicketStatus) error {
+	query := `
+		UPDATE organization_ticket_statuses
+		SET label = $1, position = $2, color = $3, state = $4, updated_at = NOW()
+		WHERE organization_id = $5 AND id = $6
+	`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, status.Label, status.Position, status.Color, status.State, status.OrganizationID, status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketStatusNotFound
+	}
+	return nil
+}
+
+func (r *TicketStatusRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	query := `DELETE FROM organization_ticket_statuses WHERE organization_id = $1 AND id = $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, orgID, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return port.ErrTicketStatusInUse
+		}
+		return fmt.Errorf("failed to delete ticket status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return port.ErrTicketStatusNotFound
+	}
+	return nil
+}
@@ -20,13 +20,14 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, avatar_url, created_at, updated_at
+		SELECT id, email, name, role, avatar_url, phone, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
-	row := r.db.QueryRow(ctx, query, id)
+	row := conn(ctx, r.db).QueryRow(ctx, query, id)
 
 	var user domain.User
+	var phone *string
 	var avatarURL *string // database allows null, we need to handle it properly if domain expects string
 	// Wait, domain.User.AvatarURL is string. If DB is NULL, we should scan to sql.NullString or *string and convert.
 
@@ -36,6 +37,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		&user.Name,
 		&user.Role,
 		&avatarURL,
+		&phone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -50,6 +52,9 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	if avatarURL != nil {
 		user.AvatarURL = *avatarURL
 	}
+	if phone != nil {
+		user.Phone = *phone
+	}
 
 	return &user, nil
 }
@@ -60,11 +65,11 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 	}
 
 	query := `
-		SELECT id, email, name, role, avatar_url, created_at, updated_at
+		SELECT id, email, name, role, avatar_url, phone, created_at, updated_at
 		FROM users
 		WHERE id = ANY($1)
 	`
-	rows, err := r.db.Query(ctx, query, ids)
+	rows, err := conn(ctx, r.db).Query(ctx, query, ids)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by ids: %w", err)
 	}
@@ -73,7 +78,7 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		var avatarURL *string
+		var avatarURL, phone *string
 
 		err := rows.Scan(
 			&user.ID,
@@ -81,6 +86,7 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 			&user.Name,
 			&user.Role,
 			&avatarURL,
+			&phone,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -91,6 +97,9 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 		if avatarURL != nil {
 			user.AvatarURL = *avatarURL
 		}
+		if phone != nil {
+			user.Phone = *phone
+		}
 		users = append(users, user)
 	}
 
@@ -103,14 +112,16 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domai
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, avatar_url, created_at, updated_at
+		SELECT id, email, name, role, avatar_url, phone, created_at, updated_at
 		FROM users
-		WHERE email = $1
+		WHERE LOWER(email) = LOWER($1)
+		ORDER BY email = $1 DESC, created_at
+		LIMIT 1
 	`
-	row := r.db.QueryRow(ctx, query, email)
+	row := conn(ctx, r.db).QueryRow(ctx, query, email)
 
 	var user domain.User
-	var avatarURL *string
+	var avatarURL, phone *string
 
 	err := row.Scan(
 		&user.ID,
@@ -118,6 +129,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		&user.Name,
 		&user.Role,
 		&avatarURL,
+		&phone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -132,14 +144,17 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	if avatarURL != nil {
 		user.AvatarURL = *avatarURL
 	}
+	if phone != nil {
+		user.Phone = *phone
+	}
 
 	return &user, nil
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (email, name, role, avatar_url)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (email, name, role, avatar_url, phone)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
 	// If user.AvatarURL is empty string, do we insert NULL or empty string?
@@ -168,7 +183,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		avatarURL = &user.AvatarURL
 	}
 
-	err := r.db.QueryRow(ctx, query, user.Email, user.Name, user.Role, avatarURL).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, user.Email, user.Name, user.Role, avatarURL, nullablePhone(user.Phone)).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -179,8 +194,8 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET name = $1, role = $2, avatar_url = $3, updated_at = NOW()
-		WHERE id = $4
+		SET name = $1, role = $2, avatar_url = $3, phone = $4, updated_at = NOW()
+		WHERE id = $5
 		RETURNING updated_at
 	`
 
@@ -189,7 +204,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		avatarURL = &user.AvatarURL
 	}
 
-	err := r.db.QueryRow(ctx, query, user.Name, user.Role, avatarURL, user.ID).Scan(&user.UpdatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, user.Name, user.Role, avatarURL, nullablePhone(user.Phone), user.ID).Scan(&user.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -200,3 +215,11 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 	return nil
 }
+
+// nullablePhone stores a missing phone number as NULL.
+func nullablePhone(phone string) *string {
+	if phone == "" {
+		return nil
+	}
+	return &phone
+}
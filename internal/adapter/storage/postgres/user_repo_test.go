@@ -87,6 +87,14 @@ func TestUserRepository(t *testing.T) {
 		t.Errorf("Expected ID %v, got %v", user.ID, fetchedUserEmail.ID)
 	}
 
+	fetchedUserEmail, err = repo.GetByEmail(ctx, "Test@Example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail with different case failed: %v", err)
+	}
+	if fetchedUserEmail == nil || fetchedUserEmail.ID != user.ID {
+		t.Errorf("Expected user found by email ignoring case, got %v", fetchedUserEmail)
+	}
+
 	// Test Update
 	user.Name = "Updated Name"
 	user.Role = domain.RoleAdmin
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookEndpointColumns = `id, organization_id, url, description, format, events, priority_ids, category_ids, internal, enabled, secret, created_at, updated_at`
+
+func scanWebhookEndpoint(row pgx.Row) (*domain.WebhookEndpoint, error) {
+	var e domain.WebhookEndpoint
+	var events []string
+	err := row.Scan(
+		&e.ID,
+		&e.OrganizationID,
+		&e.URL,
+		&e.Description,
+		&e.Format,
+		&events,
+		&e.PriorityIDs,
+		&e.CategoryIDs,
+		&e.Internal,
+		&e.Enabled,
+		&e.Secret,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Events = make([]domain.NotificationEvent, len(events))
+	for i, ev := range events {
+		e.Events[i] = domain.NotificationEvent(ev)
+	}
+	return &e, nil
+}
+
+func webhookEvents(events []domain.NotificationEvent) []string {
+	out := make([]string, len(events))
+	for i, ev := range events {
+		out[i] = string(ev)
+	}
+	return out
+}
+
+// nonNilStrings keeps NOT NULL array columns from receiving NULL.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error {
+	query := `
+		INSERT INTO webhook_endpoints (organization_id, url, description, format, events, priority_ids, category_ids, internal, enabled, secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		e.OrganizationID,
+		e.URL,
+		e.Description,
+		e.Format,
+		webhookEvents(e.Events),
+		nonNilStrings(e.PriorityIDs),
+		nonNilStrings(e.CategoryIDs),
+		e.Internal,
+		e.Enabled,
+		e.Secret,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE id = $1`
+	e, err := scanWebhookEndpoint(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return e, nil
+}
+
+func (r *WebhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]domain.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE organization_id = $1 ORDER BY created_at ASC, id ASC`
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := make([]domain.WebhookEndpoint, 0)
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, format = $4, events = $5, priority_ids = $6, category_ids = $7,
+			internal = $8, enabled = $9, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		e.ID,
+		e.URL,
+		e.Description,
+		e.Format,
+		webhookEvents(e.Events),
+		nonNilStrings(e.PriorityIDs),
+		nonNilStrings(e.CategoryIDs),
+		e.Internal,
+		e.Enabled,
+	).Scan(&e.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+const webhookDeliveryColumns = `id, endpoint_id, organization_id, event, payload, status, attempts, response_code, response_body, error, created_at, last_attempt_at`
+
+func scanWebhookDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.EndpointID,
+		&d.OrganizationID,
+		&d.Event,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.ResponseCode,
+		&d.ResponseBody,
+		&d.Error,
+		&d.CreatedAt,
+		&d.LastAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, organization_id, event, payload, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		d.EndpointID,
+		d.OrganizationID,
+		d.Event,
+		[]byte(d.Payload),
+		d.Status,
+	).Scan(&d.ID, &d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	d, err := scanWebhookDelivery(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return d, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter port.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE endpoint_id = $1`
+	args := []interface{}{filter.EndpointID}
+	argIdx := 2
+
+	if filter.BeforeCreatedAt != nil {
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argIdx, argIdx+1)
+		args = append(args, *filter.BeforeCreatedAt, filter.BeforeID)
+		argIdx += 2
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) UpdateDeliveryAttempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, response_code = $3, response_body = $4, error = $5, last_attempt_at = NOW()
+		WHERE id = $1
+		RETURNING attempts, last_attempt_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, d.ID, d.Status, d.ResponseCode, d.ResponseBody, d.Error).Scan(&d.Attempts, &d.LastAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+	return nil
+}
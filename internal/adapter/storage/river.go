@@ -8,15 +8,48 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivermigrate"
 )
 
-// InitRiver initializes the River client.
-func InitRiver(ctx context.Context, pool *pgxpool.Pool) (*river.Client[pgx.Tx], error) {
+// RunRiverMigrations creates or upgrades the tables River needs for its job queue.
+func RunRiverMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	migrator, err := rivermigrate.New(riverpgxv5.New(pool), nil)
+	if err != nil {
+		return fmt.Errorf("error creating river migrator: %w", err)
+	}
+
+	if _, err := migrator.Migrate(ctx, rivermigrate.DirectionUp, nil); err != nil {
+		return fmt.Errorf("error running river migrations: %w", err)
+	}
+
+	return nil
+}
+
+// InitRiver initializes the River client with the given workers and periodic jobs.
+func InitRiver(ctx context.Context, pool *pgxpool.Pool, workers *river.Workers, periodicJobs []*river.PeriodicJob) (*river.Client[pgx.Tx], error) {
 	// Create a new River client.
-	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{})
+	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{
+		Queues: map[string]river.QueueConfig{
+			river.QueueDefault: {MaxWorkers: 10},
+		},
+		Workers:      workers,
+		PeriodicJobs: periodicJobs,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing river client: %w", err)
 	}
 
 	return riverClient, nil
 }
+
+// InitRiverInserter initializes an insert-only River client. Services that
+// enqueue jobs use it, since the worker client cannot be built until the
+// services its workers depend on exist.
+func InitRiverInserter(pool *pgxpool.Pool) (*river.Client[pgx.Tx], error) {
+	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing river inserter: %w", err)
+	}
+
+	return riverClient, nil
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type APIKeyHandler struct {
+	service port.APIKeyService
+	logger  *slog.Logger
+}
+
+func NewAPIKeyHandler(service port.APIKeyService, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type createAPIKeyRequest struct {
+	Name   string               `json:"name"`
+	Scopes []domain.APIKeyScope `json:"scopes"`
+}
+
+// createAPIKeyResponse is the only response that includes the token.
+type createAPIKeyResponse struct {
+	*domain.APIKey
+	Token string `json:"token"`
+}
+
+// ListMine returns the current user's personal access tokens.
+func (h *APIKeyHandler) ListMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	keys, err := h.service.ListPersonal(r.Context(), user.ID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, keys)
+}
+
+// CreateMine issues a personal access token that acts as the current user.
+func (h *APIKeyHandler) CreateMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req createAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.create(w, r, port.CreateAPIKeyCmd{
+		UserID: user.ID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	})
+}
+
+func (h *APIKeyHandler) RevokeMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyID"))
+	if err != nil {
+		http.Error(w, "Invalid API Key ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RevokePersonal(r.Context(), user.ID, keyID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOrganization returns the organization's service keys. Like webhooks,
+// service keys are managed by owners and admins only.
+func (h *APIKeyHandler) ListOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListByOrganization(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, keys)
+}
+
+// CreateOrganization issues a service key for the organization. The key acts
+// as the admin creating it.
+func (h *APIKeyHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req createAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	h.create(w, r, port.CreateAPIKeyCmd{
+		UserID:         middleware.GetUser(r.Context()).ID,
+		OrganizationID: &orgID,
+		Name:           req.Name,
+		Scopes:         req.Scopes,
+	})
+}
+
+func (h *APIKeyHandler) RevokeOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyID"))
+	if err != nil {
+		http.Error(w, "Invalid API Key ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RevokeForOrganization(r.Context(), orgID, keyID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) create(w http.ResponseWriter, r *http.Request, cmd port.CreateAPIKeyCmd) {
+	token, key, err := h.service.Create(r.Context(), cmd)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, createAPIKeyResponse{APIKey: key, Token: token})
+}
+
+func (h *APIKeyHandler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *APIKeyHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, port.ErrInvalidAPIKey):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrAPIKeyNotFound):
+		http.Error(w, "API key not found", http.StatusNotFound)
+	default:
+		h.logger.Error("failed to handle api key request", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
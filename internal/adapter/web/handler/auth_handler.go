@@ -2,41 +2,88 @@ package handler
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"html/template"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
 	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
 )
 
 type AuthHandler struct {
-	service port.AuthService
-	orgRepo port.OrganizationRepository
-	logger  *slog.Logger
-	secret  []byte
+	service    port.AuthService
+	sessions   port.SessionService
+	magicLinks port.MagicLinkService
+	orgRepo    port.OrganizationRepository
+	inbox      port.InboxService
+	users      port.UserService
+	logger     *slog.Logger
+	secret     []byte
 }
 
-func NewAuthHandler(service port.AuthService, orgRepo port.OrganizationRepository, logger *slog.Logger, secret string) *AuthHandler {
+// NewAuthHandler creates an AuthHandler. magicLinks may be nil to turn
+// email sign-in links off.
+func NewAuthHandler(service port.AuthService, sessions port.SessionService, magicLinks port.MagicLinkService, orgRepo port.OrganizationRepository, inbox port.InboxService, users port.UserService, logger *slog.Logger, secret string) *AuthHandler {
 	return &AuthHandler{
-		service: service,
-		orgRepo: orgRepo,
-		logger:  logger,
-		secret:  []byte(secret),
+		service:    service,
+		sessions:   sessions,
+		magicLinks: magicLinks,
+		orgRepo:    orgRepo,
+		inbox:      inbox,
+		users:      users,
+		logger:     logger,
+		secret:     []byte(secret),
 	}
 }
 
+// Providers lists the sign-in providers, default first, for the login page.
+func (h *AuthHandler) Providers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.service.Providers()); err != nil {
+		h.logger.Error("failed to write response", "error", err)
+	}
+}
+
+// Login redirects to the provider named by the provider query parameter, or
+// to the default one. The state carries the provider's name so the shared
+// callback knows which provider to redeem the code with.
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	state, err := generateState()
+	provider := r.URL.Query().Get("provider")
+	if provider == "" {
+		if providers := h.service.Providers(); len(providers) > 0 {
+			provider = providers[0].Name
+		}
+	}
+
+	random, err := generateState()
 	if err != nil {
 		h.logger.Error("failed to generate state", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
+	state := provider + "." + random
+
+	url, err := h.service.GetLoginURL(provider, state)
+	if err != nil {
+		if errors.Is(err, port.ErrUnknownProvider) {
+			http.Error(w, "Unknown sign-in provider", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get login url", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
 
 	secure := true
 	if os.Getenv("APP_ENV") == "development" {
@@ -53,7 +100,6 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		Expires:  time.Now().Add(15 * time.Minute),
 	})
 
-	url := h.service.GetLoginURL(state)
 	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
 }
 
@@ -91,43 +137,225 @@ func (h *AuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		Expires:  time.Now().Add(-1 * time.Hour),
 	})
 
-	user, err := h.service.LoginFromProvider(r.Context(), code)
+	provider, _, _ := strings.Cut(state, ".")
+	user, err := h.service.LoginFromProvider(r.Context(), provider, code)
 	if err != nil {
 		h.logger.Error("failed to login from provider", "error", err)
 		http.Error(w, "Login failed", http.StatusInternalServerError)
 		return
 	}
 
-	sessionID, err := h.service.CreateSession(r.Context(), user)
+	if err := h.startSession(w, r, user, secure); err != nil {
+		h.logger.Error("failed to create session", "error", err)
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+}
+
+type requestMagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// RequestMagicLink emails a single-use sign-in link. It answers the same
+// whether or not the address has an account.
+func (h *AuthHandler) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	if h.magicLinks == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req requestMagicLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.magicLinks.RequestLink(r.Context(), req.Email, clientIP(r)); err != nil {
+		switch {
+		case errors.Is(err, port.ErrInvalidEmail):
+			http.Error(w, "Invalid email address", http.StatusBadRequest)
+		case errors.Is(err, port.ErrRateLimited):
+			w.Header().Set("Retry-After", "3600")
+			http.Error(w, "Too many sign-in links requested, try again later", http.StatusTooManyRequests)
+		default:
+			h.logger.Error("failed to request magic link", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// magicLinkNonceCookie ties the confirm form to the browser it was served
+// to, so another site cannot post its own token and sign the visitor in to
+// the wrong account.
+const magicLinkNonceCookie = "magic_link_nonce"
+
+// magicLinkConfirmPage asks the user to confirm the sign-in. Mail scanners
+// and link previews follow the emailed link, so opening it must not use up
+// the token; only the form's POST does.
+var magicLinkConfirmPage = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="referrer" content="no-referrer">
+<title>Sign in to OpsDeck</title>
+</head>
+<body style="font-family: sans-serif; display: flex; justify-content: center; padding-top: 4rem;">
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<input type="hidden" name="nonce" value="{{.Nonce}}">
+<p>Continue to sign in to OpsDeck.</p>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+// MagicLinkConfirm is where emailed sign-in links land. It only renders a
+// page that posts the token back to MagicLinkCallback, along with a nonce
+// that must match the cookie set here.
+func (h *AuthHandler) MagicLinkConfirm(w http.ResponseWriter, r *http.Request) {
+	if h.magicLinks == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	nonce, err := generateState()
 	if err != nil {
+		h.logger.Error("failed to generate magic link nonce", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	secure := true
+	if os.Getenv("APP_ENV") == "development" {
+		secure = false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     magicLinkNonceCookie,
+		Value:    nonce,
+		Path:     service.MagicLinkCallbackPath,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+		Expires:  time.Now().Add(15 * time.Minute),
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	err = magicLinkConfirmPage.Execute(w, map[string]string{
+		"Action": service.MagicLinkCallbackPath,
+		"Token":  r.URL.Query().Get("token"),
+		"Nonce":  nonce,
+	})
+	if err != nil {
+		h.logger.Error("failed to render magic link page", "error", err)
+	}
+}
+
+// MagicLinkCallback exchanges a confirmed link's token for a session like
+// Callback does for a provider's code.
+func (h *AuthHandler) MagicLinkCallback(w http.ResponseWriter, r *http.Request) {
+	if h.magicLinks == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Only a form served by MagicLinkConfirm to this browser carries the
+	// nonce in its cookie; the token is not looked at otherwise.
+	cookie, err := r.Cookie(magicLinkNonceCookie)
+	nonce := r.PostFormValue("nonce")
+	if err != nil || cookie.Value == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(nonce)) != 1 {
+		http.Error(w, "Invalid sign-in request, open the link from your email again", http.StatusBadRequest)
+		return
+	}
+
+	secure := true
+	if os.Getenv("APP_ENV") == "development" {
+		secure = false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     magicLinkNonceCookie,
+		Value:    "",
+		Path:     service.MagicLinkCallbackPath,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   -1,
+		Expires:  time.Now().Add(-1 * time.Hour),
+	})
+
+	user, err := h.magicLinks.Login(r.Context(), r.PostFormValue("token"))
+	if err != nil {
+		h.logger.Error("failed to login with magic link", "error", err)
+		http.Error(w, "Login failed", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "This sign-in link is invalid or has expired", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.startSession(w, r, user, secure); err != nil {
 		h.logger.Error("failed to create session", "error", err)
 		http.Error(w, "Failed to create session", http.StatusInternalServerError)
 		return
 	}
 
-	signedSessionID := middleware.SignSessionID(sessionID, h.secret)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// startSession creates a server-side session for user and hands the browser
+// its signed token. The cookie lives as long as a session can; the server
+// ends it sooner if it goes unused.
+func (h *AuthHandler) startSession(w http.ResponseWriter, r *http.Request, user *domain.User, secure bool) error {
+	token, _, err := h.sessions.Create(r.Context(), user.ID, port.SessionInfo{
+		IPAddress: clientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
 
 	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    signedSessionID,
+		Name:     middleware.SessionCookieName,
+		Value:    middleware.SignSessionID(token, h.secret),
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   secure,
 		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(24 * time.Hour), // Set a reasonable expiration
+		Expires:  time.Now().Add(service.SessionMaxAge),
 	})
-
-	http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+	return nil
 }
 
+// Logout ends the session on the server as well as clearing the cookie, so a
+// copied cookie stops working too.
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	secure := true
 	if os.Getenv("APP_ENV") == "development" {
 		secure = false
 	}
 
+	if cookie, err := r.Cookie(middleware.SessionCookieName); err == nil {
+		if token, ok := middleware.VerifySessionID(cookie.Value, h.secret); ok {
+			if err := h.sessions.RevokeToken(r.Context(), token); err != nil {
+				h.logger.Error("failed to revoke session", "error", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
 	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
+		Name:     middleware.SessionCookieName,
 		Value:    "",
 		Path:     "/",
 		HttpOnly: true,
@@ -141,8 +369,9 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 }
 
 type MeResponse struct {
-	User          *domain.User            `json:"user"`
-	Organizations []domain.UserMembership `json:"organizations"`
+	User                *domain.User            `json:"user"`
+	Organizations       []domain.UserMembership `json:"organizations"`
+	UnreadNotifications int                     `json:"unread_notifications"`
 }
 
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
@@ -152,16 +381,24 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	organizations, err := h.orgRepo.ListByUser(r.Context(), user.ID)
+	organizations, err := listMemberships(r.Context(), h.orgRepo, user.ID)
 	if err != nil {
 		h.logger.Error("failed to list user organizations", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
+	unread, err := h.inbox.CountUnread(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to count unread notifications", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
 	resp := MeResponse{
-		User:          user,
-		Organizations: organizations,
+		User:                user,
+		Organizations:       organizations,
+		UnreadNotifications: unread,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -171,6 +408,113 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type updatePhoneRequest struct {
+	Phone string `json:"phone"`
+}
+
+// UpdatePhone sets the number the current user receives SMS notifications
+// on. An empty phone removes it.
+func (h *AuthHandler) UpdatePhone(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req updatePhoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.users.UpdatePhone(r.Context(), user.ID, req.Phone)
+	if err != nil {
+		if errors.Is(err, port.ErrInvalidPhone) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to update phone", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		h.logger.Error("failed to write response", "error", err)
+	}
+}
+
+// ListSessions returns the current user's signed-in sessions, with the one
+// making the request marked as current.
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.sessions.List(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list sessions", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if current := middleware.GetSession(r.Context()); current != nil {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == current.ID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessions); err != nil {
+		h.logger.Error("failed to write response", "error", err)
+	}
+}
+
+// RevokeOtherSessions signs the current user out everywhere except the
+// session making the request.
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	current := middleware.GetSession(r.Context())
+	if user == nil || current == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := h.sessions.RevokeOthers(r.Context(), user.ID, current.ID); err != nil {
+		h.logger.Error("failed to revoke sessions", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeSession signs one of the current user's sessions out.
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		http.Error(w, "Invalid Session ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.sessions.Revoke(r.Context(), user.ID, sessionID); err != nil {
+		if errors.Is(err, port.ErrSessionNotFound) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to revoke session", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func generateState() (string, error) {
 	b := make([]byte, 16)
 	if _, err := rand.Read(b); err != nil {
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeMagicLinkService accepts a single token and counts logins.
+type fakeMagicLinkService struct {
+	port.MagicLinkService
+	token  string
+	user   *domain.User
+	logins int
+}
+
+func (f *fakeMagicLinkService) Login(ctx context.Context, token string) (*domain.User, error) {
+	f.logins++
+	if token != f.token {
+		return nil, nil
+	}
+	return f.user, nil
+}
+
+type fakeSessionService struct {
+	port.SessionService
+}
+
+func (f *fakeSessionService) Create(ctx context.Context, userID uuid.UUID, info port.SessionInfo) (string, *domain.Session, error) {
+	return "session-token", &domain.Session{}, nil
+}
+
+func TestMagicLinkCallback(t *testing.T) {
+	newHandler := func() (*handler.AuthHandler, *fakeMagicLinkService) {
+		links := &fakeMagicLinkService{token: "good", user: &domain.User{ID: uuid.New()}}
+		return handler.NewAuthHandler(nil, &fakeSessionService{}, links, nil, nil, nil, nil, "secret"), links
+	}
+
+	// post submits the confirm form; nonceCookie is left off when empty.
+	post := func(h *handler.AuthHandler, token, nonce, nonceCookie string) *httptest.ResponseRecorder {
+		form := url.Values{"token": {token}, "nonce": {nonce}}
+		req := httptest.NewRequest(http.MethodPost, "/auth/magic-link/callback", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if nonceCookie != "" {
+			req.AddCookie(&http.Cookie{Name: "magic_link_nonce", Value: nonceCookie})
+		}
+		w := httptest.NewRecorder()
+		h.MagicLinkCallback(w, req)
+		return w
+	}
+
+	t.Run("Opening the link does not consume the token", func(t *testing.T) {
+		h, links := newHandler()
+		req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/callback?token=%22good%3E", nil)
+		w := httptest.NewRecorder()
+
+		h.MagicLinkConfirm(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0, links.logins)
+		assert.Contains(t, w.Body.String(), `<form method="post" action="/auth/magic-link/callback">`)
+		assert.Contains(t, w.Body.String(), `value="&#34;good&gt;"`)
+		cookies := w.Result().Cookies()
+		if assert.Len(t, cookies, 1) {
+			assert.Equal(t, "magic_link_nonce", cookies[0].Name)
+			assert.Equal(t, "/auth/magic-link/callback", cookies[0].Path)
+			assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+			assert.True(t, cookies[0].HttpOnly)
+			assert.Contains(t, w.Body.String(), `name="nonce" value="`+cookies[0].Value+`"`)
+		}
+	})
+
+	t.Run("Posting the token signs in", func(t *testing.T) {
+		h, links := newHandler()
+
+		w := post(h, "good", "nonce", "nonce")
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, 1, links.logins)
+		var names []string
+		for _, c := range w.Result().Cookies() {
+			names = append(names, c.Name)
+		}
+		assert.ElementsMatch(t, []string{"magic_link_nonce", middleware.SessionCookieName}, names)
+	})
+
+	t.Run("Posting an unknown token fails", func(t *testing.T) {
+		h, _ := newHandler()
+
+		w := post(h, "bad", "nonce", "nonce")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		for _, c := range w.Result().Cookies() {
+			assert.NotEqual(t, middleware.SessionCookieName, c.Name)
+		}
+	})
+
+	t.Run("Posting without the nonce cookie is rejected", func(t *testing.T) {
+		h, links := newHandler()
+
+		w := post(h, "good", "nonce", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, 0, links.logins)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("Posting a mismatched nonce is rejected", func(t *testing.T) {
+		h, links := newHandler()
+
+		w := post(h, "good", "attacker", "nonce")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, 0, links.logins)
+		assert.Empty(t, w.Result().Cookies())
+	})
+}
@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -20,7 +18,7 @@ type CommentHandler struct {
 	commentService port.CommentService
 	ticketService  *service.TicketService
 	userRepo       port.UserRepository
-	orgRepo        port.OrganizationRepository
+	authz          port.Authorizer
 	logger         *slog.Logger
 }
 
@@ -28,14 +26,14 @@ func NewCommentHandler(
 	commentService port.CommentService,
 	ticketService *service.TicketService,
 	userRepo port.UserRepository,
-	orgRepo port.OrganizationRepository,
+	authz port.Authorizer,
 	logger *slog.Logger,
 ) *CommentHandler {
 	return &CommentHandler{
 		commentService: commentService,
 		ticketService:  ticketService,
 		userRepo:       userRepo,
-		orgRepo:        orgRepo,
+		authz:          authz,
 		logger:         logger,
 	}
 }
@@ -85,10 +83,7 @@ func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify user is member of organization
-	if err := h.checkOrgAccess(r.Context(), user.ID, ticket.OrganizationID); err != nil {
-		h.logger.Warn("Unauthorized access attempt to ticket comment", "user_id", user.ID, "ticket_id", ticketID, "error", err)
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionCommentCreate, domain.OrgResource(ticket.OrganizationID)) {
 		return
 	}
 
@@ -158,10 +153,7 @@ func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify user is member of organization
-	if err := h.checkOrgAccess(r.Context(), user.ID, ticket.OrganizationID); err != nil {
-		h.logger.Warn("Unauthorized access attempt to list comments", "user_id", user.ID, "ticket_id", ticketID, "error", err)
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionCommentView, domain.OrgResource(ticket.OrganizationID)) {
 		return
 	}
 
@@ -214,25 +206,3 @@ func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("Failed to encode response", "error", err)
 	}
 }
-
-func (h *CommentHandler) checkOrgAccess(ctx context.Context, userID, orgID uuid.UUID) error {
-	memberships, err := h.orgRepo.ListByUser(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to list user memberships: %w", err)
-	}
-
-	for _, m := range memberships {
-		// UserMembership embeds Organization, so we access OrganizationID via m.ID (since UserMembership embeds Organization struct)
-		// Wait, UserMembership struct is:
-		// type UserMembership struct {
-		// 	Organization
-		// 	Role string
-		// }
-		// So m.ID is the Organization ID.
-		if m.ID == orgID {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("user is not a member of organization %s", orgID)
-}
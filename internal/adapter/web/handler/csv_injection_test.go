@@ -17,13 +17,14 @@ import (
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
 	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
 )
 
 func TestExportTickets_CSVInjection(t *testing.T) {
 	mockService := new(MockTicketService)
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 	r := chi.NewRouter()
 	r.Get("/admin/export/tickets", h.ExportTickets)
@@ -45,6 +46,7 @@ func TestExportTickets_CSVInjection(t *testing.T) {
 	// Payload that triggers CSV injection (Formula Injection)
 	maliciousTitle := "=cmd|' /C calc'!A0"
 	maliciousDesc := "+SUM(1+1)*cmd|' /C calc'!A0"
+	category := "plumbing"
 
 	tickets := []domain.Ticket{
 		{
@@ -54,6 +56,7 @@ func TestExportTickets_CSVInjection(t *testing.T) {
 			ReporterID:     uuid.New(),
 			StatusID:       "open",
 			PriorityID:     "high",
+			CategoryID:     &category,
 			CreatedAt:      time.Now(),
 			Description:    maliciousDesc,
 		},
@@ -89,7 +92,9 @@ func TestExportTickets_CSVInjection(t *testing.T) {
 
 	// Row 1 is header, Row 2 is data
 	titleCell := records[1][2]
-	descCell := records[1][7]
+	descCell := records[1][8]
+	assert.Equal(t, "Category", records[0][5])
+	assert.Equal(t, category, records[1][5])
 
 	// It should now be sanitized (prefixed with single quote)
 	assert.Equal(t, "'"+maliciousTitle, titleCell, "Title should be sanitized")
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type DigestHandler struct {
+	service port.DigestService
+	logger  *slog.Logger
+}
+
+func NewDigestHandler(service port.DigestService, logger *slog.Logger) *DigestHandler {
+	return &DigestHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type updateDigestRequest struct {
+	Frequency domain.DigestFrequency `json:"frequency"`
+	Timezone  string                 `json:"timezone"`
+}
+
+// GetMine returns the current user's digest settings.
+func (h *DigestHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), user.ID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, settings)
+}
+
+// UpdateMine sets how often the current user receives a digest and in which
+// timezone it is scheduled.
+func (h *DigestHandler) UpdateMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req updateDigestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), user.ID, domain.DigestSettings{
+		Frequency: req.Frequency,
+		Timezone:  req.Timezone,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, settings)
+}
+
+func (h *DigestHandler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *DigestHandler) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, port.ErrInvalidDigestSettings) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.logger.Error("failed to handle digest request", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
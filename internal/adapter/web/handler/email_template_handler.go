@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type EmailTemplateHandler struct {
+	service port.EmailTemplateService
+	logger  *slog.Logger
+}
+
+func NewEmailTemplateHandler(service port.EmailTemplateService, logger *slog.Logger) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type emailTemplateRequest struct {
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
+
+type previewEmailRequest struct {
+	emailTemplateRequest
+	Public bool `json:"public"`
+}
+
+// List returns the template in effect for every event. Any member may read
+// them.
+func (h *EmailTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	templates, err := h.service.ListTemplates(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to list email templates", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h.writeJSON(w, templates)
+}
+
+// Update replaces the organization's template for one event.
+func (h *EmailTemplateHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req emailTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	tmpl, err := h.service.SetTemplate(r.Context(), port.SetEmailTemplateCmd{
+		OrganizationID: orgID,
+		Event:          domain.NotificationEvent(chi.URLParam(r, "event")),
+		Subject:        req.Subject,
+		TextBody:       req.TextBody,
+		HTMLBody:       req.HTMLBody,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, tmpl)
+}
+
+// Reset drops the organization's template for one event and returns the
+// default that replaces it.
+func (h *EmailTemplateHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	tmpl, err := h.service.ResetTemplate(r.Context(), orgID, domain.NotificationEvent(chi.URLParam(r, "event")))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, tmpl)
+}
+
+// Preview renders an event's email against a sample ticket. Template parts in
+// the body are rendered in place of the saved ones without being saved.
+func (h *EmailTemplateHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req previewEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	msg, err := h.service.Preview(r.Context(), port.PreviewEmailCmd{
+		OrganizationID: orgID,
+		Event:          domain.NotificationEvent(chi.URLParam(r, "event")),
+		Subject:        req.Subject,
+		TextBody:       req.TextBody,
+		HTMLBody:       req.HTMLBody,
+		Public:         req.Public,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, msg)
+}
+
+// GetBranding returns the organization's logo and footer.
+func (h *EmailTemplateHandler) GetBranding(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	branding, err := h.service.GetBranding(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to get email branding", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h.writeJSON(w, branding)
+}
+
+// UpdateBranding replaces the organization's logo and footer.
+func (h *EmailTemplateHandler) UpdateBranding(w http.ResponseWriter, r *http.Request) {
+	var req domain.EmailBranding
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	req.OrganizationID = orgID
+
+	branding, err := h.service.UpdateBranding(r.Context(), req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, branding)
+}
+
+func (h *EmailTemplateHandler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *EmailTemplateHandler) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, port.ErrInvalidEmailTemplate) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.logger.Error("failed to update email template", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
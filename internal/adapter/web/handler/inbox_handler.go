@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type InboxHandler struct {
+	service port.InboxService
+	logger  *slog.Logger
+}
+
+func NewInboxHandler(service port.InboxService, logger *slog.Logger) *InboxHandler {
+	return &InboxHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List returns a page of the current user's notifications, newest first.
+// With unread=true only unread notifications are returned.
+func (h *InboxHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	query := port.InboxQuery{Cursor: q.Get("cursor")}
+	if s := q.Get("unread"); s != "" {
+		unread, err := strconv.ParseBool(s)
+		if err != nil {
+			http.Error(w, "Invalid unread", http.StatusBadRequest)
+			return
+		}
+		query.UnreadOnly = unread
+	}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = limit
+	}
+
+	page, err := h.service.List(r.Context(), user.ID, query)
+	if err != nil {
+		if errors.Is(err, port.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list notifications", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// MarkRead marks one of the current user's notifications read.
+func (h *InboxHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "notificationID"))
+	if err != nil {
+		http.Error(w, "Invalid Notification ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkRead(r.Context(), user.ID, id); err != nil {
+		if errors.Is(err, port.ErrInboxNotificationNotFound) {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to mark notification read", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllRead marks every notification of the current user read.
+func (h *InboxHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.MarkAllRead(r.Context(), user.ID); err != nil {
+		h.logger.Error("failed to mark notifications read", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
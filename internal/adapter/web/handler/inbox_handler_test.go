@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockInboxService struct {
+	mock.Mock
+}
+
+func (m *MockInboxService) List(ctx context.Context, userID uuid.UUID, query port.InboxQuery) (*domain.InboxPage, error) {
+	args := m.Called(ctx, userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboxPage), args.Error(1)
+}
+
+func (m *MockInboxService) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxService) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockInboxService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestInboxHandler(t *testing.T) {
+	userID := uuid.New()
+
+	setup := func() (*chi.Mux, *MockInboxService) {
+		svc := new(MockInboxService)
+		h := handler.NewInboxHandler(svc, nil)
+		r := chi.NewRouter()
+		r.Get("/me/notifications", h.List)
+		r.Post("/me/notifications/read-all", h.MarkAllRead)
+		r.Post("/me/notifications/{notificationID}/read", h.MarkRead)
+		return r, svc
+	}
+
+	do := func(r http.Handler, method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &domain.User{ID: userID}))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("List passes the unread filter", func(t *testing.T) {
+		r, svc := setup()
+		page := &domain.InboxPage{Notifications: []domain.InboxNotification{{ID: uuid.New(), Summary: "New ticket submitted"}}}
+		svc.On("List", mock.Anything, userID, port.InboxQuery{UnreadOnly: true, Limit: 10}).Return(page, nil)
+
+		w := do(r, http.MethodGet, "/me/notifications?unread=true&limit=10")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var got domain.InboxPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, "New ticket submitted", got.Notifications[0].Summary)
+	})
+
+	t.Run("List rejects bad parameters", func(t *testing.T) {
+		r, svc := setup()
+		svc.On("List", mock.Anything, userID, port.InboxQuery{Cursor: "bad"}).Return(nil, port.ErrInvalidCursor)
+
+		assert.Equal(t, http.StatusBadRequest, do(r, http.MethodGet, "/me/notifications?unread=maybe").Code)
+		assert.Equal(t, http.StatusBadRequest, do(r, http.MethodGet, "/me/notifications?limit=0").Code)
+		assert.Equal(t, http.StatusBadRequest, do(r, http.MethodGet, "/me/notifications?cursor=bad").Code)
+	})
+
+	t.Run("MarkRead of another user's notification is not found", func(t *testing.T) {
+		r, svc := setup()
+		id := uuid.New()
+		svc.On("MarkRead", mock.Anything, userID, id).Return(port.ErrInboxNotificationNotFound)
+
+		w := do(r, http.MethodPost, "/me/notifications/"+id.String()+"/read")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("MarkAllRead", func(t *testing.T) {
+		r, svc := setup()
+		svc.On("MarkAllRead", mock.Anything, userID).Return(nil).Once()
+
+		w := do(r, http.MethodPost, "/me/notifications/read-all")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		svc.AssertExpectations(t)
+	})
+}
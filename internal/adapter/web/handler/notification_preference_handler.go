@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type NotificationPreferenceHandler struct {
+	service port.NotificationPreferenceService
+	logger  *slog.Logger
+}
+
+func NewNotificationPreferenceHandler(service port.NotificationPreferenceService, logger *slog.Logger) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetMine returns the current user's own preferences. Events without an entry
+// follow the organization default.
+func (h *NotificationPreferenceHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	prefs, err := h.service.ListUserPreferences(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list notification preferences", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h.writePreferences(w, prefs)
+}
+
+// UpdateMine replaces the current user's preferences.
+func (h *NotificationPreferenceHandler) UpdateMine(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req domain.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	prefs, err := h.service.SetUserPreferences(r.Context(), user.ID, req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writePreferences(w, prefs)
+}
+
+// GetOrganization returns the organization's default preferences. Any member
+// may read them.
+func (h *NotificationPreferenceHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.service.ListOrganizationPreferences(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to list notification preferences", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h.writePreferences(w, prefs)
+}
+
+// UpdateOrganization replaces the organization's default preferences.
+func (h *NotificationPreferenceHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req domain.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.service.SetOrganizationPreferences(r.Context(), orgID, req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writePreferences(w, prefs)
+}
+
+func (h *NotificationPreferenceHandler) writePreferences(w http.ResponseWriter, prefs domain.NotificationPreferences) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *NotificationPreferenceHandler) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, port.ErrInvalidNotificationPreference) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.logger.Error("failed to update notification preferences", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// recordAudit appends an entry to the organization's audit log. Callers run
+// it in the same transaction as the change it records, so a change is never
+// saved without its entry.
+func (h *OrgHandler) recordAudit(ctx context.Context, r *http.Request, orgID, actorID uuid.UUID, action, targetType string, targetID uuid.UUID, before, after any) error {
+	entry := &domain.AuditEntry{
+		OrganizationID: orgID,
+		ActorID:        &actorID,
+		Action:         action,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		IPAddress:      clientIP(r),
+		UserAgent:      r.UserAgent(),
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return fmt.Errorf("failed to encode audit state: %w", err)
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return fmt.Errorf("failed to encode audit state: %w", err)
+		}
+	}
+
+	if err := h.auditService.Record(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry %s: %w", action, err)
+	}
+	return nil
+}
+
+// clientIP returns the peer address of the request without its port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ListAuditLog returns a page of the organization's audit log, newest first.
+// Only owners and admins may read it.
+func (h *OrgHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	orgID, query, ok := h.parseAuditRequest(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := h.auditService.List(r.Context(), orgID, query)
+	if err != nil {
+		if errors.Is(err, port.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list audit log", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// ExportAuditLog downloads every audit entry matching the filters as CSV
+// (the default) or, with format=json, as a JSON array.
+func (h *OrgHandler) ExportAuditLog(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	orgID, query, ok := h.parseAuditRequest(w, r)
+	if !ok {
+		return
+	}
+	query.Cursor = ""
+	query.Limit = 0
+
+	entries := make([]domain.AuditEntry, 0)
+	for {
+		page, err := h.auditService.List(r.Context(), orgID, query)
+		if err != nil {
+			h.logger.Error("failed to list audit log for export", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		entries = append(entries, page.Entries...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"audit.json\"")
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			h.logger.Error("failed to encode response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"audit.csv\"")
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{"ID", "Created At", "Actor ID", "Actor Name", "Action", "Target Type", "Target ID", "Before", "After", "IP Address", "User Agent"}
+	if err := writer.Write(header); err != nil {
+		h.logger.Error("failed to write csv header", "error", err)
+		return
+	}
+
+	for _, e := range entries {
+		var actorID, actorName string
+		if e.ActorID != nil {
+			actorID = e.ActorID.String()
+		}
+		if e.ActorName != nil {
+			actorName = *e.ActorName
+		}
+		row := []string{
+			e.ID.String(),
+			e.CreatedAt.Format(time.RFC3339),
+			actorID,
+			sanitizeCSV(actorName),
+			e.Action,
+			e.TargetType,
+			e.TargetID.String(),
+			sanitizeCSV(string(e.Before)),
+			sanitizeCSV(string(e.After)),
+			e.IPAddress,
+			sanitizeCSV(e.UserAgent),
+		}
+		if err := writer.Write(row); err != nil {
+			h.logger.Error("failed to write csv row", "error", err)
+			return
+		}
+	}
+}
+
+// parseAuditRequest parses the organization and filters shared by the audit
+// endpoints. It writes the error response itself and reports whether the
+// request may proceed.
+func (h *OrgHandler) parseAuditRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, port.AuditQuery, bool) {
+	var query port.AuditQuery
+
+	orgIDStr := chi.URLParam(r, "id")
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		http.Error(w, "Invalid Organization ID", http.StatusBadRequest)
+		return orgID, query, false
+	}
+
+	q := r.URL.Query()
+	query.Action = q.Get("action")
+	query.Cursor = q.Get("cursor")
+
+	if s := q.Get("actor_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			http.Error(w, "Invalid actor_id", http.StatusBadRequest)
+			return orgID, query, false
+		}
+		query.ActorID = &id
+	}
+	if s := q.Get("target_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			http.Error(w, "Invalid target_id", http.StatusBadRequest)
+			return orgID, query, false
+		}
+		query.TargetID = &id
+	}
+	if s := q.Get("from"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(w, "Invalid from", http.StatusBadRequest)
+			return orgID, query, false
+		}
+		query.From = &t
+	}
+	if s := q.Get("to"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(w, "Invalid to", http.StatusBadRequest)
+			return orgID, query, false
+		}
+		query.To = &t
+	}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return orgID, query, false
+		}
+		query.Limit = limit
+	}
+
+	return orgID, query, true
+}
@@ -0,0 +1,303 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) List(ctx context.Context, orgID uuid.UUID, query port.AuditQuery) (*domain.AuditPage, error) {
+	args := m.Called(ctx, orgID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuditPage), args.Error(1)
+}
+
+// newMockAuditService accepts any Record call, for tests that do not assert
+// on the audit log.
+func newMockAuditService() *MockAuditService {
+	m := new(MockAuditService)
+	m.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+// passthroughTx runs the function directly; handler tests have no database.
+type passthroughTx struct{}
+
+func (passthroughTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestUpdateMemberRole_RecordsAudit(t *testing.T) {
+	mockOrgRepo := new(MockOrgRepo)
+	mockUserRepo := new(MockUserRepo)
+	mockAudit := new(MockAuditService)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, mockAudit, nil, authz, passthroughTx{}, nil)
+
+	r := chi.NewRouter()
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionMemberChangeRole)).Put("/organizations/{id}/members/{userID}/role", h.UpdateMemberRole)
+
+	orgID := uuid.New()
+	ownerID := uuid.New()
+	memberID := uuid.New()
+
+	mockOrgRepo.On("ListByUser", mock.Anything, ownerID).Return([]domain.UserMembership{
+		{Organization: domain.Organization{ID: orgID}, Role: "owner"},
+	}, nil)
+	mockOrgRepo.On("ListMembers", mock.Anything, orgID).Return([]domain.Member{
+		{UserID: ownerID, Role: "owner"},
+		{UserID: memberID, Role: "member"},
+	}, nil)
+	mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, memberID, domain.OrgRoleAdmin).Return(nil)
+	mockAudit.On("Record", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.OrganizationID == orgID &&
+			*e.ActorID == ownerID &&
+			e.Action == domain.AuditActionMemberRoleChanged &&
+			e.TargetType == domain.AuditTargetUser &&
+			e.TargetID == memberID &&
+			string(e.Before) == `{"role":"member"}` &&
+			string(e.After) == `{"role":"admin"}` &&
+			e.IPAddress == "192.0.2.1" &&
+			e.UserAgent == "test-agent"
+	})).Return(nil).Once()
+
+	body, _ := json.Marshal(map[string]string{"role": "admin"})
+	req := httptest.NewRequest("PUT", "/organizations/"+orgID.String()+"/members/"+memberID.String()+"/role", bytes.NewReader(body))
+	req.Header.Set("User-Agent", "test-agent")
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &domain.User{ID: ownerID}))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUpdateMemberRole_AuditFailure(t *testing.T) {
+	mockOrgRepo := new(MockOrgRepo)
+	mockAudit := new(MockAuditService)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, new(MockUserRepo), mockAudit, nil, authz, passthroughTx{}, slog.New(slog.DiscardHandler))
+
+	r := chi.NewRouter()
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionMemberChangeRole)).Put("/organizations/{id}/members/{userID}/role", h.UpdateMemberRole)
+
+	orgID := uuid.New()
+	ownerID := uuid.New()
+	memberID := uuid.New()
+
+	mockOrgRepo.On("ListByUser", mock.Anything, ownerID).Return([]domain.UserMembership{
+		{Organization: domain.Organization{ID: orgID}, Role: "owner"},
+	}, nil)
+	mockOrgRepo.On("ListMembers", mock.Anything, orgID).Return([]domain.Member{
+		{UserID: ownerID, Role: "owner"},
+		{UserID: memberID, Role: "member"},
+	}, nil)
+	mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, memberID, domain.OrgRoleAdmin).Return(nil)
+	mockAudit.On("Record", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+	body, _ := json.Marshal(map[string]string{"role": "admin"})
+	req := httptest.NewRequest("PUT", "/organizations/"+orgID.String()+"/members/"+memberID.String()+"/role", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &domain.User{ID: ownerID}))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	// The change and its audit entry share a transaction, so the request fails.
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestUpdateMemberRole_NotMember(t *testing.T) {
+	mockOrgRepo := new(MockOrgRepo)
+	mockAudit := new(MockAuditService)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, new(MockUserRepo), mockAudit, nil, authz, passthroughTx{}, nil)
+
+	r := chi.NewRouter()
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionMemberChangeRole)).Put("/organizations/{id}/members/{userID}/role", h.UpdateMemberRole)
+
+	orgID := uuid.New()
+	ownerID := uuid.New()
+	strangerID := uuid.New()
+
+	mockOrgRepo.On("ListByUser", mock.Anything, ownerID).Return([]domain.UserMembership{
+		{Organization: domain.Organization{ID: orgID}, Role: "owner"},
+	}, nil)
+	mockOrgRepo.On("ListMembers", mock.Anything, orgID).Return([]domain.Member{
+		{UserID: ownerID, Role: "owner"},
+	}, nil)
+
+	body, _ := json.Marshal(map[string]string{"role": "admin"})
+	req := httptest.NewRequest("PUT", "/organizations/"+orgID.String()+"/members/"+strangerID.String()+"/role", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &domain.User{ID: ownerID}))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockOrgRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestListAuditLog(t *testing.T) {
+	orgID := uuid.New()
+	adminID := uuid.New()
+	memberID := uuid.New()
+
+	setup := func() (*chi.Mux, *MockAuditService) {
+		mockOrgRepo := new(MockOrgRepo)
+		mockAudit := new(MockAuditService)
+		authz := service.NewAuthorizer(mockOrgRepo)
+		h := handler.NewOrgHandler(mockOrgRepo, new(MockUserRepo), mockAudit, nil, authz, passthroughTx{}, nil)
+
+		mockOrgRepo.On("ListByUser", mock.Anything, adminID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: orgID}, Role: "admin"},
+		}, nil)
+		mockOrgRepo.On("ListByUser", mock.Anything, memberID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: orgID}, Role: "member"},
+		}, nil)
+
+		r := chi.NewRouter()
+		r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Get("/organizations/{id}/audit", h.ListAuditLog)
+		r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Get("/organizations/{id}/audit/export", h.ExportAuditLog)
+		return r, mockAudit
+	}
+
+	do := func(r *chi.Mux, userID uuid.UUID, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &domain.User{ID: userID}))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	base := "/organizations/" + orgID.String() + "/audit"
+
+	t.Run("Forbidden - Member", func(t *testing.T) {
+		r, _ := setup()
+		w := do(r, memberID, base)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Success - Filters", func(t *testing.T) {
+		r, mockAudit := setup()
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockAudit.On("List", mock.Anything, orgID, mock.MatchedBy(func(q port.AuditQuery) bool {
+			return q.Action == domain.AuditActionMemberAdded &&
+				q.ActorID != nil && *q.ActorID == adminID &&
+				q.From != nil && q.From.Equal(from) &&
+				q.Limit == 10 &&
+				q.Cursor == "abc"
+		})).Return(&domain.AuditPage{
+			Entries:    []domain.AuditEntry{{ID: uuid.New(), Action: domain.AuditActionMemberAdded}},
+			NextCursor: "next",
+		}, nil)
+
+		w := do(r, adminID, base+"?action=member.added&actor_id="+adminID.String()+"&from=2025-01-01T00:00:00Z&limit=10&cursor=abc")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page domain.AuditPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		assert.Len(t, page.Entries, 1)
+		assert.Equal(t, "next", page.NextCursor)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		r, _ := setup()
+		w := do(r, adminID, base+"?actor_id=nope")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		r, mockAudit := setup()
+		mockAudit.On("List", mock.Anything, orgID, mock.Anything).Return(nil, port.ErrInvalidCursor)
+		w := do(r, adminID, base+"?cursor=bad")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Export CSV follows every page", func(t *testing.T) {
+		r, mockAudit := setup()
+		name := "=Admin"
+		mockAudit.On("List", mock.Anything, orgID, mock.MatchedBy(func(q port.AuditQuery) bool {
+			return q.Cursor == ""
+		})).Return(&domain.AuditPage{
+			Entries: []domain.AuditEntry{{
+				ID:        uuid.New(),
+				ActorID:   &adminID,
+				ActorName: &name,
+				Action:    domain.AuditActionShareLinkUpdated,
+				Before:    []byte(`{"enabled":false}`),
+				After:     []byte(`{"enabled":true}`),
+			}},
+			NextCursor: "page2",
+		}, nil).Once()
+		mockAudit.On("List", mock.Anything, orgID, mock.MatchedBy(func(q port.AuditQuery) bool {
+			return q.Cursor == "page2"
+		})).Return(&domain.AuditPage{
+			Entries: []domain.AuditEntry{{ID: uuid.New(), Action: domain.AuditActionPublicViewTokenRegenerated}},
+		}, nil).Once()
+
+		w := do(r, adminID, base+"/export")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "'=Admin", records[1][3])
+		assert.Equal(t, domain.AuditActionShareLinkUpdated, records[1][4])
+		assert.Equal(t, `{"enabled":true}`, records[1][8])
+		assert.Equal(t, domain.AuditActionPublicViewTokenRegenerated, records[2][4])
+		mockAudit.AssertExpectations(t)
+	})
+
+	t.Run("Export JSON", func(t *testing.T) {
+		r, mockAudit := setup()
+		mockAudit.On("List", mock.Anything, orgID, mock.Anything).Return(&domain.AuditPage{
+			Entries: []domain.AuditEntry{{ID: uuid.New(), Action: domain.AuditActionMemberRemoved}},
+		}, nil)
+
+		w := do(r, adminID, base+"/export?format=json")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var entries []domain.AuditEntry
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&entries))
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Export Invalid Format", func(t *testing.T) {
+		r, _ := setup()
+		w := do(r, adminID, base+"/export?format=xml")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -17,23 +17,35 @@ import (
 	"github.com/wsciaroni/opsdeck/internal/core/port"
 )
 
+// OrgHandler serves an organization's members and settings. Routes under
+// /organizations/{id} are guarded by middleware.RequireOrgPermission, except
+// RemoveMember, which checks permission itself.
 type OrgHandler struct {
-	orgRepo  port.OrganizationRepository
-	userRepo port.UserRepository
-	logger   *slog.Logger
+	orgRepo      port.OrganizationRepository
+	userRepo     port.UserRepository
+	auditService port.AuditService
+	taskService  port.ScheduledTaskService
+	authz        port.Authorizer
+	tx           port.TxManager
+	logger       *slog.Logger
 }
 
-func NewOrgHandler(orgRepo port.OrganizationRepository, userRepo port.UserRepository, logger *slog.Logger) *OrgHandler {
+func NewOrgHandler(orgRepo port.OrganizationRepository, userRepo port.UserRepository, auditService port.AuditService, taskService port.ScheduledTaskService, authz port.Authorizer, tx port.TxManager, logger *slog.Logger) *OrgHandler {
 	return &OrgHandler{
-		orgRepo:  orgRepo,
-		userRepo: userRepo,
-		logger:   logger,
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		taskService:  taskService,
+		authz:        authz,
+		tx:           tx,
+		logger:       logger,
 	}
 }
 
 type CreateOrgRequest struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Timezone string `json:"timezone"`
 }
 
 func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
@@ -48,6 +60,13 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if req.Timezone == "" {
+		req.Timezone = domain.DefaultTimezone
+	} else if err := domain.ValidateTimezone(req.Timezone); err != nil {
+		http.Error(w, "Invalid timezone", http.StatusBadRequest)
+		return
+	}
+
 	// Generate Slug if not provided
 	if req.Slug == "" {
 		req.Slug = generateSlug(req.Name)
@@ -60,8 +79,9 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 	}
 
 	org := &domain.Organization{
-		Name: req.Name,
-		Slug: req.Slug,
+		Name:     req.Name,
+		Slug:     req.Slug,
+		Timezone: req.Timezone,
 	}
 
 	if err := h.orgRepo.Create(r.Context(), org); err != nil {
@@ -71,7 +91,7 @@ func (h *OrgHandler) CreateOrganization(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Add Creator as Owner
-	if err := h.orgRepo.AddMember(r.Context(), org.ID, user.ID, "owner"); err != nil {
+	if err := h.orgRepo.AddMember(r.Context(), org.ID, user.ID, domain.OrgRoleOwner); err != nil {
 		h.logger.Error("failed to add owner to organization", "error", err)
 		// Note: Ideally this should be transactional or we should roll back the org creation.
 		// For MVP, we'll accept the risk of orphan orgs or handle it manually.
@@ -117,27 +137,6 @@ func (h *OrgHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check Permissions (Must be owner or admin of the org)
-	memberships, err := h.orgRepo.ListByUser(r.Context(), currentUser.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isAuthorized := false
-	for _, m := range memberships {
-		if m.Organization.ID == orgID && (m.Role == "owner" || m.Role == "admin") {
-			isAuthorized = true
-			break
-		}
-	}
-
-	if !isAuthorized {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	// Find User to Add
 	userToAdd, err := h.userRepo.GetByEmail(r.Context(), req.Email)
 	if err != nil {
@@ -157,7 +156,14 @@ func (h *OrgHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 	// Let's assume we can try to add.
 
 	// Add Member (default role 'member')
-	if err := h.orgRepo.AddMember(r.Context(), orgID, userToAdd.ID, "member"); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.AddMember(ctx, orgID, userToAdd.ID, domain.OrgRoleMember); err != nil {
+			return err
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionMemberAdded, domain.AuditTargetUser, userToAdd.ID,
+			nil, map[string]string{"email": userToAdd.Email, "role": string(domain.OrgRoleMember)})
+	})
+	if err != nil {
 		// Check for duplicate key error if possible, but for MVP generic error log is fine
 		h.logger.Error("failed to add member", "error", err)
 		http.Error(w, "Failed to add member", http.StatusInternalServerError)
@@ -176,34 +182,6 @@ func (h *OrgHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check Auth
-	currentUser := middleware.GetUser(r.Context())
-	if currentUser == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	// Check Membership (Any role can view)
-	memberships, err := h.orgRepo.ListByUser(r.Context(), currentUser.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.Organization.ID == orgID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	members, err := h.orgRepo.ListMembers(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to list organization members", "error", err)
@@ -241,47 +219,41 @@ func (h *OrgHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check Permissions
-	// 1. Owner can remove anyone.
-	// 2. User can remove themselves (leave org).
-	memberships, err := h.orgRepo.ListByUser(r.Context(), currentUser.ID)
+	// Any member may leave; removing someone else is a separate permission.
+	action := domain.ActionMemberRemove
+	if currentUser.ID == userID {
+		action = domain.ActionOrgLeave
+	}
+	if !middleware.Authorize(w, r, h.authz, h.logger, action, domain.OrgResource(orgID)) {
+		return
+	}
+
+	// Capture the membership being removed for the audit log.
+	members, err := h.orgRepo.ListMembers(r.Context(), orgID)
 	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
+		h.logger.Error("failed to list organization members", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-
-	var currentMemberRole string
-	isMember := false
-	for _, m := range memberships {
-		if m.Organization.ID == orgID {
-			isMember = true
-			currentMemberRole = m.Role
+	var removed *domain.Member
+	for i := range members {
+		if members[i].UserID == userID {
+			removed = &members[i]
 			break
 		}
 	}
 
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
-	canRemove := false
-	if currentUser.ID == userID {
-		canRemove = true // Removing self
-	} else if currentMemberRole == "owner" {
-		canRemove = true // Owner removing others
-	}
-	// Admin removing members? Not specified in MVP requirements, but usually admins can remove members.
-	// Prompt says: "Check if requester is 'owner' (or removing themselves)."
-	// I will stick to "owner" or "self".
-
-	if !canRemove {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
-	if err := h.orgRepo.RemoveMember(r.Context(), orgID, userID); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.RemoveMember(ctx, orgID, userID); err != nil {
+			return err
+		}
+		if removed == nil {
+			return nil
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionMemberRemoved, domain.AuditTargetUser, userID,
+			map[string]string{"email": removed.Email, "role": string(removed.Role)}, nil)
+	})
+	if err != nil {
 		h.logger.Error("failed to remove member", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -314,17 +286,6 @@ func (h *OrgHandler) GetShareSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	currentUser := middleware.GetUser(r.Context())
-	if currentUser == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	if !h.isMember(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -347,7 +308,7 @@ func (h *OrgHandler) GetShareSettings(w http.ResponseWriter, r *http.Request) {
 }
 
 type UpdateMemberRoleRequest struct {
-	Role string `json:"role"`
+	Role domain.OrgRole `json:"role"`
 }
 
 func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
@@ -374,7 +335,7 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Role != "admin" && req.Role != "member" && req.Role != "owner" {
+	if !req.Role.IsValid() {
 		http.Error(w, "Invalid role", http.StatusBadRequest)
 		return
 	}
@@ -386,12 +347,6 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check Permissions (Must be owner or admin of the org)
-	if !h.isAdminOrOwner(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	// Cannot change own role?
 	// Usually owners can't demote themselves if they are the only owner.
 	// For MVP, we'll allow it but maybe warn or simple implementation:
@@ -406,9 +361,9 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ownerCount := 0
-	currentRole := ""
+	var currentRole domain.OrgRole
 	for _, m := range members {
-		if m.Role == "owner" {
+		if m.Role == domain.OrgRoleOwner {
 			ownerCount++
 		}
 		if m.UserID == userID {
@@ -416,14 +371,30 @@ func (h *OrgHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if currentRole == "owner" && req.Role != "owner" {
+	if currentRole == "" {
+		http.Error(w, "User is not a member of this organization", http.StatusNotFound)
+		return
+	}
+	if currentRole == req.Role {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if currentRole == domain.OrgRoleOwner && req.Role != domain.OrgRoleOwner {
 		if ownerCount <= 1 {
 			http.Error(w, "Cannot demote the last owner", http.StatusBadRequest)
 			return
 		}
 	}
 
-	if err := h.orgRepo.UpdateMemberRole(r.Context(), orgID, userID, req.Role); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.UpdateMemberRole(ctx, orgID, userID, req.Role); err != nil {
+			return err
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionMemberRoleChanged, domain.AuditTargetUser, userID,
+			map[string]domain.OrgRole{"role": currentRole}, map[string]domain.OrgRole{"role": req.Role})
+	})
+	if err != nil {
 		h.logger.Error("failed to update member role", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -456,12 +427,6 @@ func (h *OrgHandler) UpdateShareSettings(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Only owner/admin can update settings
-	if !h.isAdminOrOwner(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -469,13 +434,24 @@ func (h *OrgHandler) UpdateShareSettings(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	wasEnabled := org.ShareLinkEnabled
 	org.ShareLinkEnabled = req.Enabled
 	if req.Enabled && org.ShareLinkToken == nil {
 		token := generateToken()
 		org.ShareLinkToken = &token
 	}
 
-	if err := h.orgRepo.Update(r.Context(), org); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.Update(ctx, org); err != nil {
+			return err
+		}
+		if wasEnabled == org.ShareLinkEnabled {
+			return nil
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionShareLinkUpdated, domain.AuditTargetOrganization, orgID,
+			map[string]bool{"enabled": wasEnabled}, map[string]bool{"enabled": org.ShareLinkEnabled})
+	})
+	if err != nil {
 		h.logger.Error("failed to update organization", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -509,12 +485,6 @@ func (h *OrgHandler) RegenerateShareToken(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Only owner/admin can update settings
-	if !h.isAdminOrOwner(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -525,7 +495,14 @@ func (h *OrgHandler) RegenerateShareToken(w http.ResponseWriter, r *http.Request
 	token := generateToken()
 	org.ShareLinkToken = &token
 
-	if err := h.orgRepo.Update(r.Context(), org); err != nil {
+	// The token itself is a credential and is never written to the log.
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.Update(ctx, org); err != nil {
+			return err
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionShareLinkTokenRegenerated, domain.AuditTargetOrganization, orgID, nil, nil)
+	})
+	if err != nil {
 		h.logger.Error("failed to update organization", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -545,30 +522,63 @@ func (h *OrgHandler) RegenerateShareToken(w http.ResponseWriter, r *http.Request
 	}
 }
 
-func (h *OrgHandler) isMember(ctx context.Context, orgID, userID uuid.UUID) bool {
-	memberships, err := h.orgRepo.ListByUser(ctx, userID)
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+// UpdateTimezone sets the IANA timezone scheduled tasks are evaluated in.
+func (h *OrgHandler) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	orgIDStr := chi.URLParam(r, "id")
+	orgID, err := uuid.Parse(orgIDStr)
 	if err != nil {
-		return false
+		http.Error(w, "Invalid Organization ID", http.StatusBadRequest)
+		return
 	}
-	for _, m := range memberships {
-		if m.Organization.ID == orgID {
-			return true
-		}
+
+	var req UpdateTimezoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := domain.ValidateTimezone(req.Timezone); err != nil {
+		http.Error(w, "Invalid timezone", http.StatusBadRequest)
+		return
+	}
+
+	currentUser := middleware.GetUser(r.Context())
+	if currentUser == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
 	}
-	return false
-}
 
-func (h *OrgHandler) isAdminOrOwner(ctx context.Context, orgID, userID uuid.UUID) bool {
-	memberships, err := h.orgRepo.ListByUser(ctx, userID)
+	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
-		return false
+		h.logger.Error("failed to get organization", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
 	}
-	for _, m := range memberships {
-		if m.Organization.ID == orgID && (m.Role == "owner" || m.Role == "admin") {
-			return true
+
+	oldTimezone := org.Timezone
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.taskService.UpdateOrganizationTimezone(ctx, org, req.Timezone); err != nil {
+			return err
 		}
+		if oldTimezone == org.Timezone {
+			return nil
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionTimezoneUpdated, domain.AuditTargetOrganization, orgID,
+			map[string]string{"timezone": oldTimezone}, map[string]string{"timezone": org.Timezone})
+	})
+	if err != nil {
+		h.logger.Error("failed to update organization", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(org); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
 	}
-	return false
 }
 
 func generateToken() string {
@@ -587,17 +597,6 @@ func (h *OrgHandler) GetPublicViewSettings(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	currentUser := middleware.GetUser(r.Context())
-	if currentUser == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	if !h.isMember(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -643,12 +642,6 @@ func (h *OrgHandler) UpdatePublicViewSettings(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Only owner/admin can update settings
-	if !h.isAdminOrOwner(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -656,13 +649,24 @@ func (h *OrgHandler) UpdatePublicViewSettings(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	wasEnabled := org.PublicViewEnabled
 	org.PublicViewEnabled = req.Enabled
 	if req.Enabled && org.PublicViewToken == nil {
 		token := generateToken()
 		org.PublicViewToken = &token
 	}
 
-	if err := h.orgRepo.Update(r.Context(), org); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.Update(ctx, org); err != nil {
+			return err
+		}
+		if wasEnabled == org.PublicViewEnabled {
+			return nil
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionPublicViewUpdated, domain.AuditTargetOrganization, orgID,
+			map[string]bool{"enabled": wasEnabled}, map[string]bool{"enabled": org.PublicViewEnabled})
+	})
+	if err != nil {
 		h.logger.Error("failed to update organization", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -696,12 +700,6 @@ func (h *OrgHandler) RegeneratePublicViewToken(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Only owner/admin can update settings
-	if !h.isAdminOrOwner(r.Context(), orgID, currentUser.ID) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
 	org, err := h.orgRepo.GetByID(r.Context(), orgID)
 	if err != nil {
 		h.logger.Error("failed to get organization", "error", err)
@@ -712,7 +710,13 @@ func (h *OrgHandler) RegeneratePublicViewToken(w http.ResponseWriter, r *http.Re
 	token := generateToken()
 	org.PublicViewToken = &token
 
-	if err := h.orgRepo.Update(r.Context(), org); err != nil {
+	err = h.tx.WithinTx(r.Context(), func(ctx context.Context) error {
+		if err := h.orgRepo.Update(ctx, org); err != nil {
+			return err
+		}
+		return h.recordAudit(ctx, r, orgID, currentUser.ID, domain.AuditActionPublicViewTokenRegenerated, domain.AuditTargetOrganization, orgID, nil, nil)
+	})
+	if err != nil {
 		h.logger.Error("failed to update organization", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -15,15 +15,17 @@ import (
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
 )
 
 func TestGetShareSettings(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Get("/organizations/{id}/share", h.GetShareSettings)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgView)).Get("/organizations/{id}/share", h.GetShareSettings)
 
 	t.Run("Success", func(t *testing.T) {
 		orgID := uuid.New()
@@ -65,10 +67,11 @@ func TestGetShareSettings(t *testing.T) {
 func TestUpdateShareSettings(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Put("/organizations/{id}/share", h.UpdateShareSettings)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Put("/organizations/{id}/share", h.UpdateShareSettings)
 
 	t.Run("Success - Enable Share Link", func(t *testing.T) {
 		orgID := uuid.New()
@@ -102,13 +105,69 @@ func TestUpdateShareSettings(t *testing.T) {
 	})
 }
 
+func TestUpdateTimezone(t *testing.T) {
+	mockOrgRepo := new(MockOrgRepo)
+	mockUserRepo := new(MockUserRepo)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	tasks := &fakeScheduledTaskService{}
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), tasks, authz, passthroughTx{}, nil)
+
+	r := chi.NewRouter()
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Put("/organizations/{id}/timezone", h.UpdateTimezone)
+
+	t.Run("Success", func(t *testing.T) {
+		orgID := uuid.New()
+		userID := uuid.New()
+		user := &domain.User{ID: userID}
+
+		mockOrgRepo.On("ListByUser", mock.Anything, userID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: orgID}, Role: "owner"},
+		}, nil)
+		mockOrgRepo.On("GetByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+
+		bodyBytes, _ := json.Marshal(map[string]string{"timezone": "America/Chicago"})
+		req := httptest.NewRequest("PUT", "/organizations/"+orgID.String()+"/timezone", bytes.NewReader(bodyBytes))
+		ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+		req = req.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp domain.Organization
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "America/Chicago", resp.Timezone)
+		assert.Equal(t, "America/Chicago", tasks.timezone)
+	})
+
+	t.Run("Fail - Invalid Timezone", func(t *testing.T) {
+		orgID := uuid.New()
+		user := &domain.User{ID: uuid.New()}
+
+		mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleOwner},
+		}, nil)
+
+		bodyBytes, _ := json.Marshal(map[string]string{"timezone": "Mars/Olympus"})
+		req := httptest.NewRequest("PUT", "/organizations/"+orgID.String()+"/timezone", bytes.NewReader(bodyBytes))
+		ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+		req = req.WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestGetPublicViewSettings(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Get("/organizations/{id}/public-view", h.GetPublicViewSettings)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgView)).Get("/organizations/{id}/public-view", h.GetPublicViewSettings)
 
 	t.Run("Success", func(t *testing.T) {
 		orgID := uuid.New()
@@ -150,10 +209,11 @@ func TestGetPublicViewSettings(t *testing.T) {
 func TestUpdatePublicViewSettings(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Put("/organizations/{id}/public-view", h.UpdatePublicViewSettings)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Put("/organizations/{id}/public-view", h.UpdatePublicViewSettings)
 
 	t.Run("Success - Enable Public View", func(t *testing.T) {
 		orgID := uuid.New()
@@ -190,10 +250,11 @@ func TestUpdatePublicViewSettings(t *testing.T) {
 func TestRegeneratePublicViewToken(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Post("/organizations/{id}/public-view/regenerate", h.RegeneratePublicViewToken)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Post("/organizations/{id}/public-view/regenerate", h.RegeneratePublicViewToken)
 
 	t.Run("Success", func(t *testing.T) {
 		orgID := uuid.New()
@@ -229,10 +290,11 @@ func TestRegeneratePublicViewToken(t *testing.T) {
 func TestRegenerateShareToken(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Post("/organizations/{id}/share/regenerate", h.RegenerateShareToken)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionOrgManage)).Post("/organizations/{id}/share/regenerate", h.RegenerateShareToken)
 
 	t.Run("Success", func(t *testing.T) {
 		orgID := uuid.New()
@@ -268,10 +330,11 @@ func TestRegenerateShareToken(t *testing.T) {
 func TestUpdateMemberRole(t *testing.T) {
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, nil)
+	authz := service.NewAuthorizer(mockOrgRepo)
+	h := handler.NewOrgHandler(mockOrgRepo, mockUserRepo, newMockAuditService(), nil, authz, passthroughTx{}, nil)
 
 	r := chi.NewRouter()
-	r.Put("/organizations/{id}/members/{userID}/role", h.UpdateMemberRole)
+	r.With(middleware.RequireOrgPermission(authz, nil, domain.ActionMemberChangeRole)).Put("/organizations/{id}/members/{userID}/role", h.UpdateMemberRole)
 
 	t.Run("Fail - Demote Last Owner", func(t *testing.T) {
 		orgID := uuid.New()
@@ -318,7 +381,7 @@ func TestUpdateMemberRole(t *testing.T) {
 		}, nil)
 
 		// Expect update
-		mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, userID, "admin").Return(nil)
+		mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, userID, domain.OrgRoleAdmin).Return(nil)
 
 		body := map[string]string{"role": "admin"}
 		bodyBytes, _ := json.Marshal(body)
@@ -349,7 +412,7 @@ func TestUpdateMemberRole(t *testing.T) {
 		}, nil)
 
 		// Expect update
-		mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, memberID, "admin").Return(nil)
+		mockOrgRepo.On("UpdateMemberRole", mock.Anything, orgID, memberID, domain.OrgRoleAdmin).Return(nil)
 
 		body := map[string]string{"role": "admin"}
 		bodyBytes, _ := json.Marshal(body)
@@ -363,3 +426,57 @@ func TestUpdateMemberRole(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 }
+
+func TestRemoveMember_Authorization(t *testing.T) {
+	orgID := uuid.New()
+
+	tests := []struct {
+		name     string
+		role     domain.OrgRole
+		isMember bool
+		self     bool
+		want     int
+	}{
+		{name: "Owner removes a member", role: domain.OrgRoleOwner, isMember: true, want: http.StatusNoContent},
+		{name: "Admin cannot remove a member", role: domain.OrgRoleAdmin, isMember: true, want: http.StatusForbidden},
+		{name: "Member cannot remove a member", role: domain.OrgRoleMember, isMember: true, want: http.StatusForbidden},
+		{name: "Admin leaves", role: domain.OrgRoleAdmin, isMember: true, self: true, want: http.StatusNoContent},
+		{name: "Member leaves", role: domain.OrgRoleMember, isMember: true, self: true, want: http.StatusNoContent},
+		{name: "Non-member", isMember: false, want: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrgRepo := new(MockOrgRepo)
+			h := handler.NewOrgHandler(mockOrgRepo, new(MockUserRepo), newMockAuditService(), nil, service.NewAuthorizer(mockOrgRepo), passthroughTx{}, nil)
+			r := chi.NewRouter()
+			r.Delete("/organizations/{id}/members/{userID}", h.RemoveMember)
+
+			user := &domain.User{ID: uuid.New()}
+			targetID := uuid.New()
+			if tc.self {
+				targetID = user.ID
+			}
+
+			var memberships []domain.UserMembership
+			if tc.isMember {
+				memberships = append(memberships, domain.UserMembership{Organization: domain.Organization{ID: orgID}, Role: tc.role})
+			}
+			mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return(memberships, nil)
+			mockOrgRepo.On("ListMembers", mock.Anything, orgID).Return([]domain.Member{{UserID: targetID, Role: domain.OrgRoleMember}}, nil).Maybe()
+			mockOrgRepo.On("RemoveMember", mock.Anything, orgID, targetID).Return(nil).Maybe()
+
+			req := httptest.NewRequest("DELETE", "/organizations/"+orgID.String()+"/members/"+targetID.String(), nil)
+			ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code)
+			if tc.want != http.StatusNoContent {
+				mockOrgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// parseOrgID parses the {id} of a route under /organizations/{id}. Access to
+// those routes is checked by middleware.RequireOrgPermission. It writes the
+// error response itself and reports whether the request may proceed.
+func parseOrgID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid Organization ID", http.StatusBadRequest)
+		return orgID, false
+	}
+	return orgID, true
+}
+
+// listMemberships returns the user's organizations. A request made with an
+// organization's service key only sees that organization.
+func listMemberships(ctx context.Context, orgRepo port.OrganizationRepository, userID uuid.UUID) ([]domain.UserMembership, error) {
+	memberships, err := orgRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	key := middleware.GetAPIKey(ctx)
+	if key == nil || key.OrganizationID == nil {
+		return memberships, nil
+	}
+	for _, m := range memberships {
+		if m.ID == *key.OrganizationID {
+			return []domain.UserMembership{m}, nil
+		}
+	}
+	return []domain.UserMembership{}, nil
+}
@@ -16,6 +16,8 @@ type PublicViewHandler struct {
 	orgRepo        port.OrganizationRepository
 	ticketService  *service.TicketService
 	commentService port.CommentService
+	statusService  port.TicketStatusService
+	priorities     port.TicketPriorityService
 	userRepo       port.UserRepository
 	logger         *slog.Logger
 }
@@ -24,6 +26,8 @@ func NewPublicViewHandler(
 	orgRepo port.OrganizationRepository,
 	ticketService *service.TicketService,
 	commentService port.CommentService,
+	statusService port.TicketStatusService,
+	priorities port.TicketPriorityService,
 	userRepo port.UserRepository,
 	logger *slog.Logger,
 ) *PublicViewHandler {
@@ -31,6 +35,8 @@ func NewPublicViewHandler(
 		orgRepo:        orgRepo,
 		ticketService:  ticketService,
 		commentService: commentService,
+		statusService:  statusService,
+		priorities:     priorities,
 		userRepo:       userRepo,
 		logger:         logger,
 	}
@@ -89,10 +95,17 @@ func (h *PublicViewHandler) ListTickets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	state, err := parseStatusState(r, "")
+	if err != nil {
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	}
+
 	sensitive := false
 	filter := port.TicketFilter{
 		OrganizationID: &org.ID,
 		Sensitive:      &sensitive,
+		StatusState:    state,
 	}
 
 	tickets, err := h.ticketService.ListTickets(r.Context(), filter)
@@ -108,6 +121,7 @@ func (h *PublicViewHandler) ListTickets(w http.ResponseWriter, r *http.Request)
 		ticketCopy := t
 		ticketCopy.ReporterID = uuid.Nil
 		ticketCopy.AssigneeUserID = nil
+		ticketCopy.ScheduledTaskID = nil
 		respList = append(respList, ticketCopy)
 	}
 
@@ -117,6 +131,72 @@ func (h *PublicViewHandler) ListTickets(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// ListStatuses returns the organization's ticket statuses so the public view
+// can show their labels, colors and states.
+func (h *PublicViewHandler) ListStatuses(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		http.Error(w, "Token is required", http.StatusBadRequest)
+		return
+	}
+
+	org, err := h.orgRepo.GetByPublicViewToken(r.Context(), token)
+	if err != nil {
+		h.logger.Error("Failed to get organization by public view token", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if org == nil || !org.PublicViewEnabled {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	statuses, err := h.statusService.ListStatuses(r.Context(), org.ID)
+	if err != nil {
+		h.logger.Error("Failed to list ticket statuses", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+}
+
+// ListPriorities returns the organization's ticket priorities so the public
+// view can show their labels and colors.
+func (h *PublicViewHandler) ListPriorities(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		http.Error(w, "Token is required", http.StatusBadRequest)
+		return
+	}
+
+	org, err := h.orgRepo.GetByPublicViewToken(r.Context(), token)
+	if err != nil {
+		h.logger.Error("Failed to get organization by public view token", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if org == nil || !org.PublicViewEnabled {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	priorities, err := h.priorities.ListPriorities(r.Context(), org.ID)
+	if err != nil {
+		h.logger.Error("Failed to list ticket priorities", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(priorities); err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+}
+
 func (h *PublicViewHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
 	ticketIDStr := chi.URLParam(r, "ticketID")
@@ -158,6 +238,7 @@ func (h *PublicViewHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
 	ticketCopy := *ticket
 	ticketCopy.ReporterID = uuid.Nil
 	ticketCopy.AssigneeUserID = nil
+	ticketCopy.ScheduledTaskID = nil
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(ticketCopy); err != nil {
@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
+)
+
+// fakeTicketRepository serves a fixed set of tickets.
+type fakeTicketRepository struct {
+	port.TicketRepository
+	tickets []domain.Ticket
+}
+
+func (r *fakeTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	for i := range r.tickets {
+		if r.tickets[i].ID == id {
+			return &r.tickets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeTicketRepository) List(ctx context.Context, filter port.TicketFilter) ([]domain.Ticket, error) {
+	return r.tickets, nil
+}
+
+func TestPublicView_HidesInternalIDs(t *testing.T) {
+	orgID := uuid.New()
+	assigneeID := uuid.New()
+	taskID := uuid.New()
+	ticket := domain.Ticket{
+		ID:              uuid.New(),
+		OrganizationID:  orgID,
+		Title:           "Replace filters",
+		ReporterID:      uuid.New(),
+		AssigneeUserID:  &assigneeID,
+		ScheduledTaskID: &taskID,
+	}
+
+	mockOrgRepo := new(MockOrgRepo)
+	mockOrgRepo.On("GetByPublicViewToken", mock.Anything, "token").Return(&domain.Organization{ID: orgID, PublicViewEnabled: true}, nil)
+	tickets := service.NewTicketService(&fakeTicketRepository{tickets: []domain.Ticket{ticket}}, nil, nil, nil, nil, nil)
+	h := handler.NewPublicViewHandler(mockOrgRepo, tickets, nil, nil, nil, nil, nil)
+
+	r := chi.NewRouter()
+	r.Get("/public/view/{token}/tickets", h.ListTickets)
+	r.Get("/public/view/{token}/tickets/{ticketID}", h.GetTicket)
+
+	// assertHidden checks a ticket as encoded in the response.
+	assertHidden := func(t *testing.T, fields map[string]any) {
+		t.Helper()
+		assert.Equal(t, uuid.Nil.String(), fields["reporter_id"])
+		assert.Nil(t, fields["assignee_user_id"])
+		assert.Nil(t, fields["scheduled_task_id"])
+	}
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/view/token/tickets", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp, 1)
+		assertHidden(t, resp[0])
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/view/token/tickets/"+ticket.ID.String(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assertHidden(t, resp)
+	})
+}
@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -17,14 +17,14 @@ import (
 
 type ScheduledTaskHandler struct {
 	service port.ScheduledTaskService
-	orgRepo port.OrganizationRepository
+	authz   port.Authorizer
 	logger  *slog.Logger
 }
 
-func NewScheduledTaskHandler(service port.ScheduledTaskService, orgRepo port.OrganizationRepository, logger *slog.Logger) *ScheduledTaskHandler {
+func NewScheduledTaskHandler(service port.ScheduledTaskService, authz port.Authorizer, logger *slog.Logger) *ScheduledTaskHandler {
 	return &ScheduledTaskHandler{
 		service: service,
-		orgRepo: orgRepo,
+		authz:   authz,
 		logger:  logger,
 	}
 }
@@ -32,9 +32,11 @@ func NewScheduledTaskHandler(service port.ScheduledTaskService, orgRepo port.Org
 type CreateScheduledTaskRequest struct {
 	Title          string           `json:"title"`
 	Description    string           `json:"description"`
+	RRule          string           `json:"rrule"`
 	Frequency      domain.Frequency `json:"frequency"`
 	StartDate      time.Time        `json:"start_date"`
 	PriorityID     string           `json:"priority_id"`
+	CategoryID     *string          `json:"category_id"`
 	OrganizationID uuid.UUID        `json:"organization_id"`
 	AssigneeUserID *uuid.UUID       `json:"assignee_user_id"`
 	Location       string           `json:"location"`
@@ -44,9 +46,11 @@ type CreateScheduledTaskRequest struct {
 type UpdateScheduledTaskRequest struct {
 	Title          *string           `json:"title"`
 	Description    *string           `json:"description"`
+	RRule          *string           `json:"rrule"`
 	Frequency      *domain.Frequency `json:"frequency"`
 	StartDate      *time.Time        `json:"start_date"`
 	PriorityID     *string           `json:"priority_id"`
+	CategoryID     *string           `json:"category_id"`
 	AssigneeUserID *uuid.UUID        `json:"assignee_user_id"`
 	Location       *string           `json:"location"`
 	Enabled        *bool             `json:"enabled"`
@@ -64,15 +68,7 @@ func (h *ScheduledTaskHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := middleware.GetUser(r.Context())
-	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	if err := h.verifyMembership(r.Context(), user.ID, orgID); err != nil {
-		h.logger.Error("failed to verify membership", "error", err)
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskView, domain.OrgResource(orgID)) {
 		return
 	}
 
@@ -95,6 +91,12 @@ func (h *ScheduledTaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if req.RRule != "" {
+		if _, err := domain.ParseRRule(req.RRule); err != nil {
+			http.Error(w, "Invalid rrule: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
 	user := middleware.GetUser(r.Context())
 	if user == nil {
@@ -102,8 +104,7 @@ func (h *ScheduledTaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.verifyMembership(r.Context(), user.ID, req.OrganizationID); err != nil {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskManage, domain.OrgResource(req.OrganizationID)) {
 		return
 	}
 
@@ -112,16 +113,21 @@ func (h *ScheduledTaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		CreatedBy:      user.ID,
 		Title:          req.Title,
 		Description:    req.Description,
+		RRule:          req.RRule,
 		Frequency:      req.Frequency,
 		StartDate:      req.StartDate,
 		AssigneeUserID: req.AssigneeUserID,
 		PriorityID:     req.PriorityID,
+		CategoryID:     req.CategoryID,
 		Location:       req.Location,
 		Enabled:        req.Enabled,
 	}
 
 	task, err := h.service.CreateTask(r.Context(), cmd)
 	if err != nil {
+		if writeLookupError(w, err) {
+			return
+		}
 		h.logger.Error("failed to create task", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -147,6 +153,12 @@ func (h *ScheduledTaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if req.RRule != nil {
+		if _, err := domain.ParseRRule(*req.RRule); err != nil {
+			http.Error(w, "Invalid rrule: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
 	task, err := h.service.GetTask(r.Context(), id)
 	if err != nil {
@@ -158,30 +170,28 @@ func (h *ScheduledTaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := middleware.GetUser(r.Context())
-	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	if err := h.verifyMembership(r.Context(), user.ID, task.OrganizationID); err != nil {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskManage, domain.OrgResource(task.OrganizationID)) {
 		return
 	}
 
 	cmd := port.UpdateScheduledTaskCmd{
 		Title:          req.Title,
 		Description:    req.Description,
+		RRule:          req.RRule,
 		Frequency:      req.Frequency,
 		StartDate:      req.StartDate,
 		AssigneeUserID: req.AssigneeUserID,
 		PriorityID:     req.PriorityID,
+		CategoryID:     req.CategoryID,
 		Location:       req.Location,
 		Enabled:        req.Enabled,
 	}
 
 	updated, err := h.service.UpdateTask(r.Context(), id, cmd)
 	if err != nil {
+		if writeLookupError(w, err) {
+			return
+		}
 		h.logger.Error("failed to update task", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -211,35 +221,205 @@ func (h *ScheduledTaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskManage, domain.OrgResource(task.OrganizationID)) {
+		return
+	}
+
+	if err := h.service.DeleteTask(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete task", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const (
+	defaultRunsLimit = 50
+	maxRunsLimit     = 200
+)
+
+func (h *ScheduledTaskHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultRunsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxRunsLimit {
+			limit = maxRunsLimit
+		}
+	}
+
+	task, err := h.service.GetTask(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if task == nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return
+	}
+
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskView, domain.OrgResource(task.OrganizationID)) {
+		return
+	}
+
+	runs, err := h.service.ListRuns(r.Context(), id, limit)
+	if err != nil {
+		h.logger.Error("failed to list task runs", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(runs); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+const (
+	defaultOccurrencesLimit = 10
+	maxOccurrencesLimit     = 100
+)
+
+// ListOccurrences previews when a saved task will fire.
+func (h *ScheduledTaskHandler) ListOccurrences(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	from, to, limit, err := parseOccurrenceRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.GetTask(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if task == nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return
+	}
+
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskView, domain.OrgResource(task.OrganizationID)) {
+		return
+	}
+
+	occurrences, err := h.service.ListOccurrences(r.Context(), id, from, to, limit)
+	if err != nil {
+		h.logger.Error("failed to list task occurrences", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(occurrences); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// PreviewOccurrences is the dry-run form of ListOccurrences. It takes the same
+// body as Create and saves nothing.
+func (h *ScheduledTaskHandler) PreviewOccurrences(w http.ResponseWriter, r *http.Request) {
+	from, to, limit, err := parseOccurrenceRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req CreateScheduledTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	rule := req.RRule
+	if rule == "" && req.Frequency != "" {
+		rule = req.Frequency.RRule()
+	}
+	if _, err := domain.ParseRRule(rule); err != nil {
+		http.Error(w, "Invalid rrule: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	user := middleware.GetUser(r.Context())
 	if user == nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	if err := h.verifyMembership(r.Context(), user.ID, task.OrganizationID); err != nil {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionScheduledTaskView, domain.OrgResource(req.OrganizationID)) {
 		return
 	}
 
-	if err := h.service.DeleteTask(r.Context(), id); err != nil {
-		h.logger.Error("failed to delete task", "error", err)
+	cmd := port.CreateScheduledTaskCmd{
+		OrganizationID: req.OrganizationID,
+		CreatedBy:      user.ID,
+		Title:          req.Title,
+		Description:    req.Description,
+		RRule:          req.RRule,
+		Frequency:      req.Frequency,
+		StartDate:      req.StartDate,
+		AssigneeUserID: req.AssigneeUserID,
+		PriorityID:     req.PriorityID,
+		CategoryID:     req.CategoryID,
+		Location:       req.Location,
+		Enabled:        req.Enabled,
+	}
+
+	occurrences, err := h.service.PreviewOccurrences(r.Context(), cmd, from, to, limit)
+	if err != nil {
+		h.logger.Error("failed to preview task occurrences", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(occurrences); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
 }
 
-func (h *ScheduledTaskHandler) verifyMembership(ctx context.Context, userID, orgID uuid.UUID) error {
-	memberships, err := h.orgRepo.ListByUser(ctx, userID)
-	if err != nil {
-		return err
+// parseOccurrenceRange reads the optional from, to (RFC 3339) and limit query
+// parameters. from defaults to now and to to no upper bound.
+func parseOccurrenceRange(r *http.Request) (from, to time.Time, limit int, err error) {
+	q := r.URL.Query()
+
+	from = time.Now()
+	if v := q.Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, 0, fmt.Errorf("invalid from")
+		}
 	}
-	for _, m := range memberships {
-		if m.ID == orgID {
-			return nil
+	if v := q.Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil || !to.After(from) {
+			return from, to, 0, fmt.Errorf("invalid to")
+		}
+	}
+
+	limit = defaultOccurrencesLimit
+	if v := q.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return from, to, 0, fmt.Errorf("invalid limit")
+		}
+		if limit > maxOccurrencesLimit {
+			limit = maxOccurrencesLimit
 		}
 	}
-	return fmt.Errorf("user not member of organization")
+	return from, to, limit, nil
 }
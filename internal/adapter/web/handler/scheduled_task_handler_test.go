@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
+)
+
+// fakeScheduledTaskService holds a single task.
+type fakeScheduledTaskService struct {
+	port.ScheduledTaskService
+	task     *domain.ScheduledTask
+	deleted  bool
+	timezone string
+}
+
+func (f *fakeScheduledTaskService) GetTask(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
+	if f.task == nil || f.task.ID != id {
+		return nil, nil
+	}
+	return f.task, nil
+}
+
+func (f *fakeScheduledTaskService) ListTasks(ctx context.Context, organizationID uuid.UUID) ([]domain.ScheduledTask, error) {
+	return []domain.ScheduledTask{*f.task}, nil
+}
+
+func (f *fakeScheduledTaskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	f.deleted = true
+	return nil
+}
+
+func (f *fakeScheduledTaskService) UpdateOrganizationTimezone(ctx context.Context, org *domain.Organization, timezone string) error {
+	org.Timezone = timezone
+	f.timezone = timezone
+	return nil
+}
+
+func TestScheduledTaskHandler_Authorization(t *testing.T) {
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	member := func(id uuid.UUID, role domain.OrgRole) []domain.UserMembership {
+		return []domain.UserMembership{{Organization: domain.Organization{ID: id}, Role: role}}
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		memberships []domain.UserMembership
+		key         *domain.APIKey
+		want        int
+	}{
+		{name: "Member lists tasks", method: "GET", path: "/scheduled-tasks?organization_id=" + orgID.String(), memberships: member(orgID, domain.OrgRoleMember), want: http.StatusOK},
+		{name: "Non-member cannot list tasks", method: "GET", path: "/scheduled-tasks?organization_id=" + orgID.String(), memberships: member(otherOrgID, domain.OrgRoleOwner), want: http.StatusForbidden},
+		{name: "Member deletes a task", method: "DELETE", path: "/scheduled-tasks/{task}", memberships: member(orgID, domain.OrgRoleMember), want: http.StatusNoContent},
+		{name: "Owner deletes a task", method: "DELETE", path: "/scheduled-tasks/{task}", memberships: member(orgID, domain.OrgRoleOwner), want: http.StatusNoContent},
+		{name: "Non-member cannot delete a task", method: "DELETE", path: "/scheduled-tasks/{task}", memberships: member(otherOrgID, domain.OrgRoleOwner), want: http.StatusForbidden},
+		{name: "Service key for another organization", method: "DELETE", path: "/scheduled-tasks/{task}", memberships: member(orgID, domain.OrgRoleOwner), key: &domain.APIKey{ID: uuid.New(), OrganizationID: &otherOrgID}, want: http.StatusForbidden},
+		{name: "Missing task", method: "DELETE", path: "/scheduled-tasks/" + uuid.NewString(), memberships: member(orgID, domain.OrgRoleOwner), want: http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &domain.ScheduledTask{ID: uuid.New(), OrganizationID: orgID}
+			tasks := &fakeScheduledTaskService{task: task}
+			mockOrgRepo := new(MockOrgRepo)
+			h := handler.NewScheduledTaskHandler(tasks, service.NewAuthorizer(mockOrgRepo), nil)
+			r := chi.NewRouter()
+			r.Get("/scheduled-tasks", h.List)
+			r.Delete("/scheduled-tasks/{id}", h.Delete)
+
+			user := &domain.User{ID: uuid.New()}
+			mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return(tc.memberships, nil)
+
+			path := tc.path
+			if path == "/scheduled-tasks/{task}" {
+				path = "/scheduled-tasks/" + task.ID.String()
+			}
+			req := httptest.NewRequest(tc.method, path, nil)
+			ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+			if tc.key != nil {
+				ctx = context.WithValue(ctx, middleware.APIKeyContextKey, tc.key)
+			}
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code)
+			assert.Equal(t, tc.want == http.StatusNoContent, tasks.deleted)
+		})
+	}
+}
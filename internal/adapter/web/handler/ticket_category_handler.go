@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TicketCategoryHandler struct {
+	service port.TicketCategoryService
+	orgRepo port.OrganizationRepository
+	logger  *slog.Logger
+}
+
+func NewTicketCategoryHandler(service port.TicketCategoryService, orgRepo port.OrganizationRepository, logger *slog.Logger) *TicketCategoryHandler {
+	return &TicketCategoryHandler{
+		service: service,
+		orgRepo: orgRepo,
+		logger:  logger,
+	}
+}
+
+type CreateTicketCategoryRequest struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+}
+
+type UpdateTicketCategoryRequest struct {
+	Label       *string `json:"label"`
+	Description *string `json:"description"`
+}
+
+// List returns the organization's ticket categories. Any member may read them.
+func (h *TicketCategoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	categories, err := h.service.ListCategories(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to list ticket categories", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(categories); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// ListShared returns the categories of the organization behind a share link so
+// the public submission form can offer them.
+func (h *TicketCategoryHandler) ListShared(w http.ResponseWriter, r *http.Request) {
+	org, err := h.orgRepo.GetByShareToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.logger.Error("failed to get organization by token", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if org == nil || !org.ShareLinkEnabled {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	categories, err := h.service.ListCategories(r.Context(), org.ID)
+	if err != nil {
+		h.logger.Error("failed to list ticket categories", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(categories); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketCategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateTicketCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.CreateCategory(r.Context(), port.CreateTicketCategoryCmd{
+		OrganizationID: orgID,
+		ID:             req.ID,
+		Label:          req.Label,
+		Description:    req.Description,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketCategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTicketCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.UpdateCategory(r.Context(), orgID, chi.URLParam(r, "categoryID"), port.UpdateTicketCategoryCmd{
+		Label:       req.Label,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(category); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketCategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), orgID, chi.URLParam(r, "categoryID")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TicketCategoryHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, port.ErrInvalidTicketCategory):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrTicketCategoryNotFound):
+		http.Error(w, "Category not found", http.StatusNotFound)
+	case errors.Is(err, port.ErrTicketCategoryInUse):
+		http.Error(w, "Category is in use by tickets or scheduled tasks", http.StatusConflict)
+	default:
+		h.logger.Error("failed to change ticket category", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -24,6 +25,7 @@ type TicketHandler struct {
 	service  port.TicketService
 	orgRepo  port.OrganizationRepository
 	userRepo port.UserRepository
+	authz    port.Authorizer
 	logger   *slog.Logger
 }
 
@@ -38,35 +40,39 @@ type CreateTicketRequest struct {
 	Title          string    `json:"title"`
 	Description    string    `json:"description"`
 	Priority       string    `json:"priority_id"`
+	CategoryID     *string   `json:"category_id"`
 	OrganizationID uuid.UUID `json:"organization_id"`
 	Location       string    `json:"location"`
 	Sensitive      bool      `json:"sensitive"`
 }
 
 type CreatePublicTicketRequest struct {
-	Token       string `json:"token"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    string `json:"priority_id"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
+	Token       string  `json:"token"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Priority    string  `json:"priority_id"`
+	CategoryID  *string `json:"category_id"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
 }
 
 type UpdateTicketRequest struct {
 	Title       *string    `json:"title"`
 	Description *string    `json:"description"`
 	Priority    *string    `json:"priority_id"`
+	CategoryID  *string    `json:"category_id"`
 	Status      *string    `json:"status_id"`
 	AssigneeID  *uuid.UUID `json:"assignee_id"`
 	Location    *string    `json:"location"`
 	Sensitive   *bool      `json:"sensitive"`
 }
 
-func NewTicketHandler(service port.TicketService, orgRepo port.OrganizationRepository, userRepo port.UserRepository, logger *slog.Logger) *TicketHandler {
+func NewTicketHandler(service port.TicketService, orgRepo port.OrganizationRepository, userRepo port.UserRepository, authz port.Authorizer, logger *slog.Logger) *TicketHandler {
 	return &TicketHandler{
 		service:  service,
 		orgRepo:  orgRepo,
 		userRepo: userRepo,
+		authz:    authz,
 		logger:   logger,
 	}
 }
@@ -90,29 +96,7 @@ func (h *TicketHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := middleware.GetUser(r.Context())
-	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.ID == ticket.OrganizationID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketView, domain.OrgResource(ticket.OrganizationID)) {
 		return
 	}
 
@@ -179,6 +163,9 @@ func (h *TicketHandler) CreatePublicTicket(w http.ResponseWriter, r *http.Reques
 		req.Name = r.FormValue("name")
 		req.Email = r.FormValue("email")
 		req.Priority = r.FormValue("priority_id")
+		if categoryID := r.FormValue("category_id"); categoryID != "" {
+			req.CategoryID = &categoryID
+		}
 
 		if r.MultipartForm != nil && r.MultipartForm.File != nil {
 			for _, fileHeader := range r.MultipartForm.File["files"] {
@@ -290,12 +277,16 @@ func (h *TicketHandler) CreatePublicTicket(w http.ResponseWriter, r *http.Reques
 		Title:          req.Title,
 		Description:    req.Description,
 		PriorityID:     req.Priority,
+		CategoryID:     req.CategoryID,
 		Files:          files,
 		// Location? Not in public form?
 	}
 
 	ticket, err := h.service.CreateTicket(r.Context(), cmd)
 	if err != nil {
+		if writeLookupError(w, err) {
+			return
+		}
 		h.logger.Error("failed to create public ticket", "error", err)
 		http.Error(w, "Failed to create ticket", http.StatusInternalServerError)
 		return
@@ -321,24 +312,7 @@ func (h *TicketHandler) ExportTickets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 2. Fetch User Memberships
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	if len(memberships) == 0 {
-		// User has no organizations, return empty list (or forbidden? Empty is fine)
-		// We'll proceed with an empty list filter which should return nothing if we handle it correctly in logic
-		// But repo logic says "if OrganizationIDs not empty, filter by it". If empty, it might fall back to "WHERE 1=1" if we are not careful.
-		// So we should handle this case specifically.
-		h.writeEmptyCSV(w)
-		return
-	}
-
-	// 3. Parse Filters
+	// 2. Parse Filters
 	var filter port.TicketFilter
 
 	orgIDStr := r.URL.Query().Get("organization_id")
@@ -348,32 +322,42 @@ func (h *TicketHandler) ExportTickets(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Invalid organization_id", http.StatusBadRequest)
 			return
 		}
+		if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketExport, domain.OrgResource(parsed)) {
+			return
+		}
+		filter.OrganizationID = &parsed
+	} else {
+		// No specific org requested, filter by every membership that allows exporting
+		memberships, err := listMemberships(r.Context(), h.orgRepo, user.ID)
+		if err != nil {
+			h.logger.Error("failed to list user memberships", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
 
-		// Verify membership
-		isMember := false
+		var orgIDs []uuid.UUID
 		for _, m := range memberships {
-			if m.ID == parsed {
-				isMember = true
-				break
+			ok, err := h.authz.Can(r.Context(), user, domain.ActionTicketExport, domain.OrgResource(m.ID))
+			if err != nil {
+				h.logger.Error("failed to authorize export", "error", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			if ok {
+				orgIDs = append(orgIDs, m.ID)
 			}
 		}
 
-		if !isMember {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if len(orgIDs) == 0 {
+			// An empty OrganizationIDs filter would match every ticket, so
+			// answer with an empty export instead.
+			h.writeEmptyCSV(w)
 			return
 		}
-
-		filter.OrganizationID = &parsed
-	} else {
-		// No specific org requested, filter by ALL memberships
-		orgIDs := make([]uuid.UUID, len(memberships))
-		for i, m := range memberships {
-			orgIDs[i] = m.ID
-		}
 		filter.OrganizationIDs = orgIDs
 	}
 
-	// 4. Fetch Tickets
+	// 3. Fetch Tickets
 	tickets, err := h.service.ListTickets(r.Context(), filter)
 	if err != nil {
 		h.logger.Error("failed to list tickets for export", "error", err)
@@ -381,7 +365,7 @@ func (h *TicketHandler) ExportTickets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 5. Stream CSV Response
+	// 4. Stream CSV Response
 	w.Header().Set("Content-Type", "text/csv")
 	w.Header().Set("Content-Disposition", "attachment; filename=\"tickets.csv\"")
 
@@ -391,7 +375,7 @@ func (h *TicketHandler) ExportTickets(w http.ResponseWriter, r *http.Request) {
 	defer writer.Flush()
 
 	// Write Header
-	header := []string{"ID", "Organization ID", "Title", "Status", "Priority", "Reporter ID", "Created At", "Description"}
+	header := []string{"ID", "Organization ID", "Title", "Status", "Priority", "Category", "Reporter ID", "Created At", "Description"}
 	if err := writer.Write(header); err != nil {
 		h.logger.Error("failed to write csv header", "error", err)
 		return
@@ -399,12 +383,17 @@ func (h *TicketHandler) ExportTickets(w http.ResponseWriter, r *http.Request) {
 
 	// Write Rows
 	for _, t := range tickets {
+		var categoryID string
+		if t.CategoryID != nil {
+			categoryID = *t.CategoryID
+		}
 		row := []string{
 			t.ID.String(),
 			t.OrganizationID.String(),
 			sanitizeCSV(t.Title),
 			t.StatusID,
 			t.PriorityID,
+			categoryID,
 			t.ReporterID.String(),
 			t.CreatedAt.Format(time.RFC3339),
 			sanitizeCSV(t.Description),
@@ -421,7 +410,7 @@ func (h *TicketHandler) writeEmptyCSV(w http.ResponseWriter) {
 	w.Header().Set("Content-Disposition", "attachment; filename=\"tickets.csv\"")
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
-	header := []string{"ID", "Organization ID", "Title", "Status", "Priority", "Reporter ID", "Created At", "Description"}
+	header := []string{"ID", "Organization ID", "Title", "Status", "Priority", "Category", "Reporter ID", "Created At", "Description"}
 	if err := writer.Write(header); err != nil {
 		h.logger.Error("failed to write csv header", "error", err)
 	}
@@ -451,6 +440,9 @@ func (h *TicketHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 		req.Title = r.FormValue("title")
 		req.Description = r.FormValue("description")
 		req.Priority = r.FormValue("priority_id")
+		if categoryID := r.FormValue("category_id"); categoryID != "" {
+			req.CategoryID = &categoryID
+		}
 		req.Location = r.FormValue("location")
 		req.Sensitive = r.FormValue("sensitive") == "true"
 
@@ -496,24 +488,7 @@ func (h *TicketHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Security Check: Verify user belongs to the organization
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.ID == req.OrganizationID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketCreate, domain.OrgResource(req.OrganizationID)) {
 		return
 	}
 
@@ -533,12 +508,16 @@ func (h *TicketHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 		Description:    req.Description,
 		Location:       req.Location,
 		PriorityID:     req.Priority,
+		CategoryID:     req.CategoryID,
 		Sensitive:      req.Sensitive,
 		Files:          files,
 	}
 
 	ticket, err := h.service.CreateTicket(r.Context(), cmd)
 	if err != nil {
+		if writeLookupError(w, err) {
+			return
+		}
 		h.logger.Error("failed to create ticket", "error", err)
 		http.Error(w, "Failed to create ticket", http.StatusInternalServerError)
 		return
@@ -570,24 +549,7 @@ func (h *TicketHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Security Check: Verify user belongs to the organization
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.ID == orgID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketView, domain.OrgResource(orgID)) {
 		return
 	}
 
@@ -596,11 +558,18 @@ func (h *TicketHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
 		ExcludeDescription: true,
 	}
 
+	// Without explicit statuses, list the tickets in the organization's open
+	// statuses. state=resolved or state=all widens the default.
 	statuses := r.URL.Query()["status"]
 	if len(statuses) > 0 {
 		filter.StatusIDs = statuses
 	} else {
-		filter.StatusIDs = domain.GetActiveStatusIDs()
+		state, err := parseStatusState(r, domain.TicketStatusStateOpen)
+		if err != nil {
+			http.Error(w, "Invalid state", http.StatusBadRequest)
+			return
+		}
+		filter.StatusState = state
 	}
 
 	priorities := r.URL.Query()["priority"]
@@ -608,6 +577,11 @@ func (h *TicketHandler) ListTickets(w http.ResponseWriter, r *http.Request) {
 		filter.PriorityIDs = priorities
 	}
 
+	categories := r.URL.Query()["category"]
+	if len(categories) > 0 {
+		filter.CategoryIDs = categories
+	}
+
 	if search := r.URL.Query().Get("search"); search != "" {
 		filter.Keyword = &search
 	}
@@ -724,7 +698,7 @@ func (h *TicketHandler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Security Check: Get existing ticket and verify user membership
+	// Security Check: Get existing ticket so its organization can be authorized
 	ticket, err := h.service.GetTicket(r.Context(), id)
 	if err != nil {
 		h.logger.Error("failed to get ticket", "error", err)
@@ -742,29 +716,15 @@ func (h *TicketHandler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.ID == ticket.OrganizationID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketUpdate, domain.OrgResource(ticket.OrganizationID)) {
 		return
 	}
 
 	cmd := port.UpdateTicketCmd{
+		ActorID:        &user.ID,
 		StatusID:       req.Status,
 		PriorityID:     req.Priority,
+		CategoryID:     req.CategoryID,
 		AssigneeUserID: req.AssigneeID,
 		Title:          req.Title,
 		Description:    req.Description,
@@ -774,6 +734,9 @@ func (h *TicketHandler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 
 	updatedTicket, err := h.service.UpdateTicket(r.Context(), id, cmd)
 	if err != nil {
+		if writeLookupError(w, err) {
+			return
+		}
 		h.logger.Error("failed to update ticket", "error", err)
 		// Check for specific validation errors if needed, for now 500
 		http.Error(w, "Failed to update ticket", http.StatusInternalServerError)
@@ -787,6 +750,43 @@ func (h *TicketHandler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetTicketHistory returns the audit trail of field changes on a ticket.
+func (h *TicketHandler) GetTicketHistory(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "ticketID")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	ticket, err := h.service.GetTicket(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get ticket", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if ticket == nil {
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+		return
+	}
+
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketView, domain.OrgResource(ticket.OrganizationID)) {
+		return
+	}
+
+	events, err := h.service.ListTicketEvents(r.Context(), ticket.ID)
+	if err != nil {
+		h.logger.Error("failed to list ticket events", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
 func (h *TicketHandler) GetTicketFile(w http.ResponseWriter, r *http.Request) {
 	fileIDStr := chi.URLParam(r, "fileID")
 	fileID, err := uuid.Parse(fileIDStr)
@@ -817,29 +817,7 @@ func (h *TicketHandler) GetTicketFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := middleware.GetUser(r.Context())
-	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	memberships, err := h.orgRepo.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		h.logger.Error("failed to list user memberships", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	isMember := false
-	for _, m := range memberships {
-		if m.ID == ticket.OrganizationID {
-			isMember = true
-			break
-		}
-	}
-
-	if !isMember {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketView, domain.OrgResource(ticket.OrganizationID)) {
 		return
 	}
 
@@ -861,6 +839,37 @@ func (h *TicketHandler) GetTicketFile(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseStatusState reads the state query parameter. An empty value selects
+// def and "all" disables state filtering.
+func parseStatusState(r *http.Request, def domain.TicketStatusState) (domain.TicketStatusState, error) {
+	switch state := r.URL.Query().Get("state"); state {
+	case "":
+		return def, nil
+	case "all":
+		return "", nil
+	default:
+		if !domain.TicketStatusState(state).IsValid() {
+			return "", fmt.Errorf("invalid state: %s", state)
+		}
+		return domain.TicketStatusState(state), nil
+	}
+}
+
+// writeLookupError answers a ticket or scheduled task that names a priority or
+// category the organization does not define with a 400. It reports whether
+// it wrote a response.
+func writeLookupError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, port.ErrInvalidTicketPriority):
+		http.Error(w, "Invalid priority", http.StatusBadRequest)
+	case errors.Is(err, port.ErrInvalidTicketCategory):
+		http.Error(w, "Invalid category", http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
 func sanitizeCSV(s string) string {
 	if strings.HasPrefix(s, "=") || strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "@") {
 		return "'" + s
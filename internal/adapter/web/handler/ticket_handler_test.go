@@ -21,6 +21,7 @@ import (
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
 	"github.com/wsciaroni/opsdeck/internal/core/port"
+	"github.com/wsciaroni/opsdeck/internal/core/service"
 )
 
 // LargeReader generates a large stream of 'A's
@@ -104,6 +105,14 @@ func (m *MockTicketService) ListTicketFiles(ctx context.Context, ticketID uuid.U
 	return args.Get(0).([]domain.File), args.Error(1)
 }
 
+func (m *MockTicketService) ListTicketEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TicketEvent), args.Error(1)
+}
+
 type MockOrgRepo struct {
 	mock.Mock
 }
@@ -128,6 +137,14 @@ func (m *MockOrgRepo) GetByShareToken(ctx context.Context, token string) (*domai
 	return args.Get(0).(*domain.Organization), args.Error(1)
 }
 
+func (m *MockOrgRepo) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
 func (m *MockOrgRepo) GetByPublicViewToken(ctx context.Context, token string) (*domain.Organization, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -148,7 +165,7 @@ func (m *MockOrgRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domai
 	return args.Get(0).([]domain.UserMembership), args.Error(1)
 }
 
-func (m *MockOrgRepo) AddMember(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+func (m *MockOrgRepo) AddMember(ctx context.Context, orgID, userID uuid.UUID, role domain.OrgRole) error {
 	return m.Called(ctx, orgID, userID, role).Error(0)
 }
 
@@ -164,7 +181,7 @@ func (m *MockOrgRepo) RemoveMember(ctx context.Context, orgID, userID uuid.UUID)
 	return m.Called(ctx, orgID, userID).Error(0)
 }
 
-func (m *MockOrgRepo) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+func (m *MockOrgRepo) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrgRole) error {
 	return m.Called(ctx, orgID, userID, role).Error(0)
 }
 
@@ -200,11 +217,20 @@ func (m *MockUserRepo) Update(ctx context.Context, user *domain.User) error {
 	return m.Called(ctx, user).Error(0)
 }
 
+// stubAuthorizer allows everything in the listed organizations.
+type stubAuthorizer struct {
+	allowed map[uuid.UUID]bool
+}
+
+func (a stubAuthorizer) Can(ctx context.Context, user *domain.User, action domain.Action, resource domain.Resource) (bool, error) {
+	return a.allowed[resource.OrganizationID], nil
+}
+
 func TestExportTickets(t *testing.T) {
 	mockService := new(MockTicketService)
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 	r := chi.NewRouter()
 	r.Get("/admin/export/tickets", h.ExportTickets)
@@ -266,6 +292,30 @@ func TestExportTickets(t *testing.T) {
 		assert.Equal(t, tickets[0].Title, records[1][2])
 	})
 
+	t.Run("Only organizations the authorizer allows are exported", func(t *testing.T) {
+		adminUser := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin}
+		allowed, denied := uuid.New(), uuid.New()
+		orgRepo := new(MockOrgRepo)
+		orgRepo.On("ListByUser", mock.Anything, adminUser.ID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: allowed}, Role: domain.OrgRoleOwner},
+			{Organization: domain.Organization{ID: denied}, Role: domain.OrgRoleOwner},
+		}, nil)
+		tickets := new(MockTicketService)
+		tickets.On("ListTickets", mock.Anything, port.TicketFilter{OrganizationIDs: []uuid.UUID{allowed}}).Return([]domain.Ticket{}, nil)
+		authz := stubAuthorizer{allowed: map[uuid.UUID]bool{allowed: true}}
+
+		r := chi.NewRouter()
+		r.Get("/admin/export/tickets", handler.NewTicketHandler(tickets, orgRepo, mockUserRepo, authz, nil).ExportTickets)
+		req := httptest.NewRequest("GET", "/admin/export/tickets", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, adminUser))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		tickets.AssertExpectations(t)
+	})
+
 	t.Run("Forbidden - Non-admin user", func(t *testing.T) {
 		regularUser := &domain.User{
 			ID:   uuid.New(),
@@ -286,7 +336,7 @@ func TestExportTickets(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -340,7 +390,7 @@ func TestCreatePublicTicket(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -390,7 +440,7 @@ func TestCreatePublicTicket(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -444,7 +494,7 @@ func TestCreatePublicTicket(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -473,7 +523,7 @@ func TestCreatePublicTicket(t *testing.T) {
 	})
 
 	t.Run("BadRequest - Invalid Input", func(t *testing.T) {
-		h := handler.NewTicketHandler(nil, nil, nil, nil)
+		h := handler.NewTicketHandler(nil, nil, nil, nil, nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -497,7 +547,7 @@ func TestCreatePublicTicket(t *testing.T) {
 	})
 
 	t.Run("BadRequest - Invalid Email", func(t *testing.T) {
-		h := handler.NewTicketHandler(nil, nil, nil, nil)
+		h := handler.NewTicketHandler(nil, nil, nil, nil, nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -521,7 +571,7 @@ func TestCreatePublicTicket(t *testing.T) {
 	})
 
 	t.Run("RequestEntityTooLarge - Body too large", func(t *testing.T) {
-		h := handler.NewTicketHandler(nil, nil, nil, nil)
+		h := handler.NewTicketHandler(nil, nil, nil, nil, nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -541,7 +591,7 @@ func TestCreatePublicTicket(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -601,7 +651,7 @@ func TestCreatePublicTicket(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/public/tickets", h.CreatePublicTicket)
 
@@ -644,7 +694,7 @@ func TestCreatePublicTicket(t *testing.T) {
 func TestCreateTicket(t *testing.T) {
 	t.Run("BadRequest - Invalid Input", func(t *testing.T) {
 		mockOrgRepo := new(MockOrgRepo)
-		h := handler.NewTicketHandler(nil, mockOrgRepo, nil, nil)
+		h := handler.NewTicketHandler(nil, mockOrgRepo, nil, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/tickets", h.CreateTicket)
 
@@ -664,7 +714,7 @@ func TestCreateTicket(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		memberships := []domain.UserMembership{
-			{Organization: domain.Organization{ID: orgID}},
+			{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleMember},
 		}
 		mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return(memberships, nil)
 
@@ -676,7 +726,7 @@ func TestCreateTicket(t *testing.T) {
 	t.Run("Success - Create Ticket", func(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, nil, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, nil, service.NewAuthorizer(mockOrgRepo), nil)
 		r := chi.NewRouter()
 		r.Post("/tickets", h.CreateTicket)
 
@@ -712,11 +762,125 @@ func TestCreateTicket(t *testing.T) {
 }
 
 func TestListTickets(t *testing.T) {
+	t.Run("State filter", func(t *testing.T) {
+		tests := []struct {
+			query string
+			state domain.TicketStatusState
+			code  int
+		}{
+			{"&state=resolved", domain.TicketStatusStateResolved, http.StatusOK},
+			{"&state=all", "", http.StatusOK},
+			{"&state=closed", "", http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			mockService := new(MockTicketService)
+			mockOrgRepo := new(MockOrgRepo)
+			mockUserRepo := new(MockUserRepo)
+			h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
+
+			r := chi.NewRouter()
+			r.Get("/tickets", h.ListTickets)
+
+			user := &domain.User{ID: uuid.New(), Role: domain.RoleStaff}
+			orgID := uuid.New()
+
+			mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+				{Organization: domain.Organization{ID: orgID}, Role: "member"},
+			}, nil)
+			mockService.On("ListTickets", mock.Anything, port.TicketFilter{
+				OrganizationID:     &orgID,
+				ExcludeDescription: true,
+				StatusState:        tt.state,
+			}).Return([]domain.Ticket{}, nil)
+
+			req := httptest.NewRequest("GET", "/tickets?organization_id="+orgID.String()+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, user))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code, tt.query)
+		}
+	})
+
+	t.Run("Category filter", func(t *testing.T) {
+		mockService := new(MockTicketService)
+		mockOrgRepo := new(MockOrgRepo)
+		mockUserRepo := new(MockUserRepo)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
+
+		r := chi.NewRouter()
+		r.Get("/tickets", h.ListTickets)
+
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleStaff}
+		orgID := uuid.New()
+
+		mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: orgID}, Role: "member"},
+		}, nil)
+		mockService.On("ListTickets", mock.Anything, port.TicketFilter{
+			OrganizationID:     &orgID,
+			ExcludeDescription: true,
+			StatusState:        domain.TicketStatusStateOpen,
+			CategoryIDs:        []string{"plumbing", "electrical"},
+		}).Return([]domain.Ticket{}, nil)
+
+		req := httptest.NewRequest("GET", "/tickets?organization_id="+orgID.String()+"&category=plumbing&category=electrical", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, user))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service keys only reach their organization", func(t *testing.T) {
+		mockService := new(MockTicketService)
+		mockOrgRepo := new(MockOrgRepo)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, new(MockUserRepo), service.NewAuthorizer(mockOrgRepo), nil)
+
+		r := chi.NewRouter()
+		r.Get("/tickets", h.ListTickets)
+
+		user := &domain.User{ID: uuid.New(), Role: domain.RoleStaff}
+		keyOrgID := uuid.New()
+		otherOrgID := uuid.New()
+		key := &domain.APIKey{ID: uuid.New(), UserID: user.ID, OrganizationID: &keyOrgID}
+
+		mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: keyOrgID}, Role: "admin"},
+			{Organization: domain.Organization{ID: otherOrgID}, Role: "admin"},
+		}, nil)
+		mockService.On("ListTickets", mock.Anything, mock.MatchedBy(func(f port.TicketFilter) bool {
+			return *f.OrganizationID == keyOrgID
+		})).Return([]domain.Ticket{}, nil)
+
+		tests := []struct {
+			orgID uuid.UUID
+			code  int
+		}{
+			{keyOrgID, http.StatusOK},
+			{otherOrgID, http.StatusForbidden},
+		}
+		for _, tt := range tests {
+			req := httptest.NewRequest("GET", "/tickets?organization_id="+tt.orgID.String(), nil)
+			ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+			ctx = context.WithValue(ctx, middleware.APIKeyContextKey, key)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req.WithContext(ctx))
+
+			assert.Equal(t, tt.code, w.Code)
+		}
+	})
+
 	t.Run("Success - List tickets for user org", func(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 		r := chi.NewRouter()
 		r.Get("/tickets", h.ListTickets)
@@ -742,7 +906,7 @@ func TestListTickets(t *testing.T) {
 		mockService.On("ListTickets", mock.Anything, port.TicketFilter{
 			OrganizationID:     &orgID,
 			ExcludeDescription: true,
-			StatusIDs:          domain.GetActiveStatusIDs(),
+			StatusState:        domain.TicketStatusStateOpen,
 		}).Return(tickets, nil)
 
 		// Expect GetByIDs (empty)
@@ -764,7 +928,7 @@ func TestListTickets(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 		r := chi.NewRouter()
 		r.Get("/tickets", h.ListTickets)
@@ -803,7 +967,7 @@ func TestListTickets(t *testing.T) {
 		mockService.On("ListTickets", mock.Anything, port.TicketFilter{
 			OrganizationID:     &orgID,
 			ExcludeDescription: true,
-			StatusIDs:          domain.GetActiveStatusIDs(),
+			StatusState:        domain.TicketStatusStateOpen,
 		}).Return(tickets, nil)
 
 		// Expect GetByIDs with 2 users
@@ -835,7 +999,7 @@ func TestListTickets(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 		r := chi.NewRouter()
 		r.Get("/tickets", h.ListTickets)
@@ -865,7 +1029,7 @@ func TestListTickets(t *testing.T) {
 			OrganizationID:     &orgID,
 			ExcludeDescription: true,
 			PriorityIDs:        []string{priority},
-			StatusIDs:          domain.GetActiveStatusIDs(),
+			StatusState:        domain.TicketStatusStateOpen,
 			Keyword:            &search,
 		}).Return(tickets, nil)
 
@@ -888,7 +1052,7 @@ func TestListTickets(t *testing.T) {
 		mockService := new(MockTicketService)
 		mockOrgRepo := new(MockOrgRepo)
 		mockUserRepo := new(MockUserRepo)
-		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+		h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 
 		r := chi.NewRouter()
 		r.Get("/tickets", h.ListTickets)
@@ -925,7 +1089,7 @@ func TestGetTicketFile(t *testing.T) {
 	mockService := new(MockTicketService)
 	mockOrgRepo := new(MockOrgRepo)
 	mockUserRepo := new(MockUserRepo)
-	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, nil)
+	h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
 	r := chi.NewRouter()
 	r.Get("/tickets/files/{fileID}", h.GetTicketFile)
 
@@ -1075,7 +1239,7 @@ func TestUpdateTicket(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			setupMocks: func(ms *MockTicketService, mo *MockOrgRepo) {
 				ms.On("GetTicket", mock.Anything, ticketID).Return(ticket, nil)
-				memberships := []domain.UserMembership{{Organization: domain.Organization{ID: orgID}}}
+				memberships := []domain.UserMembership{{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleMember}}
 				mo.On("ListByUser", mock.Anything, user.ID).Return(memberships, nil)
 				ms.On("UpdateTicket", mock.Anything, ticketID, mock.Anything).Return(ticket, nil)
 			},
@@ -1086,7 +1250,7 @@ func TestUpdateTicket(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockService := new(MockTicketService)
 			mockOrgRepo := new(MockOrgRepo)
-			h := handler.NewTicketHandler(mockService, mockOrgRepo, nil, nil)
+			h := handler.NewTicketHandler(mockService, mockOrgRepo, nil, service.NewAuthorizer(mockOrgRepo), nil)
 			r := chi.NewRouter()
 			r.Patch("/tickets/{ticketID}", h.UpdateTicket)
 
@@ -1116,3 +1280,56 @@ func TestUpdateTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTicket_Authorization(t *testing.T) {
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, Title: "Leaky faucet"}
+
+	tests := []struct {
+		name        string
+		memberships []domain.UserMembership
+		key         *domain.APIKey
+		noUser      bool
+		want        int
+	}{
+		{name: "Owner", memberships: []domain.UserMembership{{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleOwner}}, want: http.StatusOK},
+		{name: "Admin", memberships: []domain.UserMembership{{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleAdmin}}, want: http.StatusOK},
+		{name: "Member", memberships: []domain.UserMembership{{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleMember}}, want: http.StatusOK},
+		{name: "Member of another organization", memberships: []domain.UserMembership{{Organization: domain.Organization{ID: otherOrgID}, Role: domain.OrgRoleOwner}}, want: http.StatusForbidden},
+		{name: "Service key for another organization", memberships: []domain.UserMembership{{Organization: domain.Organization{ID: orgID}, Role: domain.OrgRoleOwner}}, key: &domain.APIKey{ID: uuid.New(), OrganizationID: &otherOrgID}, want: http.StatusForbidden},
+		{name: "Unauthenticated", noUser: true, want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockTicketService)
+			mockOrgRepo := new(MockOrgRepo)
+			mockUserRepo := new(MockUserRepo)
+			h := handler.NewTicketHandler(mockService, mockOrgRepo, mockUserRepo, service.NewAuthorizer(mockOrgRepo), nil)
+			r := chi.NewRouter()
+			r.Get("/tickets/{ticketID}", h.GetTicket)
+
+			user := &domain.User{ID: uuid.New()}
+			mockService.On("GetTicket", mock.Anything, ticket.ID).Return(ticket, nil)
+			mockOrgRepo.On("ListByUser", mock.Anything, user.ID).Return(tc.memberships, nil)
+			mockUserRepo.On("GetByID", mock.Anything, ticket.ReporterID).Return(nil, nil).Maybe()
+			mockService.On("ListTicketFiles", mock.Anything, ticket.ID).Return(nil, nil).Maybe()
+
+			req := httptest.NewRequest("GET", "/tickets/"+ticket.ID.String(), nil)
+			ctx := req.Context()
+			if !tc.noUser {
+				ctx = context.WithValue(ctx, middleware.UserContextKey, user)
+			}
+			if tc.key != nil {
+				ctx = context.WithValue(ctx, middleware.APIKeyContextKey, tc.key)
+			}
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TicketPriorityHandler struct {
+	service port.TicketPriorityService
+	logger  *slog.Logger
+}
+
+func NewTicketPriorityHandler(service port.TicketPriorityService, logger *slog.Logger) *TicketPriorityHandler {
+	return &TicketPriorityHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type CreateTicketPriorityRequest struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	Level       *int   `json:"level"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
+
+type UpdateTicketPriorityRequest struct {
+	Label       *string `json:"label"`
+	Level       *int    `json:"level"`
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+}
+
+// List returns the organization's ticket priorities. Any member may read them.
+func (h *TicketPriorityHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	priorities, err := h.service.ListPriorities(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to list ticket priorities", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(priorities); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketPriorityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateTicketPriorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	priority, err := h.service.CreatePriority(r.Context(), port.CreateTicketPriorityCmd{
+		OrganizationID: orgID,
+		ID:             req.ID,
+		Label:          req.Label,
+		Level:          req.Level,
+		Description:    req.Description,
+		Color:          req.Color,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(priority); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketPriorityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTicketPriorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	priority, err := h.service.UpdatePriority(r.Context(), orgID, chi.URLParam(r, "priorityID"), port.UpdateTicketPriorityCmd{
+		Label:       req.Label,
+		Level:       req.Level,
+		Description: req.Description,
+		Color:       req.Color,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(priority); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketPriorityHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePriority(r.Context(), orgID, chi.URLParam(r, "priorityID")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TicketPriorityHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, port.ErrInvalidTicketPriority):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrTicketPriorityNotFound):
+		http.Error(w, "Priority not found", http.StatusNotFound)
+	case errors.Is(err, port.ErrTicketPriorityInUse):
+		http.Error(w, "Priority is in use by tickets or scheduled tasks", http.StatusConflict)
+	default:
+		h.logger.Error("failed to change ticket priority", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
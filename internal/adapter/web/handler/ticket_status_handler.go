@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TicketStatusHandler struct {
+	service port.TicketStatusService
+	logger  *slog.Logger
+}
+
+func NewTicketStatusHandler(service port.TicketStatusService, logger *slog.Logger) *TicketStatusHandler {
+	return &TicketStatusHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type CreateTicketStatusRequest struct {
+	ID       string                   `json:"id"`
+	Label    string                   `json:"label"`
+	Position *int                     `json:"position"`
+	Color    string                   `json:"color"`
+	State    domain.TicketStatusState `json:"state"`
+}
+
+type UpdateTicketStatusRequest struct {
+	Label    *string                   `json:"label"`
+	Position *int                      `json:"position"`
+	Color    *string                   `json:"color"`
+	State    *domain.TicketStatusState `json:"state"`
+}
+
+// List returns the organization's ticket statuses. Any member may read them.
+func (h *TicketStatusHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	statuses, err := h.service.ListStatuses(r.Context(), orgID)
+	if err != nil {
+		h.logger.Error("failed to list ticket statuses", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketStatusHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateTicketStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.CreateStatus(r.Context(), port.CreateTicketStatusCmd{
+		OrganizationID: orgID,
+		ID:             req.ID,
+		Label:          req.Label,
+		Position:       req.Position,
+		Color:          req.Color,
+		State:          req.State,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketStatusHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTicketStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.UpdateStatus(r.Context(), orgID, chi.URLParam(r, "statusID"), port.UpdateTicketStatusCmd{
+		Label:    req.Label,
+		Position: req.Position,
+		Color:    req.Color,
+		State:    req.State,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *TicketStatusHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteStatus(r.Context(), orgID, chi.URLParam(r, "statusID")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TicketStatusHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, port.ErrInvalidTicketStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrTicketStatusNotFound):
+		http.Error(w, "Status not found", http.StatusNotFound)
+	case errors.Is(err, port.ErrTicketStatusInUse):
+		http.Error(w, "Status is in use by tickets", http.StatusConflict)
+	default:
+		h.logger.Error("failed to change ticket status", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type TimelineHandler struct {
+	timelineService port.TimelineService
+	ticketService   port.TicketService
+	orgRepo         port.OrganizationRepository
+	authz           port.Authorizer
+	logger          *slog.Logger
+}
+
+func NewTimelineHandler(
+	timelineService port.TimelineService,
+	ticketService port.TicketService,
+	orgRepo port.OrganizationRepository,
+	authz port.Authorizer,
+	logger *slog.Logger,
+) *TimelineHandler {
+	return &TimelineHandler{
+		timelineService: timelineService,
+		ticketService:   ticketService,
+		orgRepo:         orgRepo,
+		authz:           authz,
+		logger:          logger,
+	}
+}
+
+// Get returns the activity timeline of a ticket for organization members.
+func (h *TimelineHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ticketIDStr := chi.URLParam(r, "ticketID")
+	ticketID, err := uuid.Parse(ticketIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	query, ok := parseTimelineQuery(w, r)
+	if !ok {
+		return
+	}
+
+	ticket, err := h.ticketService.GetTicket(r.Context(), ticketID)
+	if err != nil {
+		h.logger.Error("failed to get ticket", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if ticket == nil {
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+		return
+	}
+
+	if !middleware.Authorize(w, r, h.authz, h.logger, domain.ActionTicketView, domain.OrgResource(ticket.OrganizationID)) {
+		return
+	}
+
+	page, err := h.timelineService.GetTimeline(r.Context(), ticket, query)
+	h.writePage(w, page, err)
+}
+
+// GetPublic returns the redacted timeline of a non-sensitive ticket through an
+// organization's public view link.
+func (h *TimelineHandler) GetPublic(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	ticketIDStr := chi.URLParam(r, "ticketID")
+	ticketID, err := uuid.Parse(ticketIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	query, ok := parseTimelineQuery(w, r)
+	if !ok {
+		return
+	}
+	query.Public = true
+
+	org, err := h.orgRepo.GetByPublicViewToken(r.Context(), token)
+	if err != nil {
+		h.logger.Error("Failed to get organization by public view token", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if org == nil || !org.PublicViewEnabled {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	ticket, err := h.ticketService.GetTicket(r.Context(), ticketID)
+	if err != nil {
+		h.logger.Error("Failed to get ticket", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if ticket == nil || ticket.OrganizationID != org.ID || ticket.Sensitive {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	page, err := h.timelineService.GetTimeline(r.Context(), ticket, query)
+	h.writePage(w, page, err)
+}
+
+func (h *TimelineHandler) writePage(w http.ResponseWriter, page *domain.TimelinePage, err error) {
+	if err != nil {
+		if errors.Is(err, port.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to get timeline", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func parseTimelineQuery(w http.ResponseWriter, r *http.Request) (port.TimelineQuery, bool) {
+	query := port.TimelineQuery{Cursor: r.URL.Query().Get("cursor")}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return query, false
+		}
+		query.Limit = limit
+	}
+	return query, true
+}
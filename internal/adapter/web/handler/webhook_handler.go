@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type WebhookHandler struct {
+	service port.WebhookService
+	logger  *slog.Logger
+}
+
+func NewWebhookHandler(service port.WebhookService, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type createWebhookRequest struct {
+	URL         string                     `json:"url"`
+	Description string                     `json:"description"`
+	Format      domain.WebhookFormat       `json:"format"`
+	Events      []domain.NotificationEvent `json:"events"`
+	PriorityIDs []string                   `json:"priority_ids"`
+	CategoryIDs []string                   `json:"category_ids"`
+	Internal    bool                       `json:"internal"`
+}
+
+type updateWebhookRequest struct {
+	URL         *string                     `json:"url"`
+	Description *string                     `json:"description"`
+	Format      *domain.WebhookFormat       `json:"format"`
+	Events      *[]domain.NotificationEvent `json:"events"`
+	PriorityIDs *[]string                   `json:"priority_ids"`
+	CategoryIDs *[]string                   `json:"category_ids"`
+	Internal    *bool                       `json:"internal"`
+	Enabled     *bool                       `json:"enabled"`
+}
+
+// List returns the organization's webhook endpoints. Webhooks can carry
+// ticket content off-site, so every webhook endpoint requires the owner or
+// admin role.
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.service.ListEndpoints(r.Context(), orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, endpoints)
+}
+
+// Create registers an endpoint. The response is the only one that includes
+// the signing secret.
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req createWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(r.Context(), port.CreateWebhookCmd{
+		OrganizationID: orgID,
+		URL:            req.URL,
+		Description:    req.Description,
+		Format:         req.Format,
+		Events:         req.Events,
+		PriorityIDs:    req.PriorityIDs,
+		CategoryIDs:    req.CategoryIDs,
+		Internal:       req.Internal,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, endpoint)
+}
+
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req updateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	endpointID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		http.Error(w, "Invalid Webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(r.Context(), port.UpdateWebhookCmd{
+		OrganizationID: orgID,
+		EndpointID:     endpointID,
+		URL:            req.URL,
+		Description:    req.Description,
+		Format:         req.Format,
+		Events:         req.Events,
+		PriorityIDs:    req.PriorityIDs,
+		CategoryIDs:    req.CategoryIDs,
+		Internal:       req.Internal,
+		Enabled:        req.Enabled,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, endpoint)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	endpointID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		http.Error(w, "Invalid Webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(r.Context(), orgID, endpointID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries returns a page of an endpoint's delivery log, newest first.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	endpointID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		http.Error(w, "Invalid Webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	query := port.WebhookDeliveryQuery{Cursor: q.Get("cursor")}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = limit
+	}
+
+	page, err := h.service.ListDeliveries(r.Context(), orgID, endpointID, query)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, page)
+}
+
+// Redeliver queues a past delivery to be sent again and returns the new
+// delivery.
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	endpointID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		http.Error(w, "Invalid Webhook ID", http.StatusBadRequest)
+		return
+	}
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		http.Error(w, "Invalid Delivery ID", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), orgID, endpointID, deliveryID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func (h *WebhookHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, port.ErrInvalidWebhook):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrInvalidCursor):
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+	case errors.Is(err, port.ErrWebhookNotFound):
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+	default:
+		h.logger.Error("failed to handle webhook request", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
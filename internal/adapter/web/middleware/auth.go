@@ -9,24 +9,32 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
 	"github.com/wsciaroni/opsdeck/internal/core/port"
 )
 
 type contextKey string
 
-const UserContextKey contextKey = "user"
+const (
+	UserContextKey    contextKey = "user"
+	SessionContextKey contextKey = "session"
+	APIKeyContextKey  contextKey = "api_key"
+)
+
+// SessionCookieName is the cookie that carries the signed session token.
+const SessionCookieName = "session_id"
 
 type AuthMiddleware struct {
-	userRepo port.UserRepository
+	sessions port.SessionService
+	apiKeys  port.APIKeyService
 	logger   *slog.Logger
 	secret   []byte
 }
 
-func NewAuthMiddleware(userRepo port.UserRepository, logger *slog.Logger, secret string) *AuthMiddleware {
+func NewAuthMiddleware(sessions port.SessionService, apiKeys port.APIKeyService, logger *slog.Logger, secret string) *AuthMiddleware {
 	return &AuthMiddleware{
-		userRepo: userRepo,
+		sessions: sessions,
+		apiKeys:  apiKeys,
 		logger:   logger,
 		secret:   []byte(secret),
 	}
@@ -40,58 +48,134 @@ func SignSessionID(id string, secret []byte) string {
 	return id + "." + signature
 }
 
+// VerifySessionID checks a value produced by SignSessionID and returns the
+// session ID it carries.
+func VerifySessionID(value string, secret []byte) (string, bool) {
+	id, signature, ok := strings.Cut(value, ".")
+	if !ok || id == "" {
+		return "", false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id))
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return "", false
+	}
+	return id, true
+}
+
+// Protect only lets requests through that carry the cookie of a live
+// session or an API key in an "Authorization: Bearer" header. Unsigned
+// cookies are turned away before the session store is consulted. Requests
+// made with an API key still have to pass RequireScope.
 func (m *AuthMiddleware) Protect(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		cookie, err := r.Cookie("session_id")
-		if err != nil {
-			// No session cookie
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		if header := r.Header.Get("Authorization"); header != "" {
+			m.protectAPIKey(w, r, header, next)
 			return
 		}
 
-		parts := strings.Split(cookie.Value, ".")
-		if len(parts) != 2 {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		id := parts[0]
-		signature := parts[1]
-
-		mac := hmac.New(sha256.New, m.secret)
-		mac.Write([]byte(id))
-		expectedSignature := hex.EncodeToString(mac.Sum(nil))
-
-		if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
-			m.logger.Warn("invalid session signature", "cookie", cookie.Value)
+		cookie, err := r.Cookie(SessionCookieName)
+		if err != nil {
+			// No session cookie
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		userID, err := uuid.Parse(id)
-		if err != nil {
-			// Invalid UUID
+		token, ok := VerifySessionID(cookie.Value, m.secret)
+		if !ok {
+			m.logger.Warn("invalid session signature")
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		user, err := m.userRepo.GetByID(r.Context(), userID)
+		user, session, err := m.sessions.Authenticate(r.Context(), token)
 		if err != nil {
-			m.logger.Error("failed to get user", "error", err)
+			m.logger.Error("failed to authenticate session", "error", err)
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 		if user == nil {
-			// User not found
+			// Unknown, expired or revoked session
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), UserContextKey, user)
+		ctx = context.WithValue(ctx, SessionContextKey, session)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+func (m *AuthMiddleware) protectAPIKey(w http.ResponseWriter, r *http.Request, header string, next http.Handler) {
+	scheme, token, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	user, key, err := m.apiKeys.Authenticate(r.Context(), strings.TrimSpace(token))
+	if err != nil {
+		m.logger.Error("failed to authenticate api key", "error", err)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if user == nil {
+		// Unknown or revoked key
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserContextKey, user)
+	ctx = context.WithValue(ctx, APIKeyContextKey, key)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RequireScope lets requests made with an API key through only if the key
+// has scope. Browser sessions are not limited by scopes.
+func RequireScope(scope domain.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := GetAPIKey(r.Context()); key != nil && !key.HasScope(scope) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SessionOnly turns away requests made with an API key. It guards
+// everything no scope covers, such as account and organization settings.
+func SessionOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetAPIKey(r.Context()) != nil {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetAPIKey retrieves the API key the request was authenticated with, if
+// any.
+func GetAPIKey(ctx context.Context) *domain.APIKey {
+	key, ok := ctx.Value(APIKeyContextKey).(*domain.APIKey)
+	if !ok {
+		return nil
+	}
+	return key
+}
+
+// GetSession retrieves the session the request was authenticated with.
+func GetSession(ctx context.Context) *domain.Session {
+	session, ok := ctx.Value(SessionContextKey).(*domain.Session)
+	if !ok {
+		return nil
+	}
+	return session
+}
+
 // GetUser retrieves the user from the context.
 func GetUser(ctx context.Context) *domain.User {
 	user, ok := ctx.Value(UserContextKey).(*domain.User)
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeSessions accepts a single token.
+type fakeSessions struct {
+	port.SessionService
+	token   string
+	user    *domain.User
+	session *domain.Session
+}
+
+func (f *fakeSessions) Authenticate(ctx context.Context, token string) (*domain.User, *domain.Session, error) {
+	if token != f.token {
+		return nil, nil, nil
+	}
+	return f.user, f.session, nil
+}
+
+// fakeAPIKeys accepts a single token.
+type fakeAPIKeys struct {
+	port.APIKeyService
+	token string
+	user  *domain.User
+	key   *domain.APIKey
+}
+
+func (f *fakeAPIKeys) Authenticate(ctx context.Context, token string) (*domain.User, *domain.APIKey, error) {
+	if token != f.token {
+		return nil, nil, nil
+	}
+	return f.user, f.key, nil
+}
+
+func TestProtect(t *testing.T) {
+	secret := []byte("test-secret")
+	user := &domain.User{ID: uuid.New()}
+	session := &domain.Session{ID: uuid.New(), UserID: user.ID}
+	sessions := &fakeSessions{token: "live-token", user: user, session: session}
+	key := &domain.APIKey{ID: uuid.New(), UserID: user.ID, Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}}
+	apiKeys := &fakeAPIKeys{token: "odk_live", user: user, key: key}
+	m := NewAuthMiddleware(sessions, apiKeys, slog.Default(), string(secret))
+
+	var gotUser *domain.User
+	var gotSession *domain.Session
+	var gotKey *domain.APIKey
+	handler := m.Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = GetUser(r.Context())
+		gotSession = GetSession(r.Context())
+		gotKey = GetAPIKey(r.Context())
+	}))
+
+	tests := []struct {
+		name          string
+		cookie        string
+		authorization string
+		want          int
+		wantKey       bool
+	}{
+		{name: "Live session", cookie: SignSessionID("live-token", secret), want: http.StatusOK},
+		{name: "No cookie", want: http.StatusUnauthorized},
+		{name: "Unsigned", cookie: "live-token", want: http.StatusUnauthorized},
+		{name: "Forged signature", cookie: SignSessionID("live-token", []byte("other")), want: http.StatusUnauthorized},
+		{name: "Signed user ID from before sessions", cookie: SignSessionID(user.ID.String(), secret), want: http.StatusUnauthorized},
+		{name: "Revoked or expired", cookie: SignSessionID("old-token", secret), want: http.StatusUnauthorized},
+		{name: "API key", authorization: "Bearer odk_live", want: http.StatusOK, wantKey: true},
+		{name: "Lowercase scheme", authorization: "bearer odk_live", want: http.StatusOK, wantKey: true},
+		{name: "Unknown API key", authorization: "Bearer odk_revoked", want: http.StatusUnauthorized},
+		{name: "Basic auth", authorization: "Basic b2RrX2xpdmU6", want: http.StatusUnauthorized},
+		{name: "Bad header beats a good cookie", cookie: SignSessionID("live-token", secret), authorization: "Bearer odk_revoked", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUser, gotSession, gotKey = nil, nil, nil
+			req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: tt.cookie, Expires: time.Now().Add(time.Hour)})
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+			if tt.want == http.StatusOK {
+				assert.Equal(t, user, gotUser)
+				if tt.wantKey {
+					assert.Equal(t, key, gotKey)
+					assert.Nil(t, gotSession)
+				} else {
+					assert.Equal(t, session, gotSession)
+					assert.Nil(t, gotKey)
+				}
+			} else {
+				assert.Nil(t, gotUser)
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	key := &domain.APIKey{ID: uuid.New(), Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name    string
+		key     *domain.APIKey
+		handler http.Handler
+		want    int
+	}{
+		{name: "Session", handler: RequireScope(domain.APIKeyScopeExport)(ok), want: http.StatusOK},
+		{name: "Key with the scope", key: key, handler: RequireScope(domain.APIKeyScopeTicketsRead)(ok), want: http.StatusOK},
+		{name: "Key without the scope", key: key, handler: RequireScope(domain.APIKeyScopeTicketsWrite)(ok), want: http.StatusForbidden},
+		{name: "Session on a session-only route", handler: SessionOnly(ok), want: http.StatusOK},
+		{name: "Key on a session-only route", key: key, handler: SessionOnly(ok), want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tickets", nil)
+			if tt.key != nil {
+				req = req.WithContext(context.WithValue(req.Context(), APIKeyContextKey, tt.key))
+			}
+			rr := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rr, req)
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
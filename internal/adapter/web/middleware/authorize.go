@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// Authorize checks that the request's user may take action on resource. A
+// service key is also confined to its own organization. It writes the
+// error response itself and reports whether the request may proceed.
+func Authorize(w http.ResponseWriter, r *http.Request, authz port.Authorizer, logger *slog.Logger, action domain.Action, resource domain.Resource) bool {
+	user := GetUser(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	if key := GetAPIKey(r.Context()); key != nil && key.OrganizationID != nil && *key.OrganizationID != resource.OrganizationID {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+
+	ok, err := authz.Can(r.Context(), user, action, resource)
+	if err != nil {
+		logger.Error("failed to authorize request", "action", action, "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return false
+	}
+	if !ok {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// RequireOrgPermission guards routes under /organizations/{id}, letting
+// the request through only if the user may take action in that
+// organization.
+func RequireOrgPermission(authz port.Authorizer, logger *slog.Logger, action domain.Action) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+			if err != nil {
+				http.Error(w, "Invalid Organization ID", http.StatusBadRequest)
+				return
+			}
+			if !Authorize(w, r, authz, logger, action, domain.OrgResource(orgID)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
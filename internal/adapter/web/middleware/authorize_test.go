@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// fakeAuthorizer allows a single action in a single organization.
+type fakeAuthorizer struct {
+	orgID  uuid.UUID
+	action domain.Action
+	err    error
+}
+
+func (f *fakeAuthorizer) Can(ctx context.Context, user *domain.User, action domain.Action, resource domain.Resource) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return action == f.action && resource.OrganizationID == f.orgID, nil
+}
+
+func TestRequireOrgPermission(t *testing.T) {
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	user := &domain.User{ID: uuid.New()}
+	authz := &fakeAuthorizer{orgID: orgID, action: domain.ActionOrgManage}
+
+	tests := []struct {
+		name   string
+		user   *domain.User
+		key    *domain.APIKey
+		orgID  string
+		action domain.Action
+		authz  *fakeAuthorizer
+		want   int
+	}{
+		{name: "Allowed", user: user, orgID: orgID.String(), action: domain.ActionOrgManage, want: http.StatusOK},
+		{name: "Action not allowed", user: user, orgID: orgID.String(), action: domain.ActionMemberRemove, want: http.StatusForbidden},
+		{name: "Other organization", user: user, orgID: otherOrgID.String(), action: domain.ActionOrgManage, want: http.StatusForbidden},
+		{name: "No user", orgID: orgID.String(), action: domain.ActionOrgManage, want: http.StatusUnauthorized},
+		{name: "Invalid organization ID", user: user, orgID: "not-a-uuid", action: domain.ActionOrgManage, want: http.StatusBadRequest},
+		{name: "Personal key", user: user, key: &domain.APIKey{ID: uuid.New()}, orgID: orgID.String(), action: domain.ActionOrgManage, want: http.StatusOK},
+		{name: "Service key for the organization", user: user, key: &domain.APIKey{ID: uuid.New(), OrganizationID: &orgID}, orgID: orgID.String(), action: domain.ActionOrgManage, want: http.StatusOK},
+		{name: "Service key for another organization", user: user, key: &domain.APIKey{ID: uuid.New(), OrganizationID: &otherOrgID}, orgID: orgID.String(), action: domain.ActionOrgManage, want: http.StatusForbidden},
+		{name: "Authorizer error", user: user, orgID: orgID.String(), action: domain.ActionOrgManage, authz: &fakeAuthorizer{err: errors.New("db down")}, want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := authz
+			if tt.authz != nil {
+				a = tt.authz
+			}
+			r := chi.NewRouter()
+			r.With(RequireOrgPermission(a, slog.Default(), tt.action)).Get("/organizations/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+			req := httptest.NewRequest(http.MethodGet, "/organizations/"+tt.orgID, nil)
+			ctx := req.Context()
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, UserContextKey, tt.user)
+			}
+			if tt.key != nil {
+				ctx = context.WithValue(ctx, APIKeyContextKey, tt.key)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req.WithContext(ctx))
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"io/fs"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,6 +10,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/wsciaroni/opsdeck/internal/adapter/web/handler"
 	appMiddleware "github.com/wsciaroni/opsdeck/internal/adapter/web/middleware"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
 )
 
 func NewRouter(
@@ -20,69 +23,162 @@ func NewRouter(
 	commentHandler *handler.CommentHandler,
 	publicViewHandler *handler.PublicViewHandler,
 	scheduledTaskHandler *handler.ScheduledTaskHandler,
+	timelineHandler *handler.TimelineHandler,
+	ticketStatusHandler *handler.TicketStatusHandler,
+	ticketPriorityHandler *handler.TicketPriorityHandler,
+	ticketCategoryHandler *handler.TicketCategoryHandler,
+	notificationPreferenceHandler *handler.NotificationPreferenceHandler,
+	emailTemplateHandler *handler.EmailTemplateHandler,
+	inboxHandler *handler.InboxHandler,
+	webhookHandler *handler.WebhookHandler,
+	digestHandler *handler.DigestHandler,
+	apiKeyHandler *handler.APIKeyHandler,
 	authMW *appMiddleware.AuthMiddleware,
+	authz port.Authorizer,
+	logger *slog.Logger,
 ) http.Handler {
 	r := chi.NewRouter()
 
+	// can guards a route under /organizations/{id} with the permission matrix.
+	can := func(action domain.Action) func(http.Handler) http.Handler {
+		return appMiddleware.RequireOrgPermission(authz, logger, action)
+	}
+
 	// Middleware
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(appMiddleware.SecurityHeaders)
 
 	// Auth Routes
+	r.Get("/auth/providers", authHandler.Providers)
 	r.Get("/auth/login", authHandler.Login)
 	r.Get("/auth/callback", authHandler.Callback)
+	r.Post("/auth/magic-link", authHandler.RequestMagicLink)
+	r.Get("/auth/magic-link/callback", authHandler.MagicLinkConfirm)
+	r.Post("/auth/magic-link/callback", authHandler.MagicLinkCallback)
 	r.Post("/auth/logout", authHandler.Logout)
 
 	// API Routes
 	r.Route("/api", func(r chi.Router) {
 		r.Method(http.MethodGet, "/health", NewHealthHandler(db))
 		r.Post("/public/tickets", ticketHandler.CreatePublicTicket)
+		r.Get("/public/share/{token}/categories", ticketCategoryHandler.ListShared)
 
 		r.Route("/public/view/{token}", func(r chi.Router) {
 			r.Get("/organization", publicViewHandler.GetOrganization)
+			r.Get("/statuses", publicViewHandler.ListStatuses)
+			r.Get("/priorities", publicViewHandler.ListPriorities)
 			r.Get("/tickets", publicViewHandler.ListTickets)
 			r.Get("/tickets/{ticketID}", publicViewHandler.GetTicket)
 			r.Get("/tickets/{ticketID}/comments", publicViewHandler.ListComments)
+			r.Get("/tickets/{ticketID}/timeline", timelineHandler.GetPublic)
 		})
 
-		// Protected Routes
+		// Protected Routes. Browser sessions reach all of them; API keys
+		// only reach the groups their scopes allow.
 		r.Group(func(r chi.Router) {
 			r.Use(authMW.Protect)
-			r.Get("/me", authHandler.Me)
-
-			// Admin Routes
-			r.Get("/admin/export/tickets", ticketHandler.ExportTickets)
-
-			r.Post("/tickets", ticketHandler.CreateTicket)
-			r.Get("/tickets", ticketHandler.ListTickets)
-			r.Get("/tickets/{ticketID}", ticketHandler.GetTicket)
-			r.Get("/tickets/{ticketID}/files/{fileID}", ticketHandler.GetTicketFile)
-			r.Patch("/tickets/{ticketID}", ticketHandler.UpdateTicket)
-
-			// Comments
-			r.Post("/tickets/{ticketID}/comments", commentHandler.Create)
-			r.Get("/tickets/{ticketID}/comments", commentHandler.List)
-
-			// Scheduled Tasks
-			r.Get("/scheduled-tasks", scheduledTaskHandler.List)
-			r.Post("/scheduled-tasks", scheduledTaskHandler.Create)
-			r.Patch("/scheduled-tasks/{id}", scheduledTaskHandler.Update)
-			r.Delete("/scheduled-tasks/{id}", scheduledTaskHandler.Delete)
-
-			r.Post("/organizations", orgHandler.CreateOrganization)
-			r.Post("/organizations/{id}/members", orgHandler.AddMember)
-			r.Get("/organizations/{id}/members", orgHandler.ListMembers)
-			r.Delete("/organizations/{id}/members/{userID}", orgHandler.RemoveMember)
-			r.Put("/organizations/{id}/members/{userID}/role", orgHandler.UpdateMemberRole)
-
-			r.Get("/organizations/{id}/share", orgHandler.GetShareSettings)
-			r.Put("/organizations/{id}/share", orgHandler.UpdateShareSettings)
-			r.Post("/organizations/{id}/share/regenerate", orgHandler.RegenerateShareToken)
-
-			r.Get("/organizations/{id}/public-view", orgHandler.GetPublicViewSettings)
-			r.Put("/organizations/{id}/public-view", orgHandler.UpdatePublicViewSettings)
-			r.Post("/organizations/{id}/public-view/regenerate", orgHandler.RegeneratePublicViewToken)
+
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.RequireScope(domain.APIKeyScopeTicketsRead))
+				r.Get("/tickets", ticketHandler.ListTickets)
+				r.Get("/tickets/{ticketID}", ticketHandler.GetTicket)
+				r.Get("/tickets/{ticketID}/files/{fileID}", ticketHandler.GetTicketFile)
+				r.Get("/tickets/{ticketID}/history", ticketHandler.GetTicketHistory)
+				r.Get("/tickets/{ticketID}/timeline", timelineHandler.Get)
+				r.Get("/tickets/{ticketID}/comments", commentHandler.List)
+
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/statuses", ticketStatusHandler.List)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/priorities", ticketPriorityHandler.List)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/categories", ticketCategoryHandler.List)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.RequireScope(domain.APIKeyScopeTicketsWrite))
+				r.Post("/tickets", ticketHandler.CreateTicket)
+				r.Patch("/tickets/{ticketID}", ticketHandler.UpdateTicket)
+				r.Post("/tickets/{ticketID}/comments", commentHandler.Create)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.RequireScope(domain.APIKeyScopeExport))
+				// Admin Routes
+				r.Get("/admin/export/tickets", ticketHandler.ExportTickets)
+				r.With(can(domain.ActionOrgManage)).Get("/organizations/{id}/audit/export", orgHandler.ExportAuditLog)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.SessionOnly)
+				r.Get("/me", authHandler.Me)
+				r.Put("/me/phone", authHandler.UpdatePhone)
+				r.Get("/me/sessions", authHandler.ListSessions)
+				r.Delete("/me/sessions", authHandler.RevokeOtherSessions)
+				r.Delete("/me/sessions/{sessionID}", authHandler.RevokeSession)
+				r.Get("/me/api-keys", apiKeyHandler.ListMine)
+				r.Post("/me/api-keys", apiKeyHandler.CreateMine)
+				r.Delete("/me/api-keys/{keyID}", apiKeyHandler.RevokeMine)
+				r.Get("/me/notification-preferences", notificationPreferenceHandler.GetMine)
+				r.Put("/me/notification-preferences", notificationPreferenceHandler.UpdateMine)
+				r.Get("/me/digest", digestHandler.GetMine)
+				r.Put("/me/digest", digestHandler.UpdateMine)
+				r.Get("/me/notifications", inboxHandler.List)
+				r.Post("/me/notifications/read-all", inboxHandler.MarkAllRead)
+				r.Post("/me/notifications/{notificationID}/read", inboxHandler.MarkRead)
+
+				// Scheduled Tasks
+				r.Get("/scheduled-tasks", scheduledTaskHandler.List)
+				r.Post("/scheduled-tasks", scheduledTaskHandler.Create)
+				r.Patch("/scheduled-tasks/{id}", scheduledTaskHandler.Update)
+				r.Delete("/scheduled-tasks/{id}", scheduledTaskHandler.Delete)
+				r.Get("/scheduled-tasks/{id}/runs", scheduledTaskHandler.ListRuns)
+				r.Get("/scheduled-tasks/{id}/occurrences", scheduledTaskHandler.ListOccurrences)
+				r.Post("/scheduled-tasks/occurrences", scheduledTaskHandler.PreviewOccurrences)
+
+				r.Post("/organizations", orgHandler.CreateOrganization)
+				r.With(can(domain.ActionMemberAdd)).Post("/organizations/{id}/members", orgHandler.AddMember)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/members", orgHandler.ListMembers)
+				r.Delete("/organizations/{id}/members/{userID}", orgHandler.RemoveMember)
+				r.With(can(domain.ActionMemberChangeRole)).Put("/organizations/{id}/members/{userID}/role", orgHandler.UpdateMemberRole)
+
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/timezone", orgHandler.UpdateTimezone)
+
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/statuses", ticketStatusHandler.Create)
+				r.With(can(domain.ActionOrgManage)).Patch("/organizations/{id}/statuses/{statusID}", ticketStatusHandler.Update)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/statuses/{statusID}", ticketStatusHandler.Delete)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/priorities", ticketPriorityHandler.Create)
+				r.With(can(domain.ActionOrgManage)).Patch("/organizations/{id}/priorities/{priorityID}", ticketPriorityHandler.Update)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/priorities/{priorityID}", ticketPriorityHandler.Delete)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/categories", ticketCategoryHandler.Create)
+				r.With(can(domain.ActionOrgManage)).Patch("/organizations/{id}/categories/{categoryID}", ticketCategoryHandler.Update)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/categories/{categoryID}", ticketCategoryHandler.Delete)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/notification-preferences", notificationPreferenceHandler.GetOrganization)
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/notification-preferences", notificationPreferenceHandler.UpdateOrganization)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/email-templates", emailTemplateHandler.List)
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/email-templates/{event}", emailTemplateHandler.Update)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/email-templates/{event}", emailTemplateHandler.Reset)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/email-templates/{event}/preview", emailTemplateHandler.Preview)
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/email-branding", emailTemplateHandler.GetBranding)
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/email-branding", emailTemplateHandler.UpdateBranding)
+				r.With(can(domain.ActionOrgManage)).Get("/organizations/{id}/webhooks", webhookHandler.List)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/webhooks", webhookHandler.Create)
+				r.With(can(domain.ActionOrgManage)).Patch("/organizations/{id}/webhooks/{webhookID}", webhookHandler.Update)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/webhooks/{webhookID}", webhookHandler.Delete)
+				r.With(can(domain.ActionOrgManage)).Get("/organizations/{id}/webhooks/{webhookID}/deliveries", webhookHandler.ListDeliveries)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver", webhookHandler.Redeliver)
+				r.With(can(domain.ActionOrgManage)).Get("/organizations/{id}/api-keys", apiKeyHandler.ListOrganization)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/api-keys", apiKeyHandler.CreateOrganization)
+				r.With(can(domain.ActionOrgManage)).Delete("/organizations/{id}/api-keys/{keyID}", apiKeyHandler.RevokeOrganization)
+
+				r.With(can(domain.ActionOrgManage)).Get("/organizations/{id}/audit", orgHandler.ListAuditLog)
+
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/share", orgHandler.GetShareSettings)
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/share", orgHandler.UpdateShareSettings)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/share/regenerate", orgHandler.RegenerateShareToken)
+
+				r.With(can(domain.ActionOrgView)).Get("/organizations/{id}/public-view", orgHandler.GetPublicViewSettings)
+				r.With(can(domain.ActionOrgManage)).Put("/organizations/{id}/public-view", orgHandler.UpdatePublicViewSettings)
+				r.With(can(domain.ActionOrgManage)).Post("/organizations/{id}/public-view/regenerate", orgHandler.RegeneratePublicViewToken)
+			})
 		})
 	})
 
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// maxChatCommentLength keeps quoted comments readable in a channel and well
+// under Slack's 3000 character limit for a text block.
+const maxChatCommentLength = 1000
+
+// chatMessage is the format-neutral content of a chat notification.
+type chatMessage struct {
+	headline string
+	title    string
+	details  []string
+	comment  string
+}
+
+func newChatMessage(p domain.WebhookPayload) chatMessage {
+	d := p.Data
+	t := d.Ticket
+	actor := d.ActorName
+	if actor == "" {
+		actor = "Someone"
+	}
+
+	m := chatMessage{title: t.Title}
+	if t.Redacted {
+		m.title = "Sensitive ticket " + domain.TicketReference(t.ID)
+	}
+
+	switch p.Event {
+	case domain.NotificationTicketCreated:
+		m.headline = "New ticket"
+	case domain.NotificationTicketAssigned:
+		m.headline = "Ticket assigned"
+	case domain.NotificationTicketCommented:
+		m.headline = actor + " commented"
+		if c := d.Comment; c != nil && !c.Redacted {
+			m.comment = truncate(c.Body, maxChatCommentLength)
+		}
+	case domain.NotificationStatusChanged:
+		m.headline = fmt.Sprintf("Status changed from %s to %s", label(d.OldStatusLabel, d.OldStatusID), label(d.NewStatusLabel, d.NewStatusID))
+	default:
+		m.headline = string(p.Event)
+	}
+
+	m.details = append(m.details, "Status: "+label(t.StatusLabel, t.StatusID))
+	m.details = append(m.details, "Priority: "+label(t.PriorityLabel, t.PriorityID))
+	if t.CategoryID != nil {
+		m.details = append(m.details, "Category: "+label(t.CategoryLabel, *t.CategoryID))
+	}
+	if t.Location != "" {
+		m.details = append(m.details, "Location: "+t.Location)
+	}
+	return m
+}
+
+func (m chatMessage) text() string {
+	s := m.headline + ": " + m.title
+	if m.comment != "" {
+		s += "\n" + m.comment
+	}
+	return s
+}
+
+// slackMessage renders m as a Block Kit message for a Slack incoming webhook.
+// Text is the notification fallback. Slack parses it as mrkdwn too, so it is
+// escaped like the blocks.
+func slackMessage(m chatMessage) map[string]any {
+	blocks := []map[string]any{
+		{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": "*" + slackEscape(m.headline) + "*\n" + slackEscape(m.title)},
+		},
+	}
+	if m.comment != "" {
+		quoted := "> " + strings.ReplaceAll(slackEscape(m.comment), "\n", "\n> ")
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": quoted},
+		})
+	}
+	elements := make([]map[string]any, 0, len(m.details))
+	for _, d := range m.details {
+		elements = append(elements, map[string]any{"type": "mrkdwn", "text": slackEscape(d)})
+	}
+	blocks = append(blocks, map[string]any{"type": "context", "elements": elements})
+
+	return map[string]any{
+		"text":   slackEscape(m.text()),
+		"blocks": blocks,
+	}
+}
+
+// matrixMessage renders m for a Matrix generic webhook bridge, which posts
+// html when the bridge supports it and text otherwise.
+func matrixMessage(m chatMessage) map[string]any {
+	var b strings.Builder
+	b.WriteString("<p><strong>" + html.EscapeString(m.headline) + "</strong>: " + html.EscapeString(m.title) + "</p>")
+	if m.comment != "" {
+		b.WriteString("<blockquote>" + strings.ReplaceAll(html.EscapeString(m.comment), "\n", "<br>") + "</blockquote>")
+	}
+	escaped := make([]string, len(m.details))
+	for i, d := range m.details {
+		escaped[i] = html.EscapeString(d)
+	}
+	b.WriteString("<p><small>" + strings.Join(escaped, " · ") + "</small></p>")
+
+	return map[string]any{
+		"text":     m.text() + "\n" + strings.Join(m.details, " · "),
+		"html":     b.String(),
+		"username": "OpsDeck",
+	}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func label(label, id string) string {
+	if label != "" {
+		return label
+	}
+	return id
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
@@ -0,0 +1,195 @@
+// Package webhook delivers signed webhook payloads over HTTP, either as the
+// versioned JSON payload or formatted for chat incoming webhooks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the endpoint's secret.
+	SignatureHeader = "X-OpsDeck-Signature"
+	TimestampHeader = "X-OpsDeck-Timestamp"
+	EventHeader     = "X-OpsDeck-Event"
+	DeliveryHeader  = "X-OpsDeck-Delivery"
+
+	defaultTimeout = 10 * time.Second
+	// maxResponseBody bounds how much of a response is kept in the delivery
+	// log.
+	maxResponseBody = 2048
+)
+
+// errPrivateAddress is returned when an endpoint resolves to an address
+// inside a private network.
+var errPrivateAddress = errors.New("webhook endpoint resolves to a private address")
+
+// SenderConfig configures the HTTP sender. Endpoints resolving to loopback,
+// private, link-local or other non-routable addresses are refused unless
+// AllowPrivateNetworks is set, so organizations cannot use webhooks to probe
+// the server's network.
+type SenderConfig struct {
+	Timeout              time.Duration
+	AllowPrivateNetworks bool
+}
+
+// Sender implements port.WebhookSender over HTTP.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSender(cfg SenderConfig) *Sender {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = refusePrivateAddresses
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Sender{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// Redirects could lead anywhere; endpoints must answer directly.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, req port.WebhookRequest) (*port.WebhookResponse, error) {
+	body, err := encode(req.Format, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "OpsDeck-Webhooks/1")
+	httpReq.Header.Set(EventHeader, string(req.Payload.Event))
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID.String())
+	httpReq.Header.Set(TimestampHeader, timestamp)
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, timestamp, body))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // the body has been read
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &port.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       strings.ToValidUTF8(string(respBody), "�"),
+	}, nil
+}
+
+// encode renders the payload as the endpoint's format expects.
+func encode(format domain.WebhookFormat, p domain.WebhookPayload) ([]byte, error) {
+	var v any = p
+	switch format {
+	case domain.WebhookFormatSlack:
+		v = slackMessage(newChatMessage(p))
+	case domain.WebhookFormatMatrix:
+		v = matrixMessage(newChatMessage(p))
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return body, nil
+}
+
+// Sign returns the signature header value for a payload sent at timestamp.
+// Receivers should recompute it and compare in constant time, and reject
+// timestamps too far from their own clock to prevent replays.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// blockedPrefixes are the ranges webhooks may not reach: private,
+// loopback, link-local, shared and reserved space, plus the IPv6 prefixes
+// that embed an IPv4 address and could be used to reach those.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (CGNAT)
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local, including cloud metadata
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+
+	netip.MustParsePrefix("::/128"),         // unspecified
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("100::/64"),       // discard-only
+	netip.MustParsePrefix("2001::/32"),      // Teredo
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+// refusePrivateAddresses runs after DNS resolution, so hostnames pointing at
+// internal addresses are caught too. IPv4-mapped IPv6 addresses are checked
+// as the IPv4 address they carry.
+func refusePrivateAddresses(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return errPrivateAddress
+	}
+	addr = addr.WithZone("").Unmap()
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return errPrivateAddress
+		}
+	}
+	return nil
+}
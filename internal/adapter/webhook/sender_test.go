@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// recorder is a local stand-in for an endpoint. It keeps the last request.
+type recorder struct {
+	header http.Header
+	body   []byte
+}
+
+func (rec *recorder) serve(t *testing.T, status int, reply string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.header = r.Header
+		rec.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func commentPayload() domain.WebhookPayload {
+	category := "plumbing"
+	return domain.WebhookPayload{
+		Version: domain.WebhookPayloadVersion,
+		ID:      uuid.New(),
+		Event:   domain.NotificationTicketCommented,
+		Data: domain.WebhookEventData{
+			Ticket: domain.WebhookTicket{
+				ID:            uuid.New(),
+				Title:         "Leaky faucet <kitchen>",
+				StatusID:      domain.TicketStatusInProgress,
+				StatusLabel:   "In Progress",
+				PriorityID:    domain.TicketPriorityHigh,
+				PriorityLabel: "High",
+				CategoryID:    &category,
+				CategoryLabel: "Plumbing",
+			},
+			Comment:   &domain.WebhookComment{ID: uuid.New(), Body: "Plumber booked\nfor Tuesday"},
+			ActorName: "Max",
+		},
+	}
+}
+
+func TestSenderSignsPayload(t *testing.T) {
+	rec := &recorder{}
+	url := rec.serve(t, http.StatusAccepted, "queued")
+	payload := commentPayload()
+	deliveryID := uuid.New()
+
+	sender := NewSender(SenderConfig{AllowPrivateNetworks: true})
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	resp, err := sender.Send(context.Background(), port.WebhookRequest{
+		URL:        url,
+		Secret:     "s3cret",
+		Format:     domain.WebhookFormatJSON,
+		DeliveryID: deliveryID,
+		Payload:    payload,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "queued", resp.Body)
+
+	var got domain.WebhookPayload
+	require.NoError(t, json.Unmarshal(rec.body, &got))
+	assert.Equal(t, payload.ID, got.ID)
+	assert.Equal(t, "ticket_commented", rec.header.Get(EventHeader))
+	assert.Equal(t, deliveryID.String(), rec.header.Get(DeliveryHeader))
+	assert.Equal(t, "1700000000", rec.header.Get(TimestampHeader))
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(rec.body)))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), rec.header.Get(SignatureHeader))
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"version":1}' | openssl dgst -sha256 -hmac s3cret
+	assert.Equal(t, "sha256=a88b61052c4236878628e418fb04bb6048d23e65d5ad3c5daf2866200cb2994f", Sign("s3cret", "1700000000", []byte(`{"version":1}`)))
+}
+
+func TestSenderSlackFormat(t *testing.T) {
+	rec := &recorder{}
+	url := rec.serve(t, http.StatusOK, "ok")
+
+	_, err := NewSender(SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(), port.WebhookRequest{
+		URL:     url,
+		Format:  domain.WebhookFormatSlack,
+		Payload: commentPayload(),
+	})
+	require.NoError(t, err)
+
+	var msg struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type string `json:"type"`
+			Text *struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"text"`
+			Elements []struct {
+				Text string `json:"text"`
+			} `json:"elements"`
+		} `json:"blocks"`
+	}
+	require.NoError(t, json.Unmarshal(rec.body, &msg))
+	assert.Equal(t, "Max commented: Leaky faucet &lt;kitchen&gt;\nPlumber booked\nfor Tuesday", msg.Text)
+	require.Len(t, msg.Blocks, 3)
+	assert.Equal(t, "mrkdwn", msg.Blocks[0].Text.Type)
+	assert.Equal(t, "*Max commented*\nLeaky faucet &lt;kitchen&gt;", msg.Blocks[0].Text.Text)
+	assert.Equal(t, "> Plumber booked\n> for Tuesday", msg.Blocks[1].Text.Text)
+	assert.Equal(t, "context", msg.Blocks[2].Type)
+	assert.Equal(t, "Priority: High", msg.Blocks[2].Elements[1].Text)
+	assert.Equal(t, "Category: Plumbing", msg.Blocks[2].Elements[2].Text)
+}
+
+func TestSlackMessageEscapesMentions(t *testing.T) {
+	payload := commentPayload()
+	payload.Data.Ticket.Title = "Leak <!channel>"
+	payload.Data.Comment.Body = "ping <@U123>"
+
+	msg := slackMessage(newChatMessage(payload))
+	text := msg["text"].(string)
+	assert.NotContains(t, text, "<!channel>")
+	assert.NotContains(t, text, "<@U123>")
+	assert.Equal(t, "Max commented: Leak &lt;!channel&gt;\nping &lt;@U123&gt;", text)
+}
+
+func TestSenderMatrixFormat(t *testing.T) {
+	rec := &recorder{}
+	url := rec.serve(t, http.StatusOK, "")
+
+	payload := commentPayload()
+	payload.Event = domain.NotificationStatusChanged
+	payload.Data.Comment = nil
+	payload.Data.OldStatusLabel = "New"
+	payload.Data.NewStatusLabel = "In Progress"
+
+	_, err := NewSender(SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(), port.WebhookRequest{
+		URL:     url,
+		Format:  domain.WebhookFormatMatrix,
+		Payload: payload,
+	})
+	require.NoError(t, err)
+
+	var msg map[string]string
+	require.NoError(t, json.Unmarshal(rec.body, &msg))
+	assert.Equal(t, "Status changed from New to In Progress: Leaky faucet <kitchen>\nStatus: In Progress · Priority: High · Category: Plumbing", msg["text"])
+	assert.Contains(t, msg["html"], "<strong>Status changed from New to In Progress</strong>: Leaky faucet &lt;kitchen&gt;")
+	assert.Equal(t, "OpsDeck", msg["username"])
+}
+
+func TestSenderRedactedChatMessage(t *testing.T) {
+	payload := commentPayload()
+	payload.Data.Ticket.Sensitive = true
+	payload.Data.Redact()
+
+	m := newChatMessage(payload)
+	assert.Equal(t, "Sensitive ticket "+domain.TicketReference(payload.Data.Ticket.ID), m.title)
+	assert.Equal(t, "Someone commented", m.headline)
+	assert.Empty(t, m.comment)
+}
+
+func TestSenderRefusesPrivateNetworks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback endpoint")
+	}))
+	defer srv.Close()
+
+	_, err := NewSender(SenderConfig{}).Send(context.Background(), port.WebhookRequest{URL: srv.URL})
+	assert.ErrorIs(t, err, errPrivateAddress)
+}
+
+func TestRefusePrivateAddresses(t *testing.T) {
+	refused := []string{
+		"127.0.0.1:80", "10.1.2.3:443", "172.16.0.1:443", "192.168.1.1:443",
+		"169.254.169.254:80", "100.64.0.1:443", "0.0.0.0:80", "192.0.0.8:443",
+		"198.18.0.1:443", "240.0.0.1:443", "255.255.255.255:443", "224.0.0.1:443",
+		"[::1]:443", "[::]:443", "[fd00::1]:443", "[fe80::1%eth0]:443", "[ff02::1]:443",
+		"[::ffff:10.0.0.1]:443", "[::ffff:127.0.0.1]:443", "[64:ff9b::a00:1]:443", "[2002:a00:1::]:443",
+	}
+	for _, address := range refused {
+		assert.ErrorIs(t, refusePrivateAddresses("tcp", address, nil), errPrivateAddress, address)
+	}
+
+	allowed := []string{"93.184.216.34:443", "100.128.0.1:443", "[2606:4700::1111]:443", "[::ffff:93.184.216.34]:443"}
+	for _, address := range allowed {
+		assert.NoError(t, refusePrivateAddresses("tcp", address, nil), address)
+	}
+}
+
+func TestSenderDoesNotFollowRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	resp, err := NewSender(SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(), port.WebhookRequest{URL: srv.URL})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+}
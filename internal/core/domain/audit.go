@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit Actions
+const (
+	AuditActionMemberAdded                = "member.added"
+	AuditActionMemberRemoved              = "member.removed"
+	AuditActionMemberRoleChanged          = "member.role_changed"
+	AuditActionShareLinkUpdated           = "share_link.updated"
+	AuditActionShareLinkTokenRegenerated  = "share_link.token_regenerated"
+	AuditActionPublicViewUpdated          = "public_view.updated"
+	AuditActionPublicViewTokenRegenerated = "public_view.token_regenerated"
+	AuditActionTimezoneUpdated            = "organization.timezone_updated"
+)
+
+// Audit Target Types
+const (
+	AuditTargetUser         = "user"
+	AuditTargetOrganization = "organization"
+)
+
+// AuditEntry is an immutable record of an administrative action taken on an
+// organization. Before and After hold the JSON state of the target around the
+// change; either may be null.
+type AuditEntry struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	ActorID        *uuid.UUID      `json:"actor_id"`
+	ActorName      *string         `json:"actor_name"`
+	Action         string          `json:"action"`
+	TargetType     string          `json:"target_type"`
+	TargetID       uuid.UUID       `json:"target_id"`
+	Before         json.RawMessage `json:"before"`
+	After          json.RawMessage `json:"after"`
+	IPAddress      string          `json:"ip_address"`
+	UserAgent      string          `json:"user_agent"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AuditPage is one page of an organization's audit log, newest first.
+type AuditPage struct {
+	Entries    []AuditEntry `json:"entries"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
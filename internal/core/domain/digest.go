@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestFrequency is how often a user receives their activity digest.
+type DigestFrequency string
+
+const (
+	DigestFrequencyNone   DigestFrequency = "none"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// Valid reports whether f is a known frequency.
+func (f DigestFrequency) Valid() bool {
+	switch f {
+	case DigestFrequencyNone, DigestFrequencyDaily, DigestFrequencyWeekly:
+		return true
+	}
+	return false
+}
+
+// DigestHour is the local hour digests are sent at. Weekly digests go out on
+// Monday.
+const DigestHour = 7
+
+// DigestSettings is a user's digest preference. Timezone is an IANA zone;
+// when empty the timezone of the user's first organization applies.
+type DigestSettings struct {
+	UserID     uuid.UUID       `json:"-"`
+	Frequency  DigestFrequency `json:"frequency"`
+	Timezone   string          `json:"timezone"`
+	LastSentAt *time.Time      `json:"last_sent_at"`
+}
+
+// DueAt returns the latest scheduled send time at or before now: DigestHour
+// local time on every day for daily digests, or on Mondays for weekly ones.
+// It returns the zero time when digests are off.
+func (s DigestSettings) DueAt(now time.Time, loc *time.Location) time.Time {
+	if s.Frequency != DigestFrequencyDaily && s.Frequency != DigestFrequencyWeekly {
+		return time.Time{}
+	}
+	local := now.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day(), DigestHour, 0, 0, 0, loc)
+	if due.After(now) {
+		due = due.AddDate(0, 0, -1)
+	}
+	if s.Frequency == DigestFrequencyWeekly {
+		for due.Weekday() != time.Monday {
+			due = due.AddDate(0, 0, -1)
+		}
+	}
+	return due
+}
+
+// Period is the span a digest normally covers.
+func (f DigestFrequency) Period() time.Duration {
+	if f == DigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Digest summarizes what happened in a user's organizations between Since and
+// Until. All times are in the user's timezone.
+type Digest struct {
+	RecipientName string
+	Frequency     DigestFrequency
+	Since         time.Time
+	Until         time.Time
+	Organizations []DigestOrganization
+}
+
+// DigestOrganization is one organization's part of a digest. Each list is
+// capped; the matching More field counts what was left out.
+type DigestOrganization struct {
+	Name string
+	// Assigned are tickets assigned to the recipient that changed.
+	Assigned     []DigestTicket
+	MoreAssigned int
+	// New are tickets submitted to the organization.
+	New     []DigestTicket
+	MoreNew int
+	// Overdue are open tickets assigned to the recipient whose scheduled
+	// task has come round again.
+	Overdue     []DigestTicket
+	MoreOverdue int
+	// Upcoming are scheduled tasks that fire within the next week.
+	Upcoming     []DigestTask
+	MoreUpcoming int
+}
+
+// Empty reports whether there is nothing to tell about the organization.
+func (o DigestOrganization) Empty() bool {
+	return len(o.Assigned) == 0 && len(o.New) == 0 && len(o.Overdue) == 0 && len(o.Upcoming) == 0
+}
+
+type DigestTicket struct {
+	ID       uuid.UUID
+	Title    string
+	Status   string
+	Location string
+	// At is when the ticket changed, was created or fell due, depending on
+	// the list it is in.
+	At time.Time
+}
+
+type DigestTask struct {
+	Title    string
+	Location string
+	DueAt    time.Time
+}
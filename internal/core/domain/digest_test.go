@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestSettingsDueAt(t *testing.T) {
+	chicago := LoadLocation("America/Chicago")
+
+	tests := []struct {
+		name      string
+		frequency DigestFrequency
+		now       time.Time
+		want      time.Time
+	}{
+		{
+			name:      "Daily after the hour",
+			frequency: DigestFrequencyDaily,
+			now:       time.Date(2026, 3, 10, 8, 30, 0, 0, chicago),
+			want:      time.Date(2026, 3, 10, 7, 0, 0, 0, chicago),
+		},
+		{
+			name:      "Daily before the hour is yesterday's",
+			frequency: DigestFrequencyDaily,
+			now:       time.Date(2026, 3, 10, 6, 59, 0, 0, chicago),
+			want:      time.Date(2026, 3, 9, 7, 0, 0, 0, chicago),
+		},
+		{
+			name:      "Weekly is Monday's",
+			frequency: DigestFrequencyWeekly,
+			now:       time.Date(2026, 3, 12, 9, 0, 0, 0, chicago),
+			want:      time.Date(2026, 3, 9, 7, 0, 0, 0, chicago),
+		},
+		{
+			name:      "Off",
+			frequency: DigestFrequencyNone,
+			now:       time.Date(2026, 3, 12, 9, 0, 0, 0, chicago),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := DigestSettings{Frequency: tt.frequency}
+			got := s.DueAt(tt.now.UTC(), chicago)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
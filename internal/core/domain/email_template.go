@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailTemplate is an organization's own wording for the email sent on one
+// event. Subject and TextBody are text/template sources, HTMLBody is an
+// html/template source. All three execute against EmailTemplateData.
+type EmailTemplate struct {
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	Event          NotificationEvent `json:"event"`
+	Subject        string            `json:"subject"`
+	TextBody       string            `json:"text_body"`
+	HTMLBody       string            `json:"html_body"`
+	// Custom is false when the template is the built-in default.
+	Custom    bool      `json:"custom"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EmailBranding is how an organization's emails are framed: the logo shown
+// above and the footer shown below every message.
+type EmailBranding struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	LogoURL        string    `json:"logo_url"`
+	Footer         string    `json:"footer"`
+}
+
+// EmailTemplateData is what email templates are executed against.
+type EmailTemplateData struct {
+	Organization EmailOrganizationData
+	Recipient    EmailPersonData
+	Actor        EmailPersonData
+	Ticket       EmailTicketData
+	// Comment is nil unless the event is TicketCommented.
+	Comment *EmailCommentData
+	// OldStatus and NewStatus are set for StatusChanged.
+	OldStatus string
+	NewStatus string
+	// IsReporter is true when the recipient reported the ticket.
+	IsReporter bool
+	// Public is true when the recipient is not a member of the organization.
+	Public bool
+}
+
+type EmailOrganizationData struct {
+	Name    string
+	LogoURL string
+	Footer  string
+}
+
+type EmailPersonData struct {
+	Name string
+}
+
+type EmailTicketData struct {
+	ID uuid.UUID
+	// Reference is the ticket's TicketReference. Subjects should include it
+	// so replies by email reach the ticket.
+	Reference   string
+	Title       string
+	Description string
+	Location    string
+	Status      string
+	Reporter    string
+	Assignee    string
+}
+
+type EmailCommentData struct {
+	Body      string
+	Sensitive bool
+}
+
+// ForPublic returns a copy of d that is safe to render for someone outside
+// the organization: who reported and who works the ticket are removed, as are
+// internal notes.
+func (d EmailTemplateData) ForPublic() EmailTemplateData {
+	d.Public = true
+	d.Ticket.Reporter = ""
+	d.Ticket.Assignee = ""
+	if d.Comment != nil && d.Comment.Sensitive {
+		d.Comment = nil
+	}
+	return d
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// InboundEmail is a message received for one of the organizations'
+// mailboxes, already decoded from MIME.
+type InboundEmail struct {
+	// Mailbox is the local part of the recipient address, the organization's
+	// slug.
+	Mailbox  string
+	From     string
+	FromName string
+	Subject  string
+	// Text is the plain-text body, or the HTML body with markup removed when
+	// the message has no plain-text part.
+	Text        string
+	Attachments []File
+	// AutoSubmitted is set for auto-replies, bounces and list traffic, which
+	// must never open tickets or the acknowledgement would answer them.
+	AutoSubmitted bool
+}
+
+// TicketReference is the tag notification emails carry in their subject so
+// replies can be matched to the ticket.
+func TicketReference(id uuid.UUID) string {
+	return "[#" + id.String() + "]"
+}
+
+var ticketReferencePattern = regexp.MustCompile(`\[#([0-9a-fA-F-]{36})\]`)
+
+// ParseTicketReference finds a TicketReference in subject. It returns the
+// ticket ID and the subject with the reference removed.
+func ParseTicketReference(subject string) (uuid.UUID, string, bool) {
+	loc := ticketReferencePattern.FindStringSubmatchIndex(subject)
+	if loc == nil {
+		return uuid.Nil, subject, false
+	}
+	id, err := uuid.Parse(subject[loc[2]:loc[3]])
+	if err != nil {
+		return uuid.Nil, subject, false
+	}
+	rest := strings.Join(strings.Fields(subject[:loc[0]]+" "+subject[loc[1]:]), " ")
+	return id, rest, true
+}
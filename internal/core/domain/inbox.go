@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxNotification is an entry in a user's in-app notification inbox.
+// Summary is rendered when the notification is dispatched; TicketTitle is the
+// ticket's current title.
+type InboxNotification struct {
+	ID             uuid.UUID         `json:"id"`
+	UserID         uuid.UUID         `json:"user_id"`
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	TicketID       uuid.UUID         `json:"ticket_id"`
+	TicketTitle    string            `json:"ticket_title"`
+	Event          NotificationEvent `json:"event"`
+	ActorID        *uuid.UUID        `json:"actor_id"`
+	ActorName      *string           `json:"actor_name"`
+	Summary        string            `json:"summary"`
+	ReadAt         *time.Time        `json:"read_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// InboxPage is one page of a user's inbox, newest first.
+type InboxPage struct {
+	Notifications []InboxNotification `json:"notifications"`
+	NextCursor    string              `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Organization-defined lookup values such as ticket statuses and priorities
+// share the same ID, label and color rules.
+
+var (
+	lookupIDPattern    = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+	lookupColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	nonLookupIDChars   = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// LookupIDFromLabel derives an ID such as "waiting_on_parts" from a label.
+func LookupIDFromLabel(label string) string {
+	id := nonLookupIDChars.ReplaceAllString(strings.ToLower(label), "_")
+	id = strings.Trim(id, "_")
+	if len(id) > 50 {
+		id = strings.TrimRight(id[:50], "_")
+	}
+	return id
+}
+
+func validateLookup(id, label, color string) error {
+	if !lookupIDPattern.MatchString(id) {
+		return fmt.Errorf("id must be 1-50 lowercase letters, digits or underscores")
+	}
+	if strings.TrimSpace(label) == "" {
+		return fmt.Errorf("label is required")
+	}
+	if color != "" && !lookupColorPattern.MatchString(color) {
+		return fmt.Errorf("color must be a hex value such as #22c55e")
+	}
+	return nil
+}
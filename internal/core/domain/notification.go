@@ -0,0 +1,34 @@
+package domain
+
+import "github.com/google/uuid"
+
+// NotificationEvent identifies the ticket activity a notification is about.
+type NotificationEvent string
+
+const (
+	NotificationTicketCreated   NotificationEvent = "ticket_created"
+	NotificationTicketAssigned  NotificationEvent = "ticket_assigned"
+	NotificationTicketCommented NotificationEvent = "ticket_commented"
+	NotificationStatusChanged   NotificationEvent = "status_changed"
+)
+
+// Notification records that something happened to a ticket. It only carries
+// references; recipients and content are resolved when it is dispatched.
+type Notification struct {
+	Event     NotificationEvent `json:"event"`
+	TicketID  uuid.UUID         `json:"ticket_id"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	CommentID *uuid.UUID        `json:"comment_id,omitempty"`
+	// OldStatusID and NewStatusID are set for NotificationStatusChanged.
+	OldStatusID string `json:"old_status_id,omitempty"`
+	NewStatusID string `json:"new_status_id,omitempty"`
+}
+
+// EmailMessage is a rendered email addressed to a single recipient. Body is
+// the plain-text part; HTMLBody is optional.
+type EmailMessage struct {
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	HTMLBody string `json:"html_body,omitempty"`
+}
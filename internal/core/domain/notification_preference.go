@@ -0,0 +1,89 @@
+package domain
+
+import "fmt"
+
+// NotificationChannel is how a notification reaches a user.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	NotificationChannelNone  NotificationChannel = "none"
+)
+
+// DefaultNotificationChannel applies when neither the user nor the
+// organization chose a channel for an event.
+const DefaultNotificationChannel = NotificationChannelEmail
+
+// NotificationEvents lists every event users can set a preference for.
+var NotificationEvents = []NotificationEvent{
+	NotificationTicketCreated,
+	NotificationTicketAssigned,
+	NotificationTicketCommented,
+	NotificationStatusChanged,
+}
+
+// Valid reports whether e is one of NotificationEvents.
+func (e NotificationEvent) Valid() bool {
+	for _, known := range NotificationEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationPreference chooses the channel for one event.
+type NotificationPreference struct {
+	Event   NotificationEvent   `json:"event"`
+	Channel NotificationChannel `json:"channel"`
+}
+
+// NotificationPreferences is a set of preferences at one scope, a user or an
+// organization. Events without an entry inherit from the next scope.
+type NotificationPreferences []NotificationPreference
+
+// Channel returns the channel chosen for event, if any.
+func (ps NotificationPreferences) Channel(event NotificationEvent) (NotificationChannel, bool) {
+	for _, p := range ps {
+		if p.Event == event {
+			return p.Channel, true
+		}
+	}
+	return "", false
+}
+
+// Validate checks that every event and channel is known and that no event is
+// listed twice.
+func (ps NotificationPreferences) Validate() error {
+	seen := make(map[NotificationEvent]bool, len(ps))
+	for _, p := range ps {
+		if !p.Event.Valid() {
+			return fmt.Errorf("unknown event %q", p.Event)
+		}
+		if seen[p.Event] {
+			return fmt.Errorf("duplicate event %q", p.Event)
+		}
+		seen[p.Event] = true
+
+		switch p.Channel {
+		case NotificationChannelEmail, NotificationChannelSMS, NotificationChannelNone:
+		default:
+			return fmt.Errorf("unknown channel %q", p.Channel)
+		}
+	}
+	return nil
+}
+
+// ResolveNotificationChannel applies the preference hierarchy from FR-11: the
+// user's preference wins, then the organization's default, then
+// DefaultNotificationChannel.
+func ResolveNotificationChannel(event NotificationEvent, user, org NotificationPreferences) NotificationChannel {
+	if channel, ok := user.Channel(event); ok {
+		return channel
+	}
+	if channel, ok := org.Channel(event); ok {
+		return channel
+	}
+	return DefaultNotificationChannel
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,29 +9,64 @@ import (
 
 // Organization represents a workspace or tenant.
 type Organization struct {
-	ID               uuid.UUID `json:"id"`
-	Name             string    `json:"name"`
-	Slug             string    `json:"slug"`
-	ShareLinkEnabled bool      `json:"share_link_enabled"`
-	ShareLinkToken   *string   `json:"share_link_token"`
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	Slug              string    `json:"slug"`
+	ShareLinkEnabled  bool      `json:"share_link_enabled"`
+	ShareLinkToken    *string   `json:"share_link_token"`
 	PublicViewEnabled bool      `json:"public_view_enabled"`
 	PublicViewToken   *string   `json:"public_view_token"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	Timezone          string    `json:"timezone"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// DefaultTimezone is used for organizations that have not chosen a timezone.
+const DefaultTimezone = "UTC"
+
+// Location returns the organization's timezone, falling back to UTC when it is
+// unset or unknown.
+func (o *Organization) Location() *time.Location {
+	return LoadLocation(o.Timezone)
+}
+
+// ValidateTimezone checks that name is an IANA timezone such as
+// "America/New_York".
+func ValidateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("invalid timezone: %q", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("invalid timezone: %q", name)
+	}
+	return nil
+}
+
+// LoadLocation resolves an IANA timezone name, falling back to UTC when it is
+// empty or unknown.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 // OrganizationMember represents the link between a user and an organization.
 type OrganizationMember struct {
 	OrganizationID uuid.UUID `json:"organization_id"`
 	UserID         uuid.UUID `json:"user_id"`
-	Role           string    `json:"role"`
+	Role           OrgRole   `json:"role"`
 	JoinedAt       time.Time `json:"joined_at"`
 }
 
 // UserMembership represents a user's membership in an organization, including the organization details and their role.
 type UserMembership struct {
 	Organization
-	Role string `json:"role"`
+	Role OrgRole `json:"role"`
 }
 
 // Member represents a user in an organization with their role.
@@ -39,5 +75,5 @@ type Member struct {
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
 	AvatarURL string    `json:"avatar_url"`
-	Role      string    `json:"role"`
+	Role      OrgRole   `json:"role"`
 }
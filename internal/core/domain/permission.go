@@ -0,0 +1,103 @@
+package domain
+
+import "github.com/google/uuid"
+
+// OrgRole is a member's role within an organization. It is separate from a
+// user's site-wide Role.
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+// OrgRoles lists every organization role, most privileged first.
+var OrgRoles = []OrgRole{OrgRoleOwner, OrgRoleAdmin, OrgRoleMember}
+
+// IsValid reports whether r is a known organization role.
+func (r OrgRole) IsValid() bool {
+	for _, known := range OrgRoles {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Action is something a member can do within an organization.
+type Action string
+
+const (
+	// ActionOrgView covers reading an organization's members, settings and
+	// lookup values.
+	ActionOrgView Action = "org:view"
+	// ActionOrgManage covers changing an organization's settings and lookup
+	// values, its integrations, and reading its audit log.
+	ActionOrgManage Action = "org:manage"
+	// ActionOrgLeave is a member removing themselves.
+	ActionOrgLeave         Action = "org:leave"
+	ActionMemberAdd        Action = "member:add"
+	ActionMemberChangeRole Action = "member:change_role"
+	ActionMemberRemove     Action = "member:remove"
+
+	ActionTicketView   Action = "ticket:view"
+	ActionTicketCreate Action = "ticket:create"
+	ActionTicketUpdate Action = "ticket:update"
+	// ActionTicketExport is only offered to site-wide admins, who may then
+	// export the organizations where their role allows it.
+	ActionTicketExport Action = "ticket:export"
+
+	ActionCommentView   Action = "comment:view"
+	ActionCommentCreate Action = "comment:create"
+
+	ActionScheduledTaskView   Action = "scheduled_task:view"
+	ActionScheduledTaskManage Action = "scheduled_task:manage"
+)
+
+// orgPermissions is the permission matrix: the actions each organization
+// role may take. Anything not listed is denied.
+var orgPermissions = map[OrgRole][]Action{
+	OrgRoleOwner: {
+		ActionOrgView, ActionOrgManage, ActionOrgLeave,
+		ActionMemberAdd, ActionMemberChangeRole, ActionMemberRemove,
+		ActionTicketView, ActionTicketCreate, ActionTicketUpdate, ActionTicketExport,
+		ActionCommentView, ActionCommentCreate,
+		ActionScheduledTaskView, ActionScheduledTaskManage,
+	},
+	OrgRoleAdmin: {
+		ActionOrgView, ActionOrgManage, ActionOrgLeave,
+		ActionMemberAdd, ActionMemberChangeRole,
+		ActionTicketView, ActionTicketCreate, ActionTicketUpdate, ActionTicketExport,
+		ActionCommentView, ActionCommentCreate,
+		ActionScheduledTaskView, ActionScheduledTaskManage,
+	},
+	OrgRoleMember: {
+		ActionOrgView, ActionOrgLeave,
+		ActionTicketView, ActionTicketCreate, ActionTicketUpdate, ActionTicketExport,
+		ActionCommentView, ActionCommentCreate,
+		ActionScheduledTaskView, ActionScheduledTaskManage,
+	},
+}
+
+// Can reports whether the matrix lets role r take action.
+func (r OrgRole) Can(action Action) bool {
+	for _, allowed := range orgPermissions[r] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource is what an action is taken on. Everything a member acts on
+// belongs to an organization.
+type Resource struct {
+	OrganizationID uuid.UUID
+}
+
+// OrgResource returns the resource for an organization itself or anything
+// in it.
+func OrgResource(orgID uuid.UUID) Resource {
+	return Resource{OrganizationID: orgID}
+}
@@ -0,0 +1,616 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFreq is the FREQ part of an RRULE.
+type RecurrenceFreq string
+
+const (
+	RecurrenceDaily   RecurrenceFreq = "DAILY"
+	RecurrenceWeekly  RecurrenceFreq = "WEEKLY"
+	RecurrenceMonthly RecurrenceFreq = "MONTHLY"
+	RecurrenceYearly  RecurrenceFreq = "YEARLY"
+)
+
+// maxEmptyRecurrencePeriods bounds how many consecutive periods without a match
+// are scanned, so rules that can never match (e.g. February 30th) terminate.
+const maxEmptyRecurrencePeriods = 5000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is a BYDAY entry such as "MO", "1MO" or "-1FR". N is zero when the
+// rule applies to every matching weekday in the period.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+type untilKind int
+
+const (
+	untilNone untilKind = iota
+	untilUTC
+	untilFloating
+	untilDate
+)
+
+// RRule is a parsed RFC 5545 recurrence rule. The supported parts are FREQ
+// (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYMONTH,
+// BYMONTHDAY, BYDAY, BYSETPOS and WKST. Occurrences keep the wall-clock time of
+// DTSTART in DTSTART's location, and DTSTART itself is only an occurrence when
+// it matches the rule.
+type RRule struct {
+	Freq       RecurrenceFreq
+	Interval   int
+	Count      int
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	BySetPos   []int
+	WeekStart  time.Weekday
+
+	until     time.Time
+	untilKind untilKind
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=1MO". An
+// optional "RRULE:" prefix is accepted.
+func ParseRRule(s string) (*RRule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("rrule is empty")
+	}
+
+	r := &RRule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rrule part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = RecurrenceFreq(value)
+			switch r.Freq {
+			case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+			default:
+				err = fmt.Errorf("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseRangedInt(value, 1, 1000)
+		case "COUNT":
+			r.Count, err = parseRangedInt(value, 1, 100000)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYMONTH":
+			err = eachValue(value, func(v string) error {
+				m, err := parseRangedInt(v, 1, 12)
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+				return err
+			})
+		case "BYMONTHDAY":
+			err = eachValue(value, func(v string) error {
+				d, err := parseSignedRangedInt(v, 31)
+				r.ByMonthDay = append(r.ByMonthDay, d)
+				return err
+			})
+		case "BYDAY":
+			err = eachValue(value, func(v string) error {
+				wd, err := parseWeekdayNum(v)
+				r.ByDay = append(r.ByDay, wd)
+				return err
+			})
+		case "BYSETPOS":
+			err = eachValue(value, func(v string) error {
+				p, err := parseSignedRangedInt(v, 366)
+				r.BySetPos = append(r.BySetPos, p)
+				return err
+			})
+		case "WKST":
+			day, ok := weekdayCodes[value]
+			if !ok {
+				err = fmt.Errorf("invalid WKST %s", value)
+			}
+			r.WeekStart = day
+		default:
+			err = fmt.Errorf("unsupported rrule part %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RRule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("rrule FREQ is required")
+	}
+	if r.Count > 0 && r.untilKind != untilNone {
+		return fmt.Errorf("rrule COUNT and UNTIL are mutually exclusive")
+	}
+	if r.Freq == RecurrenceWeekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, wd := range r.ByDay {
+		if wd.N == 0 {
+			continue
+		}
+		switch {
+		case r.Freq == RecurrenceMonthly && (wd.N < -5 || wd.N > 5):
+			return fmt.Errorf("BYDAY ordinal %d out of range for FREQ=MONTHLY", wd.N)
+		case r.Freq == RecurrenceYearly && len(r.ByMonth) > 0 && (wd.N < -5 || wd.N > 5):
+			return fmt.Errorf("BYDAY ordinal %d out of range with BYMONTH", wd.N)
+		case r.Freq == RecurrenceDaily || r.Freq == RecurrenceWeekly:
+			return fmt.Errorf("BYDAY ordinals are only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		return fmt.Errorf("BYSETPOS requires another BYxxx rule part")
+	}
+	return nil
+}
+
+// String returns the canonical RRULE form, without the "RRULE:" prefix.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	switch r.untilKind {
+	case untilUTC:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405Z"))
+	case untilFloating:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405"))
+	case untilDate:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102"))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth, func(m time.Month) int { return int(m) }))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay, func(d int) int { return d }))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = wd.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos, func(p int) int { return p }))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after the given time. The second
+// result is false when the rule has no further occurrences.
+func (r *RRule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, after, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			found = true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Between returns up to limit occurrences in the half-open range [from, to).
+// A zero to means no upper bound.
+func (r *RRule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	out := make([]time.Time, 0)
+	if limit <= 0 {
+		return out
+	}
+	r.iterate(dtstart, from, func(t time.Time) bool {
+		if !to.IsZero() && !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return len(out) < limit
+	})
+	return out
+}
+
+// iterate calls fn for each occurrence in order until fn returns false or the
+// rule is exhausted. hint allows skipping whole periods before that time when
+// COUNT does not require counting from the start.
+func (r *RRule) iterate(dtstart, hint time.Time, fn func(time.Time) bool) {
+	loc := dtstart.Location()
+	count := 0
+	empty := 0
+
+	for k := r.firstPeriod(dtstart, hint); empty < maxEmptyRecurrencePeriods; k++ {
+		candidates := applySetPos(r.expand(dtstart, k), r.BySetPos)
+		if len(candidates) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.pastUntil(t, loc) {
+				return
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+		}
+	}
+}
+
+// firstPeriod picks the period index to start scanning from. With COUNT every
+// period from DTSTART has to be visited; otherwise it jumps close to hint.
+func (r *RRule) firstPeriod(dtstart, hint time.Time) int {
+	if r.Count > 0 || !hint.After(dtstart) {
+		return 0
+	}
+	hint = hint.In(dtstart.Location())
+
+	var elapsed int
+	switch r.Freq {
+	case RecurrenceDaily:
+		elapsed = daysBetween(dtstart, hint)
+	case RecurrenceWeekly:
+		elapsed = daysBetween(r.weekStart(dtstart), hint) / 7
+	case RecurrenceMonthly:
+		elapsed = (hint.Year()-dtstart.Year())*12 + int(hint.Month()-dtstart.Month())
+	case RecurrenceYearly:
+		elapsed = hint.Year() - dtstart.Year()
+	}
+
+	// Step back one period so occurrences that spill over are not missed.
+	k := elapsed/r.Interval - 1
+	if k < 0 {
+		return 0
+	}
+	return k
+}
+
+// expand returns the sorted candidate occurrences in period k.
+func (r *RRule) expand(dtstart time.Time, k int) []time.Time {
+	loc := dtstart.Location()
+	hh, mm, ss := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, 0, loc)
+	}
+
+	var out []time.Time
+	switch r.Freq {
+	case RecurrenceDaily:
+		day := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()+k*r.Interval, 0, 0, 0, 0, loc)
+		if r.matchesMonth(day.Month()) && r.matchesMonthDay(day) && r.matchesWeekday(day.Weekday()) {
+			out = append(out, at(day.Year(), day.Month(), day.Day()))
+		}
+
+	case RecurrenceWeekly:
+		start := r.weekStart(dtstart)
+		start = time.Date(start.Year(), start.Month(), start.Day()+7*k*r.Interval, 0, 0, 0, 0, loc)
+		for i := 0; i < 7; i++ {
+			day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, loc)
+			if !r.matchesMonth(day.Month()) {
+				continue
+			}
+			if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			if len(r.ByDay) > 0 && !r.matchesWeekday(day.Weekday()) {
+				continue
+			}
+			out = append(out, at(day.Year(), day.Month(), day.Day()))
+		}
+
+	case RecurrenceMonthly:
+		month := time.Date(dtstart.Year(), dtstart.Month()+time.Month(k*r.Interval), 1, 0, 0, 0, 0, loc)
+		if r.matchesMonth(month.Month()) {
+			for _, d := range r.expandMonth(month.Year(), month.Month(), dtstart.Day(), loc) {
+				out = append(out, at(month.Year(), month.Month(), d))
+			}
+		}
+
+	case RecurrenceYearly:
+		year := dtstart.Year() + k*r.Interval
+		months := r.ByMonth
+		switch {
+		case len(months) > 0:
+		case len(r.ByMonthDay) > 0:
+			months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		case len(r.ByDay) > 0:
+			for _, yd := range r.expandYearByDay(year, loc) {
+				out = append(out, at(year, 1, yd))
+			}
+		default:
+			months = []time.Month{dtstart.Month()}
+		}
+		for _, m := range months {
+			for _, d := range r.expandMonth(year, m, dtstart.Day(), loc) {
+				out = append(out, at(year, m, d))
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return dedupeTimes(out)
+}
+
+// expandMonth returns the matching days of a month, in ascending order.
+func (r *RRule) expandMonth(year int, month time.Month, defaultDay int, loc *time.Location) []int {
+	n := daysIn(year, month, loc)
+
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if defaultDay <= n {
+			return []int{defaultDay}
+		}
+		return nil
+	}
+
+	var days []int
+	for d := 1; d <= n; d++ {
+		day := time.Date(year, month, d, 0, 0, 0, 0, loc)
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(day) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !r.matchesWeekdayInScope(day.Weekday(), (d-1)/7+1, (n-d)/7+1) {
+			continue
+		}
+		days = append(days, d)
+	}
+	return days
+}
+
+// expandYearByDay handles YEARLY rules with BYDAY but no BYMONTH or
+// BYMONTHDAY, where ordinals count weeks within the year. It returns
+// day-of-year numbers.
+func (r *RRule) expandYearByDay(year int, loc *time.Location) []int {
+	n := daysIn(year, 12, loc) + time.Date(year, 12, 1, 0, 0, 0, 0, loc).YearDay() - 1
+	var days []int
+	for yd := 1; yd <= n; yd++ {
+		day := time.Date(year, 1, yd, 0, 0, 0, 0, loc)
+		if r.matchesWeekdayInScope(day.Weekday(), (yd-1)/7+1, (n-yd)/7+1) {
+			days = append(days, yd)
+		}
+	}
+	return days
+}
+
+func (r *RRule) matchesMonth(m time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, bm := range r.ByMonth {
+		if bm == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	n := daysIn(day.Year(), day.Month(), day.Location())
+	for _, md := range r.ByMonthDay {
+		if md == day.Day() || (md < 0 && n+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesWeekday(wd time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, bd := range r.ByDay {
+		if bd.Day == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekdayInScope checks BYDAY with ordinals, where nth is the 1-based
+// occurrence of the weekday from the start of the scope and nthFromEnd from
+// its end.
+func (r *RRule) matchesWeekdayInScope(wd time.Weekday, nth, nthFromEnd int) bool {
+	for _, bd := range r.ByDay {
+		if bd.Day != wd {
+			continue
+		}
+		if bd.N == 0 || bd.N == nth || bd.N == -nthFromEnd {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(r.WeekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+func (r *RRule) pastUntil(t time.Time, loc *time.Location) bool {
+	switch r.untilKind {
+	case untilUTC:
+		return t.After(r.until)
+	case untilFloating:
+		u := r.until
+		return t.After(time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc))
+	case untilDate:
+		u := r.until
+		return !t.Before(time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc))
+	}
+	return false
+}
+
+func (r *RRule) parseUntil(value string) error {
+	layouts := []struct {
+		layout string
+		kind   untilKind
+	}{
+		{"20060102T150405Z", untilUTC},
+		{"20060102T150405", untilFloating},
+		{"20060102", untilDate},
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l.layout, value); err == nil {
+			r.until = t
+			r.untilKind = l.kind
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid UNTIL %s", value)
+}
+
+func applySetPos(candidates []time.Time, setPos []int) []time.Time {
+	if len(setPos) == 0 {
+		return candidates
+	}
+	n := len(candidates)
+	var out []time.Time
+	for _, p := range setPos {
+		idx := p - 1
+		if p < 0 {
+			idx = n + p
+		}
+		if idx >= 0 && idx < n {
+			out = append(out, candidates[idx])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return dedupeTimes(out)
+}
+
+func dedupeTimes(ts []time.Time) []time.Time {
+	if len(ts) < 2 {
+		return ts
+	}
+	out := ts[:1]
+	for _, t := range ts[1:] {
+		if !t.Equal(out[len(out)-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func daysIn(year int, month time.Month, loc *time.Location) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+// daysBetween counts calendar days from a to b, ignoring the time of day.
+func daysBetween(a, b time.Time) int {
+	ad := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	bd := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bd.Sub(ad).Hours() / 24)
+}
+
+func eachValue(value string, fn func(string) error) error {
+	for _, v := range strings.Split(value, ",") {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRangedInt(v string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("invalid value %q, expected %d..%d", v, lo, hi)
+	}
+	return n, nil
+}
+
+func parseSignedRangedInt(v string, max int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n == 0 || n < -max || n > max {
+		return 0, fmt.Errorf("invalid value %q, expected ±1..%d", v, max)
+	}
+	return n, nil
+}
+
+func parseWeekdayNum(v string) (WeekdayNum, error) {
+	if len(v) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", v)
+	}
+	day, ok := weekdayCodes[v[len(v)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", v)
+	}
+	wd := WeekdayNum{Day: day}
+	if prefix := v[:len(v)-2]; prefix != "" {
+		n, err := parseSignedRangedInt(strings.TrimPrefix(prefix, "+"), 53)
+		if err != nil {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", v)
+		}
+		wd.N = n
+	}
+	return wd, nil
+}
+
+func joinInts[T any](values []T, toInt func(T) int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(toInt(v))
+	}
+	return strings.Join(parts, ",")
+}
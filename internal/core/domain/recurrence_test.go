@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d, hh, mm int) time.Time {
+	return time.Date(y, m, d, hh, mm, 0, 0, time.UTC)
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		canonical string
+		wantErr   bool
+	}{
+		{"simple", "FREQ=DAILY", "FREQ=DAILY", false},
+		{"prefix and lowercase", "rrule:freq=weekly;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE", false},
+		{"canonical ordering", "BYDAY=1MO;FREQ=MONTHLY;INTERVAL=2", "FREQ=MONTHLY;INTERVAL=2;BYDAY=1MO", false},
+		{"interval of one is implied", "FREQ=MONTHLY;INTERVAL=1", "FREQ=MONTHLY", false},
+		{"setpos", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", false},
+		{"until utc", "FREQ=DAILY;UNTIL=20250101T000000Z", "FREQ=DAILY;UNTIL=20250101T000000Z", false},
+		{"until date", "FREQ=DAILY;UNTIL=20250101", "FREQ=DAILY;UNTIL=20250101", false},
+		{"plus ordinal", "FREQ=MONTHLY;BYDAY=+2TU", "FREQ=MONTHLY;BYDAY=2TU", false},
+		{"wkst", "FREQ=WEEKLY;WKST=SU", "FREQ=WEEKLY;WKST=SU", false},
+		{"empty", "", "", true},
+		{"missing freq", "INTERVAL=2", "", true},
+		{"hourly unsupported", "FREQ=HOURLY", "", true},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0", "", true},
+		{"count and until", "FREQ=DAILY;COUNT=2;UNTIL=20250101", "", true},
+		{"bad weekday", "FREQ=WEEKLY;BYDAY=XX", "", true},
+		{"ordinal on weekly", "FREQ=WEEKLY;BYDAY=1MO", "", true},
+		{"monthday zero", "FREQ=MONTHLY;BYMONTHDAY=0", "", true},
+		{"monthday on weekly", "FREQ=WEEKLY;BYMONTHDAY=1", "", true},
+		{"setpos alone", "FREQ=MONTHLY;BYSETPOS=1", "", true},
+		{"unknown part", "FREQ=DAILY;BYHOUR=9", "", true},
+		{"duplicate part", "FREQ=DAILY;FREQ=WEEKLY", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, r.String())
+		})
+	}
+}
+
+func TestRRuleBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		from     time.Time
+		limit    int
+		expected []time.Time
+	}{
+		{
+			name:    "every 3 months",
+			rule:    "FREQ=MONTHLY;INTERVAL=3",
+			dtstart: date(2025, 1, 15, 8, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2025, 1, 15, 8, 0), date(2025, 4, 15, 8, 0), date(2025, 7, 15, 8, 0),
+			},
+		},
+		{
+			name:    "first monday of each month",
+			rule:    "FREQ=MONTHLY;BYDAY=1MO",
+			dtstart: date(2025, 1, 1, 9, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2025, 1, 6, 9, 0), date(2025, 2, 3, 9, 0), date(2025, 3, 3, 9, 0),
+			},
+		},
+		{
+			name:    "weekdays only",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: date(2025, 3, 7, 7, 30), // Friday
+			from:    date(2025, 3, 7, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2025, 3, 7, 7, 30), date(2025, 3, 10, 7, 30), date(2025, 3, 11, 7, 30),
+			},
+		},
+		{
+			name:    "last weekday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: date(2025, 1, 1, 17, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2025, 1, 31, 17, 0), date(2025, 2, 28, 17, 0), date(2025, 3, 31, 17, 0),
+			},
+		},
+		{
+			name:    "last day of the month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: date(2024, 1, 1, 0, 0),
+			from:    date(2024, 1, 1, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2024, 1, 31, 0, 0), date(2024, 2, 29, 0, 0), date(2024, 3, 31, 0, 0),
+			},
+		},
+		{
+			name:    "monthly on the 31st skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2025, 1, 31, 0, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			limit:   3,
+			expected: []time.Time{
+				date(2025, 1, 31, 0, 0), date(2025, 3, 31, 0, 0), date(2025, 5, 31, 0, 0),
+			},
+		},
+		{
+			name:    "count limits total occurrences",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: date(2025, 1, 1, 0, 0),
+			from:    date(2025, 1, 2, 0, 0),
+			limit:   10,
+			expected: []time.Time{
+				date(2025, 1, 2, 0, 0), date(2025, 1, 3, 0, 0),
+			},
+		},
+		{
+			name:    "until date is inclusive",
+			rule:    "FREQ=WEEKLY;UNTIL=20250115",
+			dtstart: date(2025, 1, 1, 10, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			limit:   10,
+			expected: []time.Time{
+				date(2025, 1, 1, 10, 0), date(2025, 1, 8, 10, 0), date(2025, 1, 15, 10, 0),
+			},
+		},
+		{
+			name:    "yearly by month and weekday",
+			rule:    "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart: date(2024, 1, 1, 12, 0),
+			from:    date(2024, 1, 1, 0, 0),
+			limit:   2,
+			expected: []time.Time{
+				date(2024, 11, 28, 12, 0), date(2025, 11, 27, 12, 0),
+			},
+		},
+		{
+			name:    "yearly week ordinal without month",
+			rule:    "FREQ=YEARLY;BYDAY=-1SU",
+			dtstart: date(2024, 1, 1, 0, 0),
+			from:    date(2024, 1, 1, 0, 0),
+			limit:   2,
+			expected: []time.Time{
+				date(2024, 12, 29, 0, 0), date(2025, 12, 28, 0, 0),
+			},
+		},
+		{
+			name:    "skips ahead to from",
+			rule:    "FREQ=DAILY;INTERVAL=2",
+			dtstart: date(2000, 1, 1, 6, 0),
+			from:    date(2025, 6, 1, 0, 0),
+			limit:   2,
+			expected: []time.Time{
+				date(2025, 6, 2, 6, 0), date(2025, 6, 4, 6, 0),
+			},
+		},
+		{
+			name:     "impossible rule terminates",
+			rule:     "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart:  date(2025, 1, 1, 0, 0),
+			from:     date(2025, 1, 1, 0, 0),
+			limit:    1,
+			expected: []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.Between(tt.dtstart, tt.from, time.Time{}, tt.limit))
+		})
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	r, err := ParseRRule("FREQ=DAILY;COUNT=2")
+	require.NoError(t, err)
+
+	start := date(2025, 1, 1, 8, 0)
+
+	next, ok := r.Next(start, date(2025, 1, 1, 8, 0))
+	assert.True(t, ok)
+	assert.Equal(t, date(2025, 1, 2, 8, 0), next)
+
+	_, ok = r.Next(start, date(2025, 1, 2, 8, 0))
+	assert.False(t, ok)
+}
+
+func TestFrequencyRRule(t *testing.T) {
+	assert.Equal(t, "FREQ=WEEKLY", FrequencyWeekly.RRule())
+	assert.Equal(t, FrequencyMonthly, FrequencyFromRRule("FREQ=MONTHLY"))
+	assert.Equal(t, FrequencyCustom, FrequencyFromRRule("FREQ=MONTHLY;BYDAY=1MO"))
+}
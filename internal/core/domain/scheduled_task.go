@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,21 +14,73 @@ const (
 	FrequencyWeekly  Frequency = "weekly"
 	FrequencyMonthly Frequency = "monthly"
 	FrequencyYearly  Frequency = "yearly"
+	// FrequencyCustom is reported for rules that are not a plain FREQ.
+	FrequencyCustom Frequency = "custom"
 )
 
+// RRule returns the recurrence rule equivalent to a simple frequency.
+func (f Frequency) RRule() string {
+	return "FREQ=" + strings.ToUpper(string(f))
+}
+
+// FrequencyFromRRule reports the simple frequency a canonical rule is
+// equivalent to, or FrequencyCustom if there is none.
+func FrequencyFromRRule(rule string) Frequency {
+	switch f := Frequency(strings.ToLower(strings.TrimPrefix(rule, "FREQ="))); f {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		return f
+	default:
+		return FrequencyCustom
+	}
+}
+
 type ScheduledTask struct {
 	ID             uuid.UUID  `json:"id"`
 	OrganizationID uuid.UUID  `json:"organization_id"`
 	Title          string     `json:"title"`
 	Description    string     `json:"description"`
-	Frequency      Frequency  `json:"frequency"`
+	RRule          string     `json:"rrule"`
+	Frequency      Frequency  `json:"frequency"` // derived from RRule
 	StartDate      time.Time  `json:"start_date"`
 	NextRunAt      time.Time  `json:"next_run_at"`
 	CreatedBy      uuid.UUID  `json:"created_by"`
 	AssigneeUserID *uuid.UUID `json:"assignee_user_id"`
 	PriorityID     string     `json:"priority_id"`
+	CategoryID     *string    `json:"category_id"`
 	Location       string     `json:"location"`
 	Enabled        bool       `json:"enabled"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Timezone is the owning organization's IANA zone. The rule is evaluated
+	// against StartDate's wall-clock time in this zone.
+	Timezone string `json:"timezone"`
+}
+
+// ScheduledTaskOccurrences is a preview of when a task will fire. Times are
+// expressed in Timezone.
+type ScheduledTaskOccurrences struct {
+	Timezone    string      `json:"timezone"`
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// Scheduled Task Run Outcomes
+const (
+	ScheduledTaskRunOutcomeCreated = "ticket_created"
+	ScheduledTaskRunOutcomeFailed  = "failed"
+)
+
+// ScheduledTaskRun records one occurrence of a scheduled task firing.
+type ScheduledTaskRun struct {
+	ID              uuid.UUID  `json:"id"`
+	ScheduledTaskID uuid.UUID  `json:"scheduled_task_id"`
+	RunAt           time.Time  `json:"run_at"`
+	TicketID        *uuid.UUID `json:"ticket_id"`
+	Outcome         string     `json:"outcome"`
+	Error           string     `json:"error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Current state of the generated ticket, joined when listing runs.
+	TicketStatusID    *string    `json:"ticket_status_id"`
+	TicketCompletedAt *time.Time `json:"ticket_completed_at"`
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SMSMessage is a rendered text message ready to hand to a port.SMSSender.
+type SMSMessage struct {
+	// To is an E.164 phone number.
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// NormalizePhone strips the spaces, dashes, dots and parentheses people type
+// into phone numbers and checks that what is left is an E.164 number.
+func NormalizePhone(phone string) (string, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, phone)
+	if !e164Pattern.MatchString(normalized) {
+		return "", fmt.Errorf("phone number %q must be in international format, e.g. +15551234567", phone)
+	}
+	return normalized, nil
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/google/uuid"
 )
 
-// Ticket Priorities
+// Default Ticket Priorities, seeded into every organization. Organizations
+// may rename, re-level or remove them and add their own.
 const (
 	TicketPriorityLow      = "low"
 	TicketPriorityMedium   = "medium"
@@ -14,7 +15,8 @@ const (
 	TicketPriorityCritical = "critical"
 )
 
-// Ticket Statuses
+// Default Ticket Statuses, seeded into every organization. Organizations may
+// rename, reorder or remove them and add their own.
 const (
 	TicketStatusNew        = "new"
 	TicketStatusInProgress = "in_progress"
@@ -32,38 +34,13 @@ type Ticket struct {
 	Location       string     `json:"location"`
 	StatusID       string     `json:"status_id"`
 	PriorityID     string     `json:"priority_id"`
+	CategoryID     *string    `json:"category_id"`
 	ReporterID     uuid.UUID  `json:"reporter_id"`
 	AssigneeUserID *uuid.UUID `json:"assignee_user_id"`
 	Sensitive      bool       `json:"sensitive"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	CompletedAt    *time.Time `json:"completed_at"`
-}
-
-// TicketStatus represents a valid status for a ticket.
-type TicketStatus struct {
-	ID    string
-	Label string
-	Level int
-}
-
-// TicketPriority represents a valid priority for a ticket.
-type TicketPriority struct {
-	ID    string
-	Label string
-	Level int
-}
-
-// IsFinishedStatus checks if the given status represents a finished state.
-func IsFinishedStatus(status string) bool {
-	return status == TicketStatusDone || status == TicketStatusCanceled
-}
-
-// GetActiveStatusIDs returns the list of status IDs that are considered active.
-func GetActiveStatusIDs() []string {
-	return []string{
-		TicketStatusNew,
-		TicketStatusInProgress,
-		TicketStatusOnHold,
-	}
+	// ScheduledTaskID is set when the ticket was generated by a recurring task.
+	ScheduledTaskID *uuid.UUID `json:"scheduled_task_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// TicketCategory groups an organization's tickets by the kind of work, such
+// as Plumbing or Electrical.
+type TicketCategory struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	ID             string    `json:"id"`
+	Label          string    `json:"label"`
+	Description    string    `json:"description"`
+}
+
+// Validate checks the fields an organization can set on a category.
+func (c TicketCategory) Validate() error {
+	return validateLookup(c.ID, c.Label, "")
+}
+
+// TicketCategories is an organization's category set ordered by label.
+type TicketCategories []TicketCategory
+
+// Find returns the category with the given ID, or nil.
+func (cs TicketCategories) Find(id string) *TicketCategory {
+	for i := range cs {
+		if cs[i].ID == id {
+			return &cs[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ticket Event Types
+const (
+	TicketEventFieldChanged = "field_changed"
+)
+
+// Ticket fields recorded in the audit trail.
+const (
+	TicketFieldStatus      = "status"
+	TicketFieldPriority    = "priority"
+	TicketFieldCategory    = "category"
+	TicketFieldAssignee    = "assignee"
+	TicketFieldTitle       = "title"
+	TicketFieldDescription = "description"
+	TicketFieldLocation    = "location"
+	TicketFieldSensitive   = "sensitive"
+)
+
+// TicketEvent is an immutable audit record of a change to a ticket. OldValue
+// and NewValue hold the JSON encoding of the field before and after.
+type TicketEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	TicketID  uuid.UUID       `json:"ticket_id"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	ActorName *string         `json:"actor_name"`
+	EventType string          `json:"event_type"`
+	Field     string          `json:"field"`
+	OldValue  json.RawMessage `json:"old_value"`
+	NewValue  json.RawMessage `json:"new_value"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// TicketPriority is a priority defined by an organization. Level orders
+// priorities from least to most urgent, and Description carries guidance
+// such as the expected response time.
+type TicketPriority struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	ID             string    `json:"id"`
+	Label          string    `json:"label"`
+	Level          int       `json:"level"`
+	Description    string    `json:"description"`
+	Color          string    `json:"color"`
+}
+
+// Validate checks the fields an organization can set on a priority.
+func (p TicketPriority) Validate() error {
+	return validateLookup(p.ID, p.Label, p.Color)
+}
+
+// TicketPriorities is an organization's priority set ordered by level.
+type TicketPriorities []TicketPriority
+
+// Find returns the priority with the given ID, or nil.
+func (ps TicketPriorities) Find(id string) *TicketPriority {
+	for i := range ps {
+		if ps[i].ID == id {
+			return &ps[i]
+		}
+	}
+	return nil
+}
+
+// Lowest returns the least urgent priority, or nil if there are none.
+func (ps TicketPriorities) Lowest() *TicketPriority {
+	if len(ps) == 0 {
+		return nil
+	}
+	return &ps[0]
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// TicketStatusState is the system state a status maps to. It decides whether
+// tickets in the status count as active and whether they are completed.
+type TicketStatusState string
+
+const (
+	TicketStatusStateOpen     TicketStatusState = "open"
+	TicketStatusStateResolved TicketStatusState = "resolved"
+)
+
+// IsValid reports whether s is a known state.
+func (s TicketStatusState) IsValid() bool {
+	return s == TicketStatusStateOpen || s == TicketStatusStateResolved
+}
+
+// TicketStatus is a status an organization's tickets can be in. IDs are
+// unique within the organization.
+type TicketStatus struct {
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	ID             string            `json:"id"`
+	Label          string            `json:"label"`
+	Position       int               `json:"position"`
+	Color          string            `json:"color"`
+	State          TicketStatusState `json:"state"`
+}
+
+// IsResolved reports whether tickets in this status are finished.
+func (s TicketStatus) IsResolved() bool {
+	return s.State == TicketStatusStateResolved
+}
+
+// TicketStatuses is an organization's status set ordered by position.
+type TicketStatuses []TicketStatus
+
+// Find returns the status with the given ID, or nil.
+func (ss TicketStatuses) Find(id string) *TicketStatus {
+	for i := range ss {
+		if ss[i].ID == id {
+			return &ss[i]
+		}
+	}
+	return nil
+}
+
+// Initial returns the status new tickets start in: the first open status.
+func (ss TicketStatuses) Initial() *TicketStatus {
+	for i := range ss {
+		if ss[i].State == TicketStatusStateOpen {
+			return &ss[i]
+		}
+	}
+	return nil
+}
+
+// CountState returns how many statuses map to state.
+func (ss TicketStatuses) CountState(state TicketStatusState) int {
+	n := 0
+	for _, s := range ss {
+		if s.State == state {
+			n++
+		}
+	}
+	return n
+}
+
+// Validate checks the fields an organization can set on a status.
+func (s TicketStatus) Validate() error {
+	if err := validateLookup(s.ID, s.Label, s.Color); err != nil {
+		return err
+	}
+	if !s.State.IsValid() {
+		return fmt.Errorf("state must be %q or %q", TicketStatusStateOpen, TicketStatusStateResolved)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Timeline Entry Types
+const (
+	TimelineEntryCreated       = "created"
+	TimelineEntryScheduledTask = "scheduled_task"
+	TimelineEntryComment       = "comment"
+	TimelineEntryFile          = "file"
+	TimelineEntryEvent         = "event"
+)
+
+// TimelineActor is the user behind a timeline entry.
+type TimelineActor struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	AvatarURL string    `json:"avatar_url"`
+}
+
+// TimelineScheduledTask identifies the recurring task that generated a ticket.
+type TimelineScheduledTask struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+}
+
+// TimelineEntry is one item in a ticket's activity stream. Type says which of
+// the payload fields is set.
+type TimelineEntry struct {
+	Type          string                 `json:"type"`
+	ID            uuid.UUID              `json:"id"`
+	CreatedAt     time.Time              `json:"created_at"`
+	Actor         *TimelineActor         `json:"actor"`
+	Comment       *Comment               `json:"comment,omitempty"`
+	File          *File                  `json:"file,omitempty"`
+	Event         *TicketEvent           `json:"event,omitempty"`
+	ScheduledTask *TimelineScheduledTask `json:"scheduled_task,omitempty"`
+}
+
+// TimelinePage is a page of timeline entries in chronological order.
+// NextCursor is empty on the last page.
+type TimelinePage struct {
+	Entries    []TimelineEntry `json:"entries"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
@@ -23,6 +23,9 @@ type User struct {
 	Name      string    `json:"name"`
 	Role      Role      `json:"role"`
 	AvatarURL string    `json:"avatar_url"`
+	// Phone is an E.164 number for SMS notifications. Like Email, it must
+	// never appear in a public response (NFR-06).
+	Phone     string    `json:"phone,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookFormat is the body an endpoint expects.
+type WebhookFormat string
+
+const (
+	// WebhookFormatJSON sends the versioned WebhookPayload.
+	WebhookFormatJSON WebhookFormat = "json"
+	// WebhookFormatSlack sends a Block Kit message to a Slack-compatible
+	// incoming webhook.
+	WebhookFormatSlack WebhookFormat = "slack"
+	// WebhookFormatMatrix sends a text and HTML message to a Matrix
+	// incoming webhook bridge such as matrix-hookshot.
+	WebhookFormatMatrix WebhookFormat = "matrix"
+)
+
+func (f WebhookFormat) Valid() bool {
+	switch f {
+	case WebhookFormatJSON, WebhookFormatSlack, WebhookFormatMatrix:
+		return true
+	}
+	return false
+}
+
+// WebhookEndpoint is a URL an organization has subscribed to ticket events.
+// Internal endpoints belong to the organization's own systems and receive
+// sensitive content; all others receive it redacted. PriorityIDs and
+// CategoryIDs narrow the tickets an endpoint hears about; empty means all.
+type WebhookEndpoint struct {
+	ID             uuid.UUID           `json:"id"`
+	OrganizationID uuid.UUID           `json:"organization_id"`
+	URL            string              `json:"url"`
+	Description    string              `json:"description"`
+	Format         WebhookFormat       `json:"format"`
+	Events         []NotificationEvent `json:"events"`
+	PriorityIDs    []string            `json:"priority_ids"`
+	CategoryIDs    []string            `json:"category_ids"`
+	Internal       bool                `json:"internal"`
+	Enabled        bool                `json:"enabled"`
+	// Secret signs every payload. It is only returned when the endpoint is
+	// created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribes reports whether the endpoint wants event.
+func (e WebhookEndpoint) Subscribes(event NotificationEvent) bool {
+	for _, ev := range e.Events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the endpoint wants event for t. A category filter
+// excludes uncategorized tickets.
+func (e WebhookEndpoint) Matches(event NotificationEvent, t *Ticket) bool {
+	if !e.Subscribes(event) {
+		return false
+	}
+	if len(e.PriorityIDs) > 0 && !containsString(e.PriorityIDs, t.PriorityID) {
+		return false
+	}
+	if len(e.CategoryIDs) > 0 && (t.CategoryID == nil || !containsString(e.CategoryIDs, *t.CategoryID)) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one endpoint.
+// ResponseCode and ResponseBody come from the latest attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	EndpointID     uuid.UUID             `json:"endpoint_id"`
+	OrganizationID uuid.UUID             `json:"organization_id"`
+	Event          NotificationEvent     `json:"event"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseCode   *int                  `json:"response_code"`
+	ResponseBody   string                `json:"response_body"`
+	Error          string                `json:"error"`
+	CreatedAt      time.Time             `json:"created_at"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at"`
+}
+
+// WebhookDeliveryPage is one page of an endpoint's delivery log, newest first.
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// WebhookPayloadVersion is bumped whenever WebhookPayload changes
+// incompatibly.
+const WebhookPayloadVersion = 1
+
+// WebhookPayload is the JSON body POSTed to endpoints. ID identifies the
+// event and stays the same when a delivery is redelivered.
+type WebhookPayload struct {
+	Version        int               `json:"version"`
+	ID             uuid.UUID         `json:"id"`
+	Event          NotificationEvent `json:"event"`
+	OccurredAt     time.Time         `json:"occurred_at"`
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	Data           WebhookEventData  `json:"data"`
+}
+
+// WebhookEventData describes what happened. Labels are the organization's
+// display names at the time of the event.
+type WebhookEventData struct {
+	Ticket         WebhookTicket   `json:"ticket"`
+	Comment        *WebhookComment `json:"comment,omitempty"`
+	ActorID        *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorName      string          `json:"actor_name,omitempty"`
+	OldStatusID    string          `json:"old_status_id,omitempty"`
+	OldStatusLabel string          `json:"old_status_label,omitempty"`
+	NewStatusID    string          `json:"new_status_id,omitempty"`
+	NewStatusLabel string          `json:"new_status_label,omitempty"`
+}
+
+// Redact strips sensitive content for endpoints that are not internal. A
+// sensitive ticket keeps only its identifiers, workflow state and
+// timestamps, and who acted on it is dropped; comments lose their author and
+// body when they or their ticket are sensitive.
+func (d *WebhookEventData) Redact() {
+	if d.Ticket.Sensitive {
+		d.Ticket = WebhookTicket{
+			ID:            d.Ticket.ID,
+			StatusID:      d.Ticket.StatusID,
+			StatusLabel:   d.Ticket.StatusLabel,
+			PriorityID:    d.Ticket.PriorityID,
+			PriorityLabel: d.Ticket.PriorityLabel,
+			CategoryID:    d.Ticket.CategoryID,
+			CategoryLabel: d.Ticket.CategoryLabel,
+			Sensitive:     true,
+			Redacted:      true,
+			CreatedAt:     d.Ticket.CreatedAt,
+			UpdatedAt:     d.Ticket.UpdatedAt,
+			CompletedAt:   d.Ticket.CompletedAt,
+		}
+		d.ActorID = nil
+		d.ActorName = ""
+	}
+	if c := d.Comment; c != nil && (c.Sensitive || d.Ticket.Sensitive) {
+		d.Comment = &WebhookComment{
+			ID:        c.ID,
+			Sensitive: c.Sensitive,
+			Redacted:  true,
+			CreatedAt: c.CreatedAt,
+		}
+	}
+}
+
+// WebhookTicket is a ticket as sent to endpoints.
+type WebhookTicket struct {
+	ID             uuid.UUID  `json:"id"`
+	Title          string     `json:"title,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Location       string     `json:"location,omitempty"`
+	StatusID       string     `json:"status_id"`
+	StatusLabel    string     `json:"status_label,omitempty"`
+	PriorityID     string     `json:"priority_id"`
+	PriorityLabel  string     `json:"priority_label,omitempty"`
+	CategoryID     *string    `json:"category_id"`
+	CategoryLabel  string     `json:"category_label,omitempty"`
+	ReporterID     *uuid.UUID `json:"reporter_id,omitempty"`
+	AssigneeUserID *uuid.UUID `json:"assignee_user_id,omitempty"`
+	Sensitive      bool       `json:"sensitive"`
+	Redacted       bool       `json:"redacted"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+func NewWebhookTicket(t *Ticket) WebhookTicket {
+	reporterID := t.ReporterID
+	return WebhookTicket{
+		ID:             t.ID,
+		Title:          t.Title,
+		Description:    t.Description,
+		Location:       t.Location,
+		StatusID:       t.StatusID,
+		PriorityID:     t.PriorityID,
+		CategoryID:     t.CategoryID,
+		ReporterID:     &reporterID,
+		AssigneeUserID: t.AssigneeUserID,
+		Sensitive:      t.Sensitive,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+		CompletedAt:    t.CompletedAt,
+	}
+}
+
+// WebhookComment is a comment as sent to endpoints.
+type WebhookComment struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Body      string     `json:"body,omitempty"`
+	Sensitive bool       `json:"sensitive"`
+	Redacted  bool       `json:"redacted"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewWebhookComment(c *Comment) *WebhookComment {
+	userID := c.UserID
+	return &WebhookComment{
+		ID:        c.ID,
+		UserID:    &userID,
+		Body:      c.Body,
+		Sensitive: c.Sensitive,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// APIKeyRepository stores API keys by the hash of their token.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey, tokenHash []byte) error
+	// GetByTokenHash returns nil if no key has the hash.
+	GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.APIKey, error)
+	// ListPersonal returns the user's personal access tokens, newest first.
+	ListPersonal(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error)
+	// ListByOrganization returns the organization's service keys, newest
+	// first.
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.APIKey, error)
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+	// DeletePersonal reports whether the user had the personal access token.
+	DeletePersonal(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	// DeleteForOrganization reports whether the organization had the key.
+	DeleteForOrganization(ctx context.Context, orgID, id uuid.UUID) (bool, error)
+}
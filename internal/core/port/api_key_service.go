@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrAPIKeyNotFound is returned when a user or organization has no such
+	// key.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrInvalidAPIKey wraps validation failures on new keys.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// CreateAPIKeyCmd defines the command to issue an API key. A nil
+// OrganizationID issues a personal access token for UserID; otherwise a
+// service key for the organization that acts as UserID.
+type CreateAPIKeyCmd struct {
+	UserID         uuid.UUID
+	OrganizationID *uuid.UUID
+	Name           string
+	Scopes         []domain.APIKeyScope
+}
+
+// APIKeyService issues, authenticates and revokes API keys.
+type APIKeyService interface {
+	// Create returns the token, which is not stored and cannot be shown
+	// again, along with the key.
+	Create(ctx context.Context, cmd CreateAPIKeyCmd) (string, *domain.APIKey, error)
+	// Authenticate returns the key and the user it acts as, and records
+	// that the key was used. It returns nil without error for unknown
+	// tokens.
+	Authenticate(ctx context.Context, token string) (*domain.User, *domain.APIKey, error)
+	ListPersonal(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.APIKey, error)
+	RevokePersonal(ctx context.Context, userID, id uuid.UUID) error
+	RevokeForOrganization(ctx context.Context, orgID, id uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// AuditFilter defines criteria for listing audit entries. Results are ordered
+// newest first; when BeforeCreatedAt is set only entries strictly older than
+// (BeforeCreatedAt, BeforeID) are returned.
+type AuditFilter struct {
+	OrganizationID  uuid.UUID
+	Action          string
+	ActorID         *uuid.UUID
+	TargetID        *uuid.UUID
+	From            *time.Time
+	To              *time.Time
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	Limit           int
+}
+
+// AuditRepository defines the interface for interacting with the
+// organization audit log.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, filter AuditFilter) ([]domain.AuditEntry, error)
+}
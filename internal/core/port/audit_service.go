@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// AuditQuery selects a page of an organization's audit log.
+type AuditQuery struct {
+	Action   string
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	From     *time.Time
+	To       *time.Time
+	Cursor   string
+	Limit    int
+}
+
+// AuditService records and lists organization-level administrative actions.
+type AuditService interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, orgID uuid.UUID, query AuditQuery) (*domain.AuditPage, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// Authorizer decides what users may do within organizations.
+type Authorizer interface {
+	// Can reports whether user may take action on resource. Non-members
+	// may do nothing.
+	Can(ctx context.Context, user *domain.User, action domain.Action, resource domain.Resource) (bool, error)
+}
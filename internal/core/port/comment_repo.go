@@ -10,5 +10,6 @@ import (
 // CommentRepository defines the interface for interacting with comment data.
 type CommentRepository interface {
 	Create(ctx context.Context, comment *domain.Comment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
 	ListByTicket(ctx context.Context, ticketID uuid.UUID, includeSensitive bool) ([]domain.Comment, error)
 }
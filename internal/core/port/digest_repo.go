@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// DigestRepository stores users' digest settings.
+type DigestRepository interface {
+	// Get returns nil if the user never changed their settings.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.DigestSettings, error)
+	Upsert(ctx context.Context, settings *domain.DigestSettings) error
+	// ListEnabled returns the settings of every user who receives digests.
+	ListEnabled(ctx context.Context) ([]domain.DigestSettings, error)
+	// MarkSent records that the digest due at dueAt was sent at sentAt. It
+	// reports false if that digest was already recorded, so only one runner
+	// sends it.
+	MarkSent(ctx context.Context, userID uuid.UUID, dueAt, sentAt time.Time) (bool, error)
+}
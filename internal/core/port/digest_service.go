@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInvalidDigestSettings wraps validation failures on digest settings.
+var ErrInvalidDigestSettings = errors.New("invalid digest settings")
+
+// DigestService manages activity digests.
+type DigestService interface {
+	GetSettings(ctx context.Context, userID uuid.UUID) (*domain.DigestSettings, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, settings domain.DigestSettings) (*domain.DigestSettings, error)
+	// SendDue enqueues the digest of every user whose digest has fallen due
+	// by now and returns how many were sent.
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
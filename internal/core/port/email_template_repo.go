@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// EmailTemplateRepository stores organizations' email template overrides and
+// branding.
+type EmailTemplateRepository interface {
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.EmailTemplate, error)
+	// Get returns the organization's override for event, or nil if it uses
+	// the default.
+	Get(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (*domain.EmailTemplate, error)
+	Upsert(ctx context.Context, tmpl *domain.EmailTemplate) error
+	Delete(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) error
+	// GetBranding returns the organization's branding, empty if never set.
+	GetBranding(ctx context.Context, orgID uuid.UUID) (*domain.EmailBranding, error)
+	UpsertBranding(ctx context.Context, branding *domain.EmailBranding) error
+}
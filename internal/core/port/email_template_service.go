@@ -0,0 +1,52 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInvalidEmailTemplate wraps templates that fail to parse or execute, and
+// invalid branding.
+var ErrInvalidEmailTemplate = errors.New("invalid email template")
+
+// SetEmailTemplateCmd defines the command to override an event's email.
+// HTMLBody may be empty to send plain text only.
+type SetEmailTemplateCmd struct {
+	OrganizationID uuid.UUID
+	Event          domain.NotificationEvent
+	Subject        string
+	TextBody       string
+	HTMLBody       string
+}
+
+// PreviewEmailCmd renders an event's email against a sample ticket. Template
+// parts left empty fall back to the organization's current template. Public
+// previews what someone outside the organization would receive.
+type PreviewEmailCmd struct {
+	OrganizationID uuid.UUID
+	Event          domain.NotificationEvent
+	Subject        string
+	TextBody       string
+	HTMLBody       string
+	Public         bool
+}
+
+// EmailTemplateService manages email templates and branding and renders
+// notification emails.
+type EmailTemplateService interface {
+	// ListTemplates returns the template in effect for every event.
+	ListTemplates(ctx context.Context, orgID uuid.UUID) ([]domain.EmailTemplate, error)
+	SetTemplate(ctx context.Context, cmd SetEmailTemplateCmd) (*domain.EmailTemplate, error)
+	// ResetTemplate removes an override and returns the default template.
+	ResetTemplate(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (*domain.EmailTemplate, error)
+	GetBranding(ctx context.Context, orgID uuid.UUID) (*domain.EmailBranding, error)
+	UpdateBranding(ctx context.Context, branding domain.EmailBranding) (*domain.EmailBranding, error)
+	Preview(ctx context.Context, cmd PreviewEmailCmd) (*domain.EmailMessage, error)
+	// Render renders the organization's email for event. When data.Public is
+	// set, sensitive fields are stripped before any template sees them. The
+	// returned message has no recipient.
+	Render(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent, data domain.EmailTemplateData) (*domain.EmailMessage, error)
+}
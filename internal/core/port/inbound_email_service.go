@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrUnknownMailbox is returned for mail to an address that does not
+	// belong to an organization accepting public submissions.
+	ErrUnknownMailbox = errors.New("unknown mailbox")
+	// ErrSenderRejected is returned when the sender may not submit tickets by
+	// email, such as staff whose address could be spoofed.
+	ErrSenderRejected = errors.New("sender rejected")
+)
+
+// InboundEmailService turns email sent to an organization into tickets.
+type InboundEmailService interface {
+	// Receive opens a ticket for msg, or adds a comment when msg replies to
+	// one of the sender's own tickets.
+	Receive(ctx context.Context, msg domain.InboundEmail) error
+}
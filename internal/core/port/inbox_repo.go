@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// InboxFilter defines criteria for listing a user's inbox. Results are ordered
+// newest first; when BeforeCreatedAt is set only entries strictly older than
+// (BeforeCreatedAt, BeforeID) are returned.
+type InboxFilter struct {
+	UserID          uuid.UUID
+	UnreadOnly      bool
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	Limit           int
+}
+
+// InboxRepository defines the interface for interacting with in-app
+// notifications.
+type InboxRepository interface {
+	Create(ctx context.Context, n *domain.InboxNotification) error
+	List(ctx context.Context, filter InboxFilter) ([]domain.InboxNotification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead marks one of the user's notifications read. It returns
+	// ErrInboxNotificationNotFound if the user has no such notification.
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInboxNotificationNotFound is returned when a notification does not exist
+// in the user's inbox.
+var ErrInboxNotificationNotFound = errors.New("notification not found")
+
+// InboxQuery selects a page of a user's inbox.
+type InboxQuery struct {
+	UnreadOnly bool
+	Cursor     string
+	Limit      int
+}
+
+// InboxService manages users' in-app notification inboxes.
+type InboxService interface {
+	List(ctx context.Context, userID uuid.UUID, query InboxQuery) (*domain.InboxPage, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// LoginTokenRepository stores emailed sign-in links by the hash of their
+// token.
+type LoginTokenRepository interface {
+	// Create stores a link issued to email, which the caller lower-cases.
+	Create(ctx context.Context, email, ipAddress string, tokenHash []byte, createdAt, expiresAt time.Time) error
+	// Consume marks the token used if it is unused and unexpired at now and
+	// returns the email it was issued to. It returns "" otherwise, so a
+	// token can only be consumed once.
+	Consume(ctx context.Context, tokenHash []byte, now time.Time) (string, error)
+	// CountSince counts the links issued to the lower-cased email and
+	// requested from ipAddress since the given time. It first locks both
+	// until the transaction ends, so concurrent requests for either count
+	// one at a time and cannot all pass a limit together.
+	CountSince(ctx context.Context, email, ipAddress string, since time.Time) (byEmail, byIP int, err error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
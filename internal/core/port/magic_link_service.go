@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrInvalidEmail is returned when a sign-in link is requested for
+	// something that is not an email address.
+	ErrInvalidEmail = errors.New("invalid email address")
+	// ErrRateLimited is returned when too many sign-in links were requested
+	// for an email address or from an IP address.
+	ErrRateLimited = errors.New("too many requests")
+)
+
+// MagicLinkService signs users in with single-use links sent by email.
+type MagicLinkService interface {
+	// RequestLink emails a sign-in link to email. It succeeds whether or
+	// not an account exists, so it cannot be used to discover accounts.
+	RequestLink(ctx context.Context, email, ipAddress string) error
+	// Login consumes the link's token and returns its user, provisioning
+	// one for a new email address. It returns nil without error for
+	// invalid, expired or used tokens.
+	Login(ctx context.Context, token string) (*domain.User, error)
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// NotificationPreferenceRepository stores notification preferences at user
+// and organization scope.
+type NotificationPreferenceRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) (domain.NotificationPreferences, error)
+	// ListByUsers returns the preferences of each given user that has any.
+	ListByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error)
+	// ReplaceForUser overwrites all of the user's preferences.
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, prefs domain.NotificationPreferences) error
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.NotificationPreferences, error)
+	// ReplaceForOrganization overwrites all of the organization's defaults.
+	ReplaceForOrganization(ctx context.Context, orgID uuid.UUID, prefs domain.NotificationPreferences) error
+}
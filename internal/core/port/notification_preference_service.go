@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInvalidNotificationPreference wraps validation failures on preference
+// changes.
+var ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+
+// NotificationPreferenceService manages notification preferences and resolves
+// which channel a notification is delivered on.
+type NotificationPreferenceService interface {
+	ListUserPreferences(ctx context.Context, userID uuid.UUID) (domain.NotificationPreferences, error)
+	SetUserPreferences(ctx context.Context, userID uuid.UUID, prefs domain.NotificationPreferences) (domain.NotificationPreferences, error)
+	ListOrganizationPreferences(ctx context.Context, orgID uuid.UUID) (domain.NotificationPreferences, error)
+	SetOrganizationPreferences(ctx context.Context, orgID uuid.UUID, prefs domain.NotificationPreferences) (domain.NotificationPreferences, error)
+	// ResolveChannels returns the channel each user receives event on for a
+	// ticket in the organization.
+	ResolveChannels(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent, userIDs []uuid.UUID) (map[uuid.UUID]domain.NotificationChannel, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// NotificationService defines the interface for notification business logic.
+type NotificationService interface {
+	// Dispatch resolves the recipients of n and enqueues an email or a text
+	// message for each, as their preferences choose.
+	Dispatch(ctx context.Context, n domain.Notification) error
+}
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// Notifier delivers a rendered email.
+type Notifier interface {
+	Send(ctx context.Context, msg domain.EmailMessage) error
+}
+
+// SMSSender delivers a text message.
+type SMSSender interface {
+	Send(ctx context.Context, msg domain.SMSMessage) error
+}
+
+// NotificationQueue defers notification work to a persistent job queue so that
+// failed deliveries are retried. When ctx carries a transaction, jobs are only
+// visible once it commits.
+type NotificationQueue interface {
+	// Enqueue schedules a notification to be dispatched to its recipients
+	// and published to the organization's webhooks.
+	Enqueue(ctx context.Context, n domain.Notification) error
+	// EnqueueEmail schedules a single email for delivery.
+	EnqueueEmail(ctx context.Context, msg domain.EmailMessage) error
+	// EnqueueSMS schedules a single text message for delivery.
+	EnqueueSMS(ctx context.Context, msg domain.SMSMessage) error
+}
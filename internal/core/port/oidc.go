@@ -2,8 +2,13 @@ package port
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrUnknownProvider is returned when a login names a provider that is not
+// configured.
+var ErrUnknownProvider = errors.New("unknown sign-in provider")
+
 // UserInfo represents basic user information retrieved from an OIDC provider.
 type UserInfo struct {
 	Email     string
@@ -13,6 +18,15 @@ type UserInfo struct {
 
 // OIDCProvider defines the interface for interacting with an OIDC provider.
 type OIDCProvider interface {
+	// Name identifies the provider in /auth/login?provider=.
+	Name() string
+	DisplayName() string
 	AuthCodeURL(state string) string
 	ExchangeCode(ctx context.Context, code string) (*UserInfo, error)
 }
+
+// LoginProvider describes a configured provider to the sign-in page.
+type LoginProvider struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
@@ -11,6 +11,8 @@ import (
 type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	// GetByEmail ignores case, preferring an exact match if addresses
+	// differing only in case both have accounts.
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Create(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, user *domain.User) error
@@ -21,11 +23,13 @@ type OrganizationRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
 	GetByShareToken(ctx context.Context, token string) (*domain.Organization, error)
 	GetByPublicViewToken(ctx context.Context, token string) (*domain.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Organization, error)
 	Create(ctx context.Context, org *domain.Organization) error
 	Update(ctx context.Context, org *domain.Organization) error
-	AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error
+	AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error
+	// ListByUser returns the user's memberships, oldest first.
 	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.UserMembership, error)
 	ListMembers(ctx context.Context, orgID uuid.UUID) ([]domain.Member, error)
-	UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error
+	UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error
 	RemoveMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error
 }
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
@@ -13,4 +14,13 @@ type ScheduledTaskRepository interface {
 	Create(ctx context.Context, task *domain.ScheduledTask) error
 	Update(ctx context.Context, task *domain.ScheduledTask) error
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListDueIDs returns enabled tasks whose next run is at or before the given time.
+	ListDueIDs(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+	// GetForUpdate locks the task row for the current transaction. It returns
+	// nil if the task does not exist or is already locked by another worker.
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error)
+
+	CreateRun(ctx context.Context, run *domain.ScheduledTaskRun) error
+	ListRuns(ctx context.Context, taskID uuid.UUID, limit int) ([]domain.ScheduledTaskRun, error)
 }
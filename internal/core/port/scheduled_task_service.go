@@ -13,21 +13,29 @@ type CreateScheduledTaskCmd struct {
 	CreatedBy      uuid.UUID
 	Title          string
 	Description    string
+	// RRule is an RFC 5545 recurrence rule. Frequency is a shorthand used
+	// when RRule is empty.
+	RRule          string
 	Frequency      domain.Frequency
 	StartDate      time.Time
 	AssigneeUserID *uuid.UUID
 	PriorityID     string
+	CategoryID     *string
 	Location       string
 	Enabled        bool
 }
 
+// UpdateScheduledTaskCmd defines the command to update a scheduled task. An
+// empty CategoryID removes the task's category.
 type UpdateScheduledTaskCmd struct {
 	Title          *string
 	Description    *string
+	RRule          *string
 	Frequency      *domain.Frequency
 	StartDate      *time.Time
 	AssigneeUserID *uuid.UUID
 	PriorityID     *string
+	CategoryID     *string
 	Location       *string
 	Enabled        *bool
 }
@@ -38,4 +46,11 @@ type ScheduledTaskService interface {
 	CreateTask(ctx context.Context, cmd CreateScheduledTaskCmd) (*domain.ScheduledTask, error)
 	UpdateTask(ctx context.Context, id uuid.UUID, cmd UpdateScheduledTaskCmd) (*domain.ScheduledTask, error)
 	DeleteTask(ctx context.Context, id uuid.UUID) error
+	RunDueTasks(ctx context.Context, now time.Time) (int, error)
+	ListRuns(ctx context.Context, taskID uuid.UUID, limit int) ([]domain.ScheduledTaskRun, error)
+	ListOccurrences(ctx context.Context, id uuid.UUID, from, to time.Time, limit int) (*domain.ScheduledTaskOccurrences, error)
+	PreviewOccurrences(ctx context.Context, cmd CreateScheduledTaskCmd, from, to time.Time, limit int) (*domain.ScheduledTaskOccurrences, error)
+	// UpdateOrganizationTimezone moves the organization to a new timezone and
+	// reschedules its enabled tasks so their next run keeps its local time.
+	UpdateOrganizationTimezone(ctx context.Context, org *domain.Organization, timezone string) error
 }
@@ -8,7 +8,14 @@ import (
 
 // AuthService defines the interface for authentication operations.
 type AuthService interface {
-	GetLoginURL(state string) string
-	LoginFromProvider(ctx context.Context, code string) (*domain.User, error)
-	CreateSession(ctx context.Context, user *domain.User) (string, error)
+	// Providers lists the configured sign-in providers; the first is the
+	// default.
+	Providers() []LoginProvider
+	// GetLoginURL returns the URL that starts a login with the named
+	// provider, or the default one when provider is empty.
+	GetLoginURL(provider, state string) (string, error)
+	LoginFromProvider(ctx context.Context, provider, code string) (*domain.User, error)
+	// LoginWithEmail signs in the owner of an email address the caller has
+	// already verified, provisioning a new user as LoginFromProvider does.
+	LoginWithEmail(ctx context.Context, email string) (*domain.User, error)
 }
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// SessionRepository stores sessions by the hash of their token.
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session, tokenHash []byte) error
+	// GetByTokenHash returns nil if no session has the hash.
+	GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.Session, error)
+	// ListByUser returns the user's sessions that expire after now, most
+	// recently used first.
+	ListByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error)
+	Touch(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error
+	// Delete reports whether the user had the session.
+	Delete(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	// DeleteByUser removes all of the user's sessions except keep.
+	DeleteByUser(ctx context.Context, userID uuid.UUID, keep uuid.UUID) (int64, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
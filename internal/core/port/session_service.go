@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrSessionNotFound is returned when a user has no such session.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionInfo describes the client a session is created for.
+type SessionInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// SessionService manages server-side sessions.
+type SessionService interface {
+	// Create starts a session and returns the token the client presents.
+	Create(ctx context.Context, userID uuid.UUID, info SessionInfo) (string, *domain.Session, error)
+	// Authenticate returns the session's user and extends the session. It
+	// returns nil without error for unknown or expired tokens.
+	Authenticate(ctx context.Context, token string) (*domain.User, *domain.Session, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	// RevokeOthers ends all of the user's sessions except keep.
+	RevokeOthers(ctx context.Context, userID, keep uuid.UUID) (int64, error)
+	RevokeToken(ctx context.Context, token string) error
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
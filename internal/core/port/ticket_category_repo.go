@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrTicketCategoryInUse is returned when deleting a category that tickets or
+// scheduled tasks still reference.
+var ErrTicketCategoryInUse = errors.New("ticket category is in use")
+
+// TicketCategoryRepository defines the interface for interacting with
+// organization ticket categories.
+type TicketCategoryRepository interface {
+	// ListByOrganization returns the organization's categories ordered by
+	// label.
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketCategories, error)
+	Create(ctx context.Context, category *domain.TicketCategory) error
+	Update(ctx context.Context, category *domain.TicketCategory) error
+	Delete(ctx context.Context, orgID uuid.UUID, id string) error
+}
@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrTicketCategoryNotFound is returned when a category does not exist in
+	// the organization.
+	ErrTicketCategoryNotFound = errors.New("ticket category not found")
+	// ErrInvalidTicketCategory wraps validation failures on category changes
+	// and unknown categories on tickets and scheduled tasks.
+	ErrInvalidTicketCategory = errors.New("invalid ticket category")
+)
+
+// CreateTicketCategoryCmd defines the command to add a category to an
+// organization. ID is derived from Label when empty.
+type CreateTicketCategoryCmd struct {
+	OrganizationID uuid.UUID
+	ID             string
+	Label          string
+	Description    string
+}
+
+// UpdateTicketCategoryCmd defines the command to update a category.
+type UpdateTicketCategoryCmd struct {
+	Label       *string
+	Description *string
+}
+
+// TicketCategoryService manages an organization's ticket categories.
+type TicketCategoryService interface {
+	ListCategories(ctx context.Context, orgID uuid.UUID) (domain.TicketCategories, error)
+	CreateCategory(ctx context.Context, cmd CreateTicketCategoryCmd) (*domain.TicketCategory, error)
+	UpdateCategory(ctx context.Context, orgID uuid.UUID, id string, cmd UpdateTicketCategoryCmd) (*domain.TicketCategory, error)
+	DeleteCategory(ctx context.Context, orgID uuid.UUID, id string) error
+}
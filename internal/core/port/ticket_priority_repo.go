@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrTicketPriorityInUse is returned when deleting a priority that tickets or
+// scheduled tasks still reference.
+var ErrTicketPriorityInUse = errors.New("ticket priority is in use")
+
+// TicketPriorityRepository defines the interface for interacting with
+// organization ticket priorities.
+type TicketPriorityRepository interface {
+	// ListByOrganization returns the organization's priorities ordered by
+	// level.
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketPriorities, error)
+	Create(ctx context.Context, priority *domain.TicketPriority) error
+	Update(ctx context.Context, priority *domain.TicketPriority) error
+	Delete(ctx context.Context, orgID uuid.UUID, id string) error
+}
@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrTicketPriorityNotFound is returned when a priority does not exist in
+	// the organization.
+	ErrTicketPriorityNotFound = errors.New("ticket priority not found")
+	// ErrInvalidTicketPriority wraps validation failures on priority changes.
+	ErrInvalidTicketPriority = errors.New("invalid ticket priority")
+)
+
+// CreateTicketPriorityCmd defines the command to add a priority to an
+// organization. ID is derived from Label when empty and the priority is
+// placed above the most urgent one when Level is nil.
+type CreateTicketPriorityCmd struct {
+	OrganizationID uuid.UUID
+	ID             string
+	Label          string
+	Level          *int
+	Description    string
+	Color          string
+}
+
+// UpdateTicketPriorityCmd defines the command to update a priority.
+type UpdateTicketPriorityCmd struct {
+	Label       *string
+	Level       *int
+	Description *string
+	Color       *string
+}
+
+// TicketPriorityService manages an organization's ticket priorities.
+type TicketPriorityService interface {
+	ListPriorities(ctx context.Context, orgID uuid.UUID) (domain.TicketPriorities, error)
+	CreatePriority(ctx context.Context, cmd CreateTicketPriorityCmd) (*domain.TicketPriority, error)
+	UpdatePriority(ctx context.Context, orgID uuid.UUID, id string, cmd UpdateTicketPriorityCmd) (*domain.TicketPriority, error)
+	DeletePriority(ctx context.Context, orgID uuid.UUID, id string) error
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wsciaroni/opsdeck/internal/core/domain"
@@ -9,27 +10,41 @@ import (
 
 // TicketFilter defines criteria for listing tickets.
 type TicketFilter struct {
-	OrganizationID     *uuid.UUID
-	OrganizationIDs    []uuid.UUID
-	StatusIDs          []string
+	OrganizationID  *uuid.UUID
+	OrganizationIDs []uuid.UUID
+	StatusIDs       []string
+	// StatusState limits results to tickets whose status maps to this state
+	// in the ticket's organization.
+	StatusState        domain.TicketStatusState
 	PriorityIDs        []string
+	CategoryIDs        []string
 	AssigneeID         *uuid.UUID
 	ReporterID         *uuid.UUID
 	ExcludeDescription bool
 	Sensitive          *bool
 	Keyword            *string
-	SortBy             string
-	SortOrder          string
+	// CreatedAfter and UpdatedAfter only keep tickets created or last
+	// updated after the given time.
+	CreatedAfter *time.Time
+	UpdatedAfter *time.Time
+	SortBy       string
+	SortOrder    string
 }
 
 // TicketRepository defines the interface for interacting with ticket data.
 type TicketRepository interface {
 	Create(ctx context.Context, ticket *domain.Ticket) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
+	// GetByIDForUpdate is GetByID that also locks the row until the current
+	// transaction ends, so concurrent updates apply one after the other.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
 	List(ctx context.Context, filter TicketFilter) ([]domain.Ticket, error)
 	Update(ctx context.Context, ticket *domain.Ticket) error
 
 	AddFile(ctx context.Context, file *domain.File) error
 	GetFile(ctx context.Context, id uuid.UUID) (*domain.File, error)
 	ListFiles(ctx context.Context, ticketID uuid.UUID) ([]domain.File, error)
+
+	CreateEvents(ctx context.Context, events []domain.TicketEvent) error
+	ListEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error)
 }
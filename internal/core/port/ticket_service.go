@@ -9,20 +9,27 @@ import (
 
 // CreateTicketCmd defines the command to create a new ticket.
 type CreateTicketCmd struct {
-	OrganizationID uuid.UUID
-	ReporterID     uuid.UUID
-	Title          string
-	Description    string
-	Location       string
-	PriorityID     string
-	Sensitive      bool
-	Files          []domain.File
+	OrganizationID  uuid.UUID
+	ReporterID      uuid.UUID
+	Title           string
+	Description     string
+	Location        string
+	PriorityID      string
+	CategoryID      *string
+	AssigneeUserID  *uuid.UUID
+	ScheduledTaskID *uuid.UUID
+	Sensitive       bool
+	Files           []domain.File
 }
 
-// UpdateTicketCmd defines the command to update an existing ticket.
+// UpdateTicketCmd defines the command to update an existing ticket. An empty
+// CategoryID removes the ticket's category.
 type UpdateTicketCmd struct {
+	// ActorID is the user making the change, recorded in the audit trail.
+	ActorID        *uuid.UUID
 	StatusID       *string
 	PriorityID     *string
+	CategoryID     *string
 	AssigneeUserID *uuid.UUID
 	Title          *string
 	Description    *string
@@ -38,4 +45,5 @@ type TicketService interface {
 	GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
 	GetTicketFile(ctx context.Context, id uuid.UUID) (*domain.File, error)
 	ListTicketFiles(ctx context.Context, ticketID uuid.UUID) ([]domain.File, error)
+	ListTicketEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error)
 }
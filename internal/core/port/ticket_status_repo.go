@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrTicketStatusInUse is returned when deleting a status that tickets still
+// reference.
+var ErrTicketStatusInUse = errors.New("ticket status is in use")
+
+// TicketStatusRepository defines the interface for interacting with
+// organization ticket statuses.
+type TicketStatusRepository interface {
+	// ListByOrganization returns the organization's statuses ordered by
+	// position.
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketStatuses, error)
+	Create(ctx context.Context, status *domain.TicketStatus) error
+	Update(ctx context.Context, status *domain.TicketStatus) error
+	Delete(ctx context.Context, orgID uuid.UUID, id string) error
+}
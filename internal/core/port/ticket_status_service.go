@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrTicketStatusNotFound is returned when a status does not exist in the
+	// organization.
+	ErrTicketStatusNotFound = errors.New("ticket status not found")
+	// ErrInvalidTicketStatus wraps validation failures on status changes.
+	ErrInvalidTicketStatus = errors.New("invalid ticket status")
+)
+
+// CreateTicketStatusCmd defines the command to add a status to an
+// organization. ID is derived from Label when empty and the status is
+// appended after the last one when Position is nil.
+type CreateTicketStatusCmd struct {
+	OrganizationID uuid.UUID
+	ID             string
+	Label          string
+	Position       *int
+	Color          string
+	State          domain.TicketStatusState
+}
+
+// UpdateTicketStatusCmd defines the command to update a status.
+type UpdateTicketStatusCmd struct {
+	Label    *string
+	Position *int
+	Color    *string
+	State    *domain.TicketStatusState
+}
+
+// TicketStatusService manages an organization's ticket statuses.
+type TicketStatusService interface {
+	ListStatuses(ctx context.Context, orgID uuid.UUID) (domain.TicketStatuses, error)
+	CreateStatus(ctx context.Context, cmd CreateTicketStatusCmd) (*domain.TicketStatus, error)
+	UpdateStatus(ctx context.Context, orgID uuid.UUID, id string, cmd UpdateTicketStatusCmd) (*domain.TicketStatus, error)
+	DeleteStatus(ctx context.Context, orgID uuid.UUID, id string) error
+}
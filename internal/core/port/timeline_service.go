@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TimelineQuery selects a page of a ticket's timeline.
+type TimelineQuery struct {
+	Cursor string
+	Limit  int
+	// Public applies the public view redaction rules: sensitive comments,
+	// attachments and user identifiers are left out.
+	Public bool
+}
+
+// TimelineService merges a ticket's comments, attachments and audit events
+// into a single stream.
+type TimelineService interface {
+	GetTimeline(ctx context.Context, ticket *domain.Ticket, query TimelineQuery) (*domain.TimelinePage, error)
+}
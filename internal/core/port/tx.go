@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+)
+
+// TxManager runs a unit of work inside a single database transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
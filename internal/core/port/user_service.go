@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// ErrInvalidPhone is returned when a phone number is not in E.164 format.
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// UserService manages a user's own profile.
+type UserService interface {
+	// UpdatePhone sets the number SMS notifications are sent to. An empty
+	// phone removes it.
+	UpdatePhone(ctx context.Context, userID uuid.UUID, phone string) (*domain.User, error)
+}
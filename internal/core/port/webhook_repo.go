@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+// WebhookDeliveryFilter defines criteria for listing an endpoint's deliveries.
+// Results are ordered newest first; when BeforeCreatedAt is set only
+// deliveries strictly older than (BeforeCreatedAt, BeforeID) are returned.
+type WebhookDeliveryFilter struct {
+	EndpointID      uuid.UUID
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	Limit           int
+}
+
+// WebhookRepository defines the interface for interacting with webhook
+// endpoints and their delivery log.
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	CreateDelivery(ctx context.Context, d *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	// UpdateDeliveryAttempt records the outcome of one delivery attempt.
+	UpdateDeliveryAttempt(ctx context.Context, d *domain.WebhookDelivery) error
+}
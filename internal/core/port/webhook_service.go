@@ -0,0 +1,101 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+var (
+	// ErrWebhookNotFound is returned when an endpoint or delivery does not
+	// exist in the organization.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrInvalidWebhook wraps validation failures on endpoint changes.
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
+
+// CreateWebhookCmd defines the command to register a webhook endpoint. An
+// empty Format means domain.WebhookFormatJSON.
+type CreateWebhookCmd struct {
+	OrganizationID uuid.UUID
+	URL            string
+	Description    string
+	Format         domain.WebhookFormat
+	Events         []domain.NotificationEvent
+	PriorityIDs    []string
+	CategoryIDs    []string
+	Internal       bool
+}
+
+// UpdateWebhookCmd defines the command to change a webhook endpoint. Nil
+// fields are left unchanged.
+type UpdateWebhookCmd struct {
+	OrganizationID uuid.UUID
+	EndpointID     uuid.UUID
+	URL            *string
+	Description    *string
+	Format         *domain.WebhookFormat
+	Events         *[]domain.NotificationEvent
+	PriorityIDs    *[]string
+	CategoryIDs    *[]string
+	Internal       *bool
+	Enabled        *bool
+}
+
+// WebhookDeliveryQuery selects a page of an endpoint's delivery log.
+type WebhookDeliveryQuery struct {
+	Cursor string
+	Limit  int
+}
+
+// WebhookService manages an organization's webhook endpoints and delivers
+// ticket events to them.
+type WebhookService interface {
+	ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]domain.WebhookEndpoint, error)
+	// CreateEndpoint registers an endpoint with a newly generated secret. The
+	// returned endpoint is the only place the secret is ever shown.
+	CreateEndpoint(ctx context.Context, cmd CreateWebhookCmd) (*domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, cmd UpdateWebhookCmd) (*domain.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, orgID, endpointID uuid.UUID) error
+	ListDeliveries(ctx context.Context, orgID, endpointID uuid.UUID, query WebhookDeliveryQuery) (*domain.WebhookDeliveryPage, error)
+	// Redeliver sends a past delivery's payload again as a new delivery.
+	Redeliver(ctx context.Context, orgID, endpointID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error)
+
+	// Publish records a delivery of n for every enabled endpoint of the
+	// ticket's organization that subscribes to its event.
+	Publish(ctx context.Context, n domain.Notification, occurredAt time.Time) error
+	// Deliver makes one attempt at a delivery. It returns an error when the
+	// endpoint did not accept it, so the attempt can be retried.
+	Deliver(ctx context.Context, deliveryID uuid.UUID) error
+}
+
+// WebhookRequest is a payload ready to be encoded in the endpoint's format,
+// signed and sent.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	Format     domain.WebhookFormat
+	DeliveryID uuid.UUID
+	Payload    domain.WebhookPayload
+}
+
+// WebhookResponse is what an endpoint answered.
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookSender POSTs payloads to endpoints. An error means no response was
+// received.
+type WebhookSender interface {
+	Send(ctx context.Context, req WebhookRequest) (*WebhookResponse, error)
+}
+
+// WebhookQueue defers webhook delivery to a persistent job queue. When ctx
+// carries a transaction, jobs are only visible once it commits.
+type WebhookQueue interface {
+	EnqueueDelivery(ctx context.Context, deliveryID uuid.UUID) error
+}
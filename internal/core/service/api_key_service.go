@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// APIKeyTokenPrefix starts every API key so that leaked keys are easy to
+	// recognize.
+	APIKeyTokenPrefix = "odk_"
+	// apiKeyDisplayLength is how much of the token is kept to tell keys
+	// apart.
+	apiKeyDisplayLength = len(APIKeyTokenPrefix) + 8
+	// apiKeyUseInterval limits how often a busy key's last use is written
+	// back.
+	apiKeyUseInterval   = time.Minute
+	maxAPIKeyNameLength = 100
+)
+
+// APIKeyService implements port.APIKeyService.
+type APIKeyService struct {
+	repo  port.APIKeyRepository
+	users port.UserRepository
+	now   func() time.Time
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(repo port.APIKeyRepository, users port.UserRepository) *APIKeyService {
+	return &APIKeyService{repo: repo, users: users, now: time.Now}
+}
+
+// Create issues a key. The caller decides whether cmd.UserID may issue a
+// service key for the organization.
+func (s *APIKeyService) Create(ctx context.Context, cmd port.CreateAPIKeyCmd) (string, *domain.APIKey, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: name is required", port.ErrInvalidAPIKey)
+	}
+	if utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return "", nil, fmt.Errorf("%w: name must be at most %d characters", port.ErrInvalidAPIKey, maxAPIKeyNameLength)
+	}
+	scopes, err := normalizeScopes(cmd.Scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	secret, err := newToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	token := APIKeyTokenPrefix + secret
+
+	key := &domain.APIKey{
+		UserID:         cmd.UserID,
+		OrganizationID: cmd.OrganizationID,
+		Name:           name,
+		Prefix:         token[:apiKeyDisplayLength],
+		Scopes:         scopes,
+		CreatedAt:      s.now(),
+	}
+	if err := s.repo.Create(ctx, key, hashToken(token)); err != nil {
+		return "", nil, err
+	}
+	return token, key, nil
+}
+
+// normalizeScopes checks scopes and returns them without duplicates in
+// display order.
+func normalizeScopes(scopes []domain.APIKeyScope) ([]domain.APIKeyScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", port.ErrInvalidAPIKey)
+	}
+	for _, scope := range scopes {
+		if !scope.Valid() {
+			return nil, fmt.Errorf("%w: unknown scope %q", port.ErrInvalidAPIKey, scope)
+		}
+	}
+	var out []domain.APIKeyScope
+	for _, known := range domain.APIKeyScopes {
+		for _, scope := range scopes {
+			if scope == known {
+				out = append(out, known)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// Authenticate looks the token up and records when the key was last used,
+// at most once every apiKeyUseInterval.
+func (s *APIKeyService) Authenticate(ctx context.Context, token string) (*domain.User, *domain.APIKey, error) {
+	if !strings.HasPrefix(token, APIKeyTokenPrefix) {
+		return nil, nil, nil
+	}
+	key, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil || key == nil {
+		return nil, nil, err
+	}
+
+	user, err := s.users.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, nil
+	}
+
+	now := s.now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUseInterval {
+		if err := s.repo.MarkUsed(ctx, key.ID, now); err != nil {
+			return nil, nil, err
+		}
+		key.LastUsedAt = &now
+	}
+	return user, key, nil
+}
+
+func (s *APIKeyService) ListPersonal(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	return s.repo.ListPersonal(ctx, userID)
+}
+
+func (s *APIKeyService) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.APIKey, error) {
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+func (s *APIKeyService) RevokePersonal(ctx context.Context, userID, id uuid.UUID) error {
+	ok, err := s.repo.DeletePersonal(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return port.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (s *APIKeyService) RevokeForOrganization(ctx context.Context, orgID, id uuid.UUID) error {
+	ok, err := s.repo.DeleteForOrganization(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return port.ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeAPIKeyRepository keeps keys in memory, keyed by token hash.
+type fakeAPIKeyRepository struct {
+	keys     map[string]*domain.APIKey
+	markUsed int
+}
+
+func (r *fakeAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey, tokenHash []byte) error {
+	key.ID = uuid.New()
+	copied := *key
+	r.keys[string(tokenHash)] = &copied
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.APIKey, error) {
+	k, ok := r.keys[string(tokenHash)]
+	if !ok {
+		return nil, nil
+	}
+	copied := *k
+	return &copied, nil
+}
+
+func (r *fakeAPIKeyRepository) ListPersonal(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	for _, k := range r.keys {
+		if k.UserID == userID && k.OrganizationID == nil {
+			keys = append(keys, *k)
+		}
+	}
+	return keys, nil
+}
+
+func (r *fakeAPIKeyRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	for _, k := range r.keys {
+		if k.OrganizationID != nil && *k.OrganizationID == orgID {
+			keys = append(keys, *k)
+		}
+	}
+	return keys, nil
+}
+
+func (r *fakeAPIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.markUsed++
+	for _, k := range r.keys {
+		if k.ID == id {
+			k.LastUsedAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) DeletePersonal(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	for hash, k := range r.keys {
+		if k.ID == id && k.UserID == userID && k.OrganizationID == nil {
+			delete(r.keys, hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAPIKeyRepository) DeleteForOrganization(ctx context.Context, orgID, id uuid.UUID) (bool, error) {
+	for hash, k := range r.keys {
+		if k.ID == id && k.OrganizationID != nil && *k.OrganizationID == orgID {
+			delete(r.keys, hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestAPIKeyService(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "sam@example.com"}
+	orgID := uuid.New()
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func() (*APIKeyService, *fakeAPIKeyRepository, *time.Time) {
+		repo := &fakeAPIKeyRepository{keys: map[string]*domain.APIKey{}}
+		users := new(MockUserRepository)
+		users.On("GetByID", ctx, user.ID).Return(user, nil)
+		now := start
+		service := NewAPIKeyService(repo, users)
+		service.now = func() time.Time { return now }
+		return service, repo, &now
+	}
+
+	t.Run("Tokens are stored hashed and only their prefix is kept", func(t *testing.T) {
+		service, repo, _ := setup()
+
+		token, key, err := service.Create(ctx, port.CreateAPIKeyCmd{
+			UserID: user.ID,
+			Name:   " Nightly sync ",
+			Scopes: []domain.APIKeyScope{domain.APIKeyScopeExport, domain.APIKeyScopeTicketsRead, domain.APIKeyScopeExport},
+		})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, APIKeyTokenPrefix))
+		assert.True(t, strings.HasPrefix(token, key.Prefix))
+		assert.Len(t, key.Prefix, 12)
+		assert.Equal(t, "Nightly sync", key.Name)
+		assert.Equal(t, []domain.APIKeyScope{domain.APIKeyScopeTicketsRead, domain.APIKeyScopeExport}, key.Scopes)
+		assert.Nil(t, key.LastUsedAt)
+		for hash := range repo.keys {
+			assert.NotContains(t, hash, token)
+		}
+
+		got, gotKey, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, user, got)
+		assert.Equal(t, key.ID, gotKey.ID)
+
+		got, _, err = service.Authenticate(ctx, token+"x")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Use is recorded at most once a minute", func(t *testing.T) {
+		service, repo, now := setup()
+		token, key, err := service.Create(ctx, port.CreateAPIKeyCmd{UserID: user.ID, Name: "CI", Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, _, err := service.Authenticate(ctx, token)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, repo.markUsed)
+
+		*now = start.Add(time.Hour)
+		_, _, err = service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.markUsed)
+
+		keys, err := service.ListPersonal(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, key.ID, keys[0].ID)
+		assert.True(t, keys[0].LastUsedAt.Equal(*now))
+	})
+
+	t.Run("Revoked keys stop working", func(t *testing.T) {
+		service, _, _ := setup()
+		personalToken, personalKey, err := service.Create(ctx, port.CreateAPIKeyCmd{UserID: user.ID, Name: "Laptop", Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}})
+		require.NoError(t, err)
+		serviceToken, serviceKey, err := service.Create(ctx, port.CreateAPIKeyCmd{UserID: user.ID, OrganizationID: &orgID, Name: "Kiosk", Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsWrite}})
+		require.NoError(t, err)
+
+		// Keys are revoked through their owner only.
+		assert.ErrorIs(t, service.RevokePersonal(ctx, user.ID, serviceKey.ID), port.ErrAPIKeyNotFound)
+		assert.ErrorIs(t, service.RevokeForOrganization(ctx, uuid.New(), serviceKey.ID), port.ErrAPIKeyNotFound)
+		assert.ErrorIs(t, service.RevokePersonal(ctx, uuid.New(), personalKey.ID), port.ErrAPIKeyNotFound)
+
+		require.NoError(t, service.RevokePersonal(ctx, user.ID, personalKey.ID))
+		require.NoError(t, service.RevokeForOrganization(ctx, orgID, serviceKey.ID))
+		for _, token := range []string{personalToken, serviceToken} {
+			got, _, err := service.Authenticate(ctx, token)
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		}
+	})
+
+	t.Run("Invalid keys are rejected", func(t *testing.T) {
+		service, _, _ := setup()
+		tests := []port.CreateAPIKeyCmd{
+			{UserID: user.ID, Name: "  ", Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}},
+			{UserID: user.ID, Name: strings.Repeat("x", 101), Scopes: []domain.APIKeyScope{domain.APIKeyScopeTicketsRead}},
+			{UserID: user.ID, Name: "No scopes"},
+			{UserID: user.ID, Name: "Admin", Scopes: []domain.APIKeyScope{"admin"}},
+		}
+		for _, cmd := range tests {
+			_, _, err := service.Create(ctx, cmd)
+			assert.ErrorIs(t, err, port.ErrInvalidAPIKey)
+		}
+	})
+}
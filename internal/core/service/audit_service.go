@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// AuditService implements port.AuditService.
+type AuditService struct {
+	repo port.AuditRepository
+}
+
+// NewAuditService creates a new AuditService.
+func NewAuditService(repo port.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record appends an entry to the organization's audit log. Missing before and
+// after states are stored as JSON null.
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	if entry.OrganizationID == uuid.Nil {
+		return fmt.Errorf("organization_id is required")
+	}
+	if entry.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if len(entry.Before) == 0 {
+		entry.Before = []byte("null")
+	}
+	if len(entry.After) == 0 {
+		entry.After = []byte("null")
+	}
+	return s.repo.Create(ctx, entry)
+}
+
+// List returns a page of the organization's audit log, newest first.
+func (s *AuditService) List(ctx context.Context, orgID uuid.UUID, query port.AuditQuery) (*domain.AuditPage, error) {
+	before, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	filter := port.AuditFilter{
+		OrganizationID: orgID,
+		Action:         query.Action,
+		ActorID:        query.ActorID,
+		TargetID:       query.TargetID,
+		From:           query.From,
+		To:             query.To,
+		// One extra row tells us whether another page follows.
+		Limit: limit + 1,
+	}
+	if before != nil {
+		filter.BeforeCreatedAt = &before.createdAt
+		filter.BeforeID = before.id
+	}
+
+	entries, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.AuditPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter port.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
+func TestAuditRecord(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAuditRepository)
+	svc := NewAuditService(repo)
+
+	t.Run("Defaults missing state to null", func(t *testing.T) {
+		entry := &domain.AuditEntry{
+			OrganizationID: uuid.New(),
+			Action:         domain.AuditActionShareLinkTokenRegenerated,
+		}
+		repo.On("Create", ctx, entry).Return(nil).Once()
+
+		require.NoError(t, svc.Record(ctx, entry))
+		assert.JSONEq(t, `null`, string(entry.Before))
+		assert.JSONEq(t, `null`, string(entry.After))
+	})
+
+	t.Run("Requires action", func(t *testing.T) {
+		err := svc.Record(ctx, &domain.AuditEntry{OrganizationID: uuid.New()})
+		assert.Error(t, err)
+	})
+}
+
+func TestAuditList(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	base := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	entries := []domain.AuditEntry{
+		{ID: uuid.New(), CreatedAt: base.Add(3 * time.Minute)},
+		{ID: uuid.New(), CreatedAt: base.Add(2 * time.Minute)},
+		{ID: uuid.New(), CreatedAt: base.Add(time.Minute)},
+	}
+
+	t.Run("Returns a cursor when more entries follow", func(t *testing.T) {
+		repo := new(MockAuditRepository)
+		svc := NewAuditService(repo)
+		repo.On("List", ctx, mock.MatchedBy(func(f port.AuditFilter) bool {
+			return f.OrganizationID == orgID && f.Limit == 3 && f.BeforeCreatedAt == nil
+		})).Return(entries, nil)
+
+		page, err := svc.List(ctx, orgID, port.AuditQuery{Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, page.Entries, 2)
+		require.NotEmpty(t, page.NextCursor)
+
+		// The cursor resumes after the last entry returned.
+		repo.On("List", ctx, mock.MatchedBy(func(f port.AuditFilter) bool {
+			return f.BeforeCreatedAt != nil && f.BeforeCreatedAt.Equal(entries[1].CreatedAt) && f.BeforeID == entries[1].ID
+		})).Return(entries[2:], nil)
+
+		page, err = svc.List(ctx, orgID, port.AuditQuery{Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		assert.Len(t, page.Entries, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		svc := NewAuditService(new(MockAuditRepository))
+		_, err := svc.List(ctx, orgID, port.AuditQuery{Cursor: "!!"})
+		assert.ErrorIs(t, err, port.ErrInvalidCursor)
+	})
+}
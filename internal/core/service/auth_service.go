@@ -16,42 +16,86 @@ import (
 
 // AuthService implements port.AuthService.
 type AuthService struct {
-	repo    port.UserRepository
-	orgRepo port.OrganizationRepository
-	oidc    port.OIDCProvider
-	logger  *slog.Logger
+	repo      port.UserRepository
+	orgRepo   port.OrganizationRepository
+	providers []port.OIDCProvider
+	logger    *slog.Logger
 }
 
-// NewAuthService creates a new AuthService.
-func NewAuthService(repo port.UserRepository, orgRepo port.OrganizationRepository, oidc port.OIDCProvider, logger *slog.Logger) *AuthService {
+// NewAuthService creates a new AuthService. The first provider is the
+// default.
+func NewAuthService(repo port.UserRepository, orgRepo port.OrganizationRepository, providers []port.OIDCProvider, logger *slog.Logger) *AuthService {
 	return &AuthService{
-		repo:    repo,
-		orgRepo: orgRepo,
-		oidc:    oidc,
-		logger:  logger,
+		repo:      repo,
+		orgRepo:   orgRepo,
+		providers: providers,
+		logger:    logger,
 	}
 }
 
+func (s *AuthService) Providers() []port.LoginProvider {
+	list := make([]port.LoginProvider, len(s.providers))
+	for i, p := range s.providers {
+		list[i] = port.LoginProvider{Name: p.Name(), DisplayName: p.DisplayName()}
+	}
+	return list
+}
+
+// provider returns the named provider, or the default one for an empty name.
+func (s *AuthService) provider(name string) (port.OIDCProvider, error) {
+	for _, p := range s.providers {
+		if name == "" || p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", port.ErrUnknownProvider, name)
+}
+
 // GetLoginURL delegates to the OIDC provider to get the authentication URL.
-func (s *AuthService) GetLoginURL(state string) string {
-	return s.oidc.AuthCodeURL(state)
+func (s *AuthService) GetLoginURL(provider, state string) (string, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return "", err
+	}
+	return p.AuthCodeURL(state), nil
 }
 
-// LoginFromProvider authenticates a user using an authorization code.
-func (s *AuthService) LoginFromProvider(ctx context.Context, code string) (*domain.User, error) {
-	// Step 1: Exchange code for user info
-	userInfo, err := s.oidc.ExchangeCode(ctx, code)
+// LoginFromProvider authenticates a user using an authorization code from
+// the named provider.
+func (s *AuthService) LoginFromProvider(ctx context.Context, provider, code string) (*domain.User, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exchange code for user info
+	userInfo, err := p.ExchangeCode(ctx, code)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code: %w", err)
 	}
 
-	// Step 2: Check if user exists
+	return s.login(ctx, userInfo, true)
+}
+
+// LoginWithEmail signs in the owner of an email address the caller has
+// verified, provisioning them like LoginFromProvider. A new user is named
+// after the address, and an existing user's details are left alone.
+func (s *AuthService) LoginWithEmail(ctx context.Context, email string) (*domain.User, error) {
+	name, _, _ := strings.Cut(email, "@")
+	return s.login(ctx, &port.UserInfo{Email: email, Name: name}, false)
+}
+
+// login returns the user with userInfo's email, creating them with a
+// personal organization if they are new. With syncProfile, an existing
+// user's name and avatar are refreshed from userInfo.
+func (s *AuthService) login(ctx context.Context, userInfo *port.UserInfo, syncProfile bool) (*domain.User, error) {
+	// Check if user exists
 	user, err := s.repo.GetByEmail(ctx, userInfo.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check user existence: %w", err)
 	}
 
-	// Step 3: Auto-Provisioning
+	// Auto-Provisioning
 	if user == nil {
 		// New User
 		newUser := &domain.User{
@@ -84,7 +128,7 @@ func (s *AuthService) LoginFromProvider(ctx context.Context, code string) (*doma
 		}
 
 		// Add User as Owner
-		if err := s.orgRepo.AddMember(ctx, newOrg.ID, newUser.ID, "owner"); err != nil {
+		if err := s.orgRepo.AddMember(ctx, newOrg.ID, newUser.ID, domain.OrgRoleOwner); err != nil {
 			return nil, fmt.Errorf("failed to add user to organization: %w", err)
 		}
 		s.logger.Info("created default organization", "org_id", newOrg.ID, "user_id", newUser.ID)
@@ -93,6 +137,9 @@ func (s *AuthService) LoginFromProvider(ctx context.Context, code string) (*doma
 	}
 
 	// Existing User
+	if !syncProfile {
+		return user, nil
+	}
 	updated := false
 	if user.Name != userInfo.Name {
 		user.Name = userInfo.Name
@@ -112,12 +159,6 @@ func (s *AuthService) LoginFromProvider(ctx context.Context, code string) (*doma
 	return user, nil
 }
 
-// CreateSession creates a new session for the user and returns the session token.
-func (s *AuthService) CreateSession(ctx context.Context, user *domain.User) (string, error) {
-	// For now, we simply return the user ID as the session token.
-	return user.ID.String(), nil
-}
-
 func generateSlug(name string) (string, error) {
 	// slugify(name) + "-" + randomHex(4)
 	lowerName := strings.ToLower(name)
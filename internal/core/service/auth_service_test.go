@@ -72,6 +72,14 @@ func (m *MockOrganizationRepository) GetByShareToken(ctx context.Context, token
 	return args.Get(0).(*domain.Organization), args.Error(1)
 }
 
+func (m *MockOrganizationRepository) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
 func (m *MockOrganizationRepository) GetByPublicViewToken(ctx context.Context, token string) (*domain.Organization, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -90,7 +98,7 @@ func (m *MockOrganizationRepository) Update(ctx context.Context, org *domain.Org
 	return args.Error(0)
 }
 
-func (m *MockOrganizationRepository) AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error {
 	args := m.Called(ctx, orgID, userID, role)
 	return args.Error(0)
 }
@@ -116,7 +124,7 @@ func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, orgID uui
 	return args.Error(0)
 }
 
-func (m *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+func (m *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role domain.OrgRole) error {
 	args := m.Called(ctx, orgID, userID, role)
 	return args.Error(0)
 }
@@ -124,8 +132,12 @@ func (m *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, orgID
 // MockOIDCProvider is a mock implementation of port.OIDCProvider
 type MockOIDCProvider struct {
 	mock.Mock
+	name string
 }
 
+func (m *MockOIDCProvider) Name() string        { return m.name }
+func (m *MockOIDCProvider) DisplayName() string { return m.name }
+
 func (m *MockOIDCProvider) AuthCodeURL(state string) string {
 	args := m.Called(state)
 	return args.String(0)
@@ -140,27 +152,50 @@ func (m *MockOIDCProvider) ExchangeCode(ctx context.Context, code string) (*port
 }
 
 func TestGetLoginURL(t *testing.T) {
-	mockRepo := new(MockUserRepository)
-	mockOrgRepo := new(MockOrganizationRepository)
-	mockOIDC := new(MockOIDCProvider)
-	logger := slog.Default()
-	service := NewAuthService(mockRepo, mockOrgRepo, mockOIDC, logger)
+	google := &MockOIDCProvider{name: "google"}
+	entra := &MockOIDCProvider{name: "entra"}
+	service := NewAuthService(new(MockUserRepository), new(MockOrganizationRepository), []port.OIDCProvider{google, entra}, slog.Default())
 
 	state := "state-random-string"
-	expectedURL := "https://accounts.google.com/o/oauth2/auth?state=" + state
-	mockOIDC.On("AuthCodeURL", state).Return(expectedURL)
+	google.On("AuthCodeURL", state).Return("https://accounts.google.com/o/oauth2/auth?state=" + state)
+	entra.On("AuthCodeURL", state).Return("https://login.microsoftonline.com/authorize?state=" + state)
+
+	tests := []struct {
+		provider string
+		want     string
+		wantErr  error
+	}{
+		{provider: "", want: "https://accounts.google.com/o/oauth2/auth?state=" + state},
+		{provider: "entra", want: "https://login.microsoftonline.com/authorize?state=" + state},
+		{provider: "keycloak", wantErr: port.ErrUnknownProvider},
+	}
+	for _, tt := range tests {
+		url, err := service.GetLoginURL(tt.provider, state)
+		if tt.wantErr != nil {
+			assert.ErrorIs(t, err, tt.wantErr, tt.provider)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, url, tt.provider)
+	}
+	assert.Equal(t, []port.LoginProvider{{Name: "google", DisplayName: "google"}, {Name: "entra", DisplayName: "entra"}}, service.Providers())
+}
 
-	url := service.GetLoginURL(state)
-	assert.Equal(t, expectedURL, url)
-	mockOIDC.AssertExpectations(t)
+func TestLoginFromProvider_UnknownProvider(t *testing.T) {
+	mockOIDC := &MockOIDCProvider{name: "google"}
+	service := NewAuthService(new(MockUserRepository), new(MockOrganizationRepository), []port.OIDCProvider{mockOIDC}, slog.Default())
+
+	_, err := service.LoginFromProvider(context.Background(), "entra", "test-code")
+	assert.ErrorIs(t, err, port.ErrUnknownProvider)
+	mockOIDC.AssertNotCalled(t, "ExchangeCode", mock.Anything, mock.Anything)
 }
 
 func TestLoginFromProvider_NewUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockOrgRepo := new(MockOrganizationRepository)
-	mockOIDC := new(MockOIDCProvider)
+	mockOIDC := &MockOIDCProvider{name: "google"}
 	logger := slog.Default()
-	service := NewAuthService(mockRepo, mockOrgRepo, mockOIDC, logger)
+	service := NewAuthService(mockRepo, mockOrgRepo, []port.OIDCProvider{mockOIDC}, logger)
 
 	ctx := context.Background()
 	code := "test-code"
@@ -182,9 +217,9 @@ func TestLoginFromProvider_NewUser(t *testing.T) {
 	})).Return(nil)
 
 	// Expect Add Member
-	mockOrgRepo.On("AddMember", ctx, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("uuid.UUID"), "owner").Return(nil)
+	mockOrgRepo.On("AddMember", ctx, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("uuid.UUID"), domain.OrgRoleOwner).Return(nil)
 
-	user, err := service.LoginFromProvider(ctx, code)
+	user, err := service.LoginFromProvider(ctx, "google", code)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, userInfo.Email, user.Email)
@@ -198,9 +233,9 @@ func TestLoginFromProvider_NewUser(t *testing.T) {
 func TestLoginFromProvider_ExistingUser_NoUpdate(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockOrgRepo := new(MockOrganizationRepository)
-	mockOIDC := new(MockOIDCProvider)
+	mockOIDC := &MockOIDCProvider{name: "google"}
 	logger := slog.Default()
-	service := NewAuthService(mockRepo, mockOrgRepo, mockOIDC, logger)
+	service := NewAuthService(mockRepo, mockOrgRepo, []port.OIDCProvider{mockOIDC}, logger)
 
 	ctx := context.Background()
 	code := "test-code"
@@ -220,7 +255,7 @@ func TestLoginFromProvider_ExistingUser_NoUpdate(t *testing.T) {
 	mockOIDC.On("ExchangeCode", ctx, code).Return(userInfo, nil)
 	mockRepo.On("GetByEmail", ctx, userInfo.Email).Return(existingUser, nil)
 
-	user, err := service.LoginFromProvider(ctx, code)
+	user, err := service.LoginFromProvider(ctx, "google", code)
 	assert.NoError(t, err)
 	assert.Equal(t, existingUser, user)
 
@@ -231,9 +266,9 @@ func TestLoginFromProvider_ExistingUser_NoUpdate(t *testing.T) {
 func TestLoginFromProvider_ExistingUser_WithUpdate(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockOrgRepo := new(MockOrganizationRepository)
-	mockOIDC := new(MockOIDCProvider)
+	mockOIDC := &MockOIDCProvider{name: "google"}
 	logger := slog.Default()
-	service := NewAuthService(mockRepo, mockOrgRepo, mockOIDC, logger)
+	service := NewAuthService(mockRepo, mockOrgRepo, []port.OIDCProvider{mockOIDC}, logger)
 
 	ctx := context.Background()
 	code := "test-code"
@@ -256,7 +291,7 @@ func TestLoginFromProvider_ExistingUser_WithUpdate(t *testing.T) {
 		return u.Name == userInfo.Name && u.AvatarURL == userInfo.AvatarURL
 	})).Return(nil)
 
-	user, err := service.LoginFromProvider(ctx, code)
+	user, err := service.LoginFromProvider(ctx, "google", code)
 	assert.NoError(t, err)
 	assert.Equal(t, userInfo.Name, user.Name)
 	assert.Equal(t, userInfo.AvatarURL, user.AvatarURL)
@@ -264,20 +299,3 @@ func TestLoginFromProvider_ExistingUser_WithUpdate(t *testing.T) {
 	mockOIDC.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
 }
-
-func TestCreateSession(t *testing.T) {
-	mockRepo := new(MockUserRepository)
-	mockOrgRepo := new(MockOrganizationRepository)
-	mockOIDC := new(MockOIDCProvider)
-	logger := slog.Default()
-	service := NewAuthService(mockRepo, mockOrgRepo, mockOIDC, logger)
-
-	ctx := context.Background()
-	user := &domain.User{
-		ID: uuid.New(),
-	}
-
-	token, err := service.CreateSession(ctx, user)
-	assert.NoError(t, err)
-	assert.Equal(t, user.ID.String(), token)
-}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// Authorizer implements port.Authorizer with the permission matrix declared
+// in the domain package.
+type Authorizer struct {
+	orgs port.OrganizationRepository
+}
+
+// NewAuthorizer creates a new Authorizer.
+func NewAuthorizer(orgs port.OrganizationRepository) *Authorizer {
+	return &Authorizer{orgs: orgs}
+}
+
+// Can looks up the user's role in the resource's organization and checks it
+// against the matrix.
+func (a *Authorizer) Can(ctx context.Context, user *domain.User, action domain.Action, resource domain.Resource) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+
+	memberships, err := a.orgs.ListByUser(ctx, user.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list user memberships: %w", err)
+	}
+	for _, m := range memberships {
+		if m.ID == resource.OrganizationID {
+			return m.Role.Can(action), nil
+		}
+	}
+	return false, nil
+}
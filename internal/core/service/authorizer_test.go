@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+)
+
+func TestAuthorizer(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+
+	tests := []struct {
+		name   string
+		role   domain.OrgRole
+		action domain.Action
+		want   bool
+	}{
+		{name: "Owner manages the organization", role: domain.OrgRoleOwner, action: domain.ActionOrgManage, want: true},
+		{name: "Owner removes members", role: domain.OrgRoleOwner, action: domain.ActionMemberRemove, want: true},
+		{name: "Admin manages the organization", role: domain.OrgRoleAdmin, action: domain.ActionOrgManage, want: true},
+		{name: "Admin adds members", role: domain.OrgRoleAdmin, action: domain.ActionMemberAdd, want: true},
+		{name: "Admin changes roles", role: domain.OrgRoleAdmin, action: domain.ActionMemberChangeRole, want: true},
+		{name: "Admin cannot remove members", role: domain.OrgRoleAdmin, action: domain.ActionMemberRemove, want: false},
+		{name: "Member views the organization", role: domain.OrgRoleMember, action: domain.ActionOrgView, want: true},
+		{name: "Member leaves", role: domain.OrgRoleMember, action: domain.ActionOrgLeave, want: true},
+		{name: "Member works tickets", role: domain.OrgRoleMember, action: domain.ActionTicketUpdate, want: true},
+		{name: "Member comments", role: domain.OrgRoleMember, action: domain.ActionCommentCreate, want: true},
+		{name: "Member manages scheduled tasks", role: domain.OrgRoleMember, action: domain.ActionScheduledTaskManage, want: true},
+		{name: "Member cannot manage the organization", role: domain.OrgRoleMember, action: domain.ActionOrgManage, want: false},
+		{name: "Member cannot add members", role: domain.OrgRoleMember, action: domain.ActionMemberAdd, want: false},
+		{name: "Member cannot change roles", role: domain.OrgRoleMember, action: domain.ActionMemberChangeRole, want: false},
+		{name: "Member cannot remove members", role: domain.OrgRoleMember, action: domain.ActionMemberRemove, want: false},
+		{name: "Unknown role can do nothing", role: "viewer", action: domain.ActionOrgView, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			user := &domain.User{ID: uuid.New()}
+			orgs := new(MockOrganizationRepository)
+			orgs.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+				{Organization: domain.Organization{ID: otherOrgID}, Role: domain.OrgRoleOwner},
+				{Organization: domain.Organization{ID: orgID}, Role: tc.role},
+			}, nil)
+
+			ok, err := NewAuthorizer(orgs).Can(ctx, user, tc.action, domain.OrgResource(orgID))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, ok)
+		})
+	}
+
+	t.Run("Non-members can do nothing", func(t *testing.T) {
+		user := &domain.User{ID: uuid.New()}
+		orgs := new(MockOrganizationRepository)
+		orgs.On("ListByUser", mock.Anything, user.ID).Return([]domain.UserMembership{
+			{Organization: domain.Organization{ID: otherOrgID}, Role: domain.OrgRoleOwner},
+		}, nil)
+
+		ok, err := NewAuthorizer(orgs).Can(ctx, user, domain.ActionOrgView, domain.OrgResource(orgID))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("No user can do nothing", func(t *testing.T) {
+		ok, err := NewAuthorizer(new(MockOrganizationRepository)).Can(ctx, nil, domain.ActionOrgView, domain.OrgResource(orgID))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Repository errors are returned", func(t *testing.T) {
+		user := &domain.User{ID: uuid.New()}
+		orgs := new(MockOrganizationRepository)
+		orgs.On("ListByUser", mock.Anything, user.ID).Return(nil, errors.New("db down"))
+
+		ok, err := NewAuthorizer(orgs).Can(ctx, user, domain.ActionOrgView, domain.OrgResource(orgID))
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -11,12 +11,14 @@ import (
 
 // CommentService implements business logic for comments.
 type CommentService struct {
-	repo port.CommentRepository
+	repo   port.CommentRepository
+	notify port.NotificationQueue
+	tx     port.TxManager
 }
 
 // NewCommentService creates a new CommentService.
-func NewCommentService(repo port.CommentRepository) *CommentService {
-	return &CommentService{repo: repo}
+func NewCommentService(repo port.CommentRepository, notify port.NotificationQueue, tx port.TxManager) *CommentService {
+	return &CommentService{repo: repo, notify: notify, tx: tx}
 }
 
 // CreateComment creates a new comment.
@@ -38,8 +40,23 @@ func (s *CommentService) CreateComment(ctx context.Context, cmd port.CreateComme
 		Sensitive: cmd.Sensitive,
 	}
 
-	if err := s.repo.Create(ctx, comment); err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, comment); err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		if err := s.notify.Enqueue(ctx, domain.Notification{
+			Event:     domain.NotificationTicketCommented,
+			TicketID:  comment.TicketID,
+			ActorID:   &comment.UserID,
+			CommentID: &comment.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to enqueue notification: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return comment, nil
@@ -20,6 +20,14 @@ func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.Comm
 	return args.Error(0)
 }
 
+func (m *MockCommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
+}
+
 func (m *MockCommentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID, includeSensitive bool) ([]domain.Comment, error) {
 	args := m.Called(ctx, ticketID, includeSensitive)
 	return args.Get(0).([]domain.Comment), args.Error(1)
@@ -27,7 +35,8 @@ func (m *MockCommentRepository) ListByTicket(ctx context.Context, ticketID uuid.
 
 func TestCreateComment(t *testing.T) {
 	mockRepo := new(MockCommentRepository)
-	service := NewCommentService(mockRepo)
+	queue := &fakeNotificationQueue{}
+	service := NewCommentService(mockRepo, queue, &fakeTxManager{})
 	ctx := context.Background()
 
 	t.Run("Valid Comment", func(t *testing.T) {
@@ -43,6 +52,14 @@ func TestCreateComment(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, comment)
 		assert.Equal(t, cmd.Body, comment.Body)
+
+		if assert.Len(t, queue.notifications, 1) {
+			n := queue.notifications[0]
+			assert.Equal(t, domain.NotificationTicketCommented, n.Event)
+			assert.Equal(t, cmd.TicketID, n.TicketID)
+			assert.Equal(t, &cmd.UserID, n.ActorID)
+			assert.Equal(t, &comment.ID, n.CommentID)
+		}
 	})
 
 	t.Run("Empty Body", func(t *testing.T) {
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// maxDigestItems caps each list of a digest so a busy organization does
+	// not produce an endless email.
+	maxDigestItems = 20
+	// digestUpcomingWindow is how far ahead scheduled tasks are listed.
+	digestUpcomingWindow = 7 * 24 * time.Hour
+)
+
+var (
+	digestTextTemplate = texttemplate.Must(texttemplate.New("digest").Parse(readEmailTemplate("digest.txt.tmpl")))
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest").Parse(readEmailTemplate("digest.html.tmpl")))
+)
+
+// DigestService implements port.DigestService.
+type DigestService struct {
+	repo     port.DigestRepository
+	users    port.UserRepository
+	orgs     port.OrganizationRepository
+	tickets  port.TicketRepository
+	statuses port.TicketStatusRepository
+	tasks    port.ScheduledTaskRepository
+	queue    port.NotificationQueue
+	tx       port.TxManager
+}
+
+// NewDigestService creates a new DigestService.
+func NewDigestService(
+	repo port.DigestRepository,
+	users port.UserRepository,
+	orgs port.OrganizationRepository,
+	tickets port.TicketRepository,
+	statuses port.TicketStatusRepository,
+	tasks port.ScheduledTaskRepository,
+	queue port.NotificationQueue,
+	tx port.TxManager,
+) *DigestService {
+	return &DigestService{
+		repo:     repo,
+		users:    users,
+		orgs:     orgs,
+		tickets:  tickets,
+		statuses: statuses,
+		tasks:    tasks,
+		queue:    queue,
+		tx:       tx,
+	}
+}
+
+// GetSettings returns the user's digest settings. Users who never chose
+// receive no digest.
+func (s *DigestService) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.DigestSettings, error) {
+	settings, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &domain.DigestSettings{UserID: userID, Frequency: domain.DigestFrequencyNone}
+	}
+	return settings, nil
+}
+
+// UpdateSettings saves the user's frequency and timezone. An empty timezone
+// follows the user's first organization.
+func (s *DigestService) UpdateSettings(ctx context.Context, userID uuid.UUID, settings domain.DigestSettings) (*domain.DigestSettings, error) {
+	if !settings.Frequency.Valid() {
+		return nil, fmt.Errorf("%w: unknown frequency %q", port.ErrInvalidDigestSettings, settings.Frequency)
+	}
+	if settings.Timezone != "" {
+		if err := domain.ValidateTimezone(settings.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: %v", port.ErrInvalidDigestSettings, err)
+		}
+	}
+	settings.UserID = userID
+	settings.LastSentAt = nil
+	if err := s.repo.Upsert(ctx, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SendDue enqueues the digest of every user whose digest has fallen due. A
+// digest covers the time since the previous one, but never more than one
+// period, so turning digests back on does not replay months of activity.
+// Each user is handled in their own transaction that claims the digest
+// before the email is enqueued, so concurrent or retried runs send it once.
+// Digests with nothing to report are claimed but not sent.
+func (s *DigestService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	enabled, err := s.repo.ListEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, settings := range enabled {
+		ok, err := s.sendDue(ctx, settings, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("digest for user %s: %w", settings.UserID, err))
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *DigestService) sendDue(ctx context.Context, settings domain.DigestSettings, now time.Time) (bool, error) {
+	user, err := s.users.GetByID(ctx, settings.UserID)
+	if err != nil {
+		return false, err
+	}
+	if user == nil || user.Email == "" {
+		return false, nil
+	}
+	memberships, err := s.orgs.ListByUser(ctx, user.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	// Without a timezone of their own, the user gets that of the first
+	// organization they joined, so the send hour does not vary between runs.
+	timezone := settings.Timezone
+	if timezone == "" && len(memberships) > 0 {
+		timezone = memberships[0].Timezone
+	}
+	loc := domain.LoadLocation(timezone)
+
+	dueAt := settings.DueAt(now, loc)
+	if dueAt.IsZero() || (settings.LastSentAt != nil && !settings.LastSentAt.Before(dueAt)) {
+		return false, nil
+	}
+	since := dueAt.Add(-settings.Frequency.Period())
+	if settings.LastSentAt != nil && settings.LastSentAt.After(since) {
+		since = *settings.LastSentAt
+	}
+
+	digest, err := s.buildDigest(ctx, user, memberships, settings.Frequency, since, now, loc)
+	if err != nil {
+		return false, err
+	}
+
+	var msg *domain.EmailMessage
+	if len(digest.Organizations) > 0 {
+		if msg, err = renderDigest(digest); err != nil {
+			return false, err
+		}
+		msg.To = user.Email
+	}
+
+	sent := false
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		claimed, err := s.repo.MarkSent(ctx, user.ID, dueAt, now)
+		if err != nil || !claimed || msg == nil {
+			return err
+		}
+		if err := s.queue.EnqueueEmail(ctx, *msg); err != nil {
+			return fmt.Errorf("failed to enqueue digest: %w", err)
+		}
+		sent = true
+		return nil
+	})
+	return sent, err
+}
+
+// buildDigest gathers what happened between since and now in each
+// organization the user is a member of. Organizations with nothing to
+// report are left out.
+func (s *DigestService) buildDigest(ctx context.Context, user *domain.User, memberships []domain.UserMembership, freq domain.DigestFrequency, since, now time.Time, loc *time.Location) (domain.Digest, error) {
+	digest := domain.Digest{
+		RecipientName: user.Name,
+		Frequency:     freq,
+		Since:         since.In(loc),
+		Until:         now.In(loc),
+	}
+
+	for _, m := range memberships {
+		orgID := m.ID
+		statuses, err := s.statuses.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return digest, fmt.Errorf("failed to list ticket statuses: %w", err)
+		}
+		status := func(id string) string {
+			if st := statuses.Find(id); st != nil {
+				return st.Label
+			}
+			return id
+		}
+		org := domain.DigestOrganization{Name: m.Name}
+
+		assigned, err := s.tickets.List(ctx, port.TicketFilter{
+			OrganizationID:     &orgID,
+			AssigneeID:         &user.ID,
+			UpdatedAfter:       &since,
+			ExcludeDescription: true,
+			SortBy:             "updated_at",
+			SortOrder:          "desc",
+		})
+		if err != nil {
+			return digest, err
+		}
+		for _, t := range assigned {
+			if len(org.Assigned) == maxDigestItems {
+				org.MoreAssigned++
+				continue
+			}
+			org.Assigned = append(org.Assigned, digestTicket(t, status(t.StatusID), t.UpdatedAt, loc))
+		}
+
+		created, err := s.tickets.List(ctx, port.TicketFilter{
+			OrganizationID:     &orgID,
+			CreatedAfter:       &since,
+			ExcludeDescription: true,
+		})
+		if err != nil {
+			return digest, err
+		}
+		for _, t := range created {
+			if len(org.New) == maxDigestItems {
+				org.MoreNew++
+				continue
+			}
+			org.New = append(org.New, digestTicket(t, status(t.StatusID), t.CreatedAt, loc))
+		}
+
+		tasks, err := s.tasks.List(ctx, orgID)
+		if err != nil {
+			return digest, err
+		}
+		rules := make(map[uuid.UUID]*domain.RRule, len(tasks))
+		byID := make(map[uuid.UUID]domain.ScheduledTask, len(tasks))
+		for _, task := range tasks {
+			rule, err := domain.ParseRRule(task.RRule)
+			if err != nil {
+				continue
+			}
+			rules[task.ID] = rule
+			byID[task.ID] = task
+
+			if !task.Enabled {
+				continue
+			}
+			dtstart := task.StartDate.In(domain.LoadLocation(task.Timezone))
+			next := rule.Between(dtstart, now, now.Add(digestUpcomingWindow), 1)
+			if len(next) == 0 {
+				continue
+			}
+			if len(org.Upcoming) == maxDigestItems {
+				org.MoreUpcoming++
+				continue
+			}
+			org.Upcoming = append(org.Upcoming, domain.DigestTask{Title: task.Title, Location: task.Location, DueAt: next[0].In(loc)})
+		}
+
+		open, err := s.tickets.List(ctx, port.TicketFilter{
+			OrganizationID:     &orgID,
+			AssigneeID:         &user.ID,
+			StatusState:        domain.TicketStatusStateOpen,
+			ExcludeDescription: true,
+			SortBy:             "created_at",
+			SortOrder:          "asc",
+		})
+		if err != nil {
+			return digest, err
+		}
+		for _, t := range open {
+			dueAt, ok := ticketDueAt(t, byID, rules)
+			if !ok || dueAt.After(now) {
+				continue
+			}
+			if len(org.Overdue) == maxDigestItems {
+				org.MoreOverdue++
+				continue
+			}
+			org.Overdue = append(org.Overdue, digestTicket(t, status(t.StatusID), dueAt, loc))
+		}
+
+		if !org.Empty() {
+			digest.Organizations = append(digest.Organizations, org)
+		}
+	}
+	return digest, nil
+}
+
+// ticketDueAt returns when a ticket generated by a scheduled task falls due:
+// the task's next occurrence after the ticket was created. Other tickets
+// have no due date.
+func ticketDueAt(t domain.Ticket, tasks map[uuid.UUID]domain.ScheduledTask, rules map[uuid.UUID]*domain.RRule) (time.Time, bool) {
+	if t.ScheduledTaskID == nil {
+		return time.Time{}, false
+	}
+	task, ok := tasks[*t.ScheduledTaskID]
+	if !ok {
+		return time.Time{}, false
+	}
+	dtstart := task.StartDate.In(domain.LoadLocation(task.Timezone))
+	return rules[task.ID].Next(dtstart, t.CreatedAt)
+}
+
+func digestTicket(t domain.Ticket, status string, at time.Time, loc *time.Location) domain.DigestTicket {
+	return domain.DigestTicket{
+		ID:       t.ID,
+		Title:    t.Title,
+		Status:   status,
+		Location: t.Location,
+		At:       at.In(loc),
+	}
+}
+
+// renderDigest executes the built-in digest templates against d.
+func renderDigest(d domain.Digest) (*domain.EmailMessage, error) {
+	subject := "Your daily OpsDeck digest"
+	if d.Frequency == domain.DigestFrequencyWeekly {
+		subject = "Your weekly OpsDeck digest"
+	}
+	msg := &domain.EmailMessage{Subject: subject}
+
+	var buf bytes.Buffer
+	if err := digestTextTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	msg.Body = buf.String()
+
+	buf.Reset()
+	if err := digestHTMLTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	msg.HTMLBody = buf.String()
+	return msg, nil
+}
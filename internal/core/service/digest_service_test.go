@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeDigestRepository keeps settings in memory.
+type fakeDigestRepository struct {
+	settings map[uuid.UUID]domain.DigestSettings
+}
+
+func (r *fakeDigestRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.DigestSettings, error) {
+	s, ok := r.settings[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+func (r *fakeDigestRepository) Upsert(ctx context.Context, settings *domain.DigestSettings) error {
+	if old, ok := r.settings[settings.UserID]; ok {
+		settings.LastSentAt = old.LastSentAt
+	}
+	r.settings[settings.UserID] = *settings
+	return nil
+}
+
+func (r *fakeDigestRepository) ListEnabled(ctx context.Context) ([]domain.DigestSettings, error) {
+	var enabled []domain.DigestSettings
+	for _, s := range r.settings {
+		if s.Frequency != domain.DigestFrequencyNone {
+			enabled = append(enabled, s)
+		}
+	}
+	return enabled, nil
+}
+
+func (r *fakeDigestRepository) MarkSent(ctx context.Context, userID uuid.UUID, dueAt, sentAt time.Time) (bool, error) {
+	s := r.settings[userID]
+	if s.LastSentAt != nil && !s.LastSentAt.Before(dueAt) {
+		return false, nil
+	}
+	s.LastSentAt = &sentAt
+	r.settings[userID] = s
+	return true, nil
+}
+
+func TestSendDueDigests(t *testing.T) {
+	ctx := context.Background()
+	chicago := domain.LoadLocation("America/Chicago")
+	org := domain.Organization{ID: uuid.New(), Name: "St. Mark", Timezone: "America/Chicago"}
+	user := &domain.User{ID: uuid.New(), Email: "sam@example.com", Name: "Sam"}
+
+	// 08:30 on Tuesday in Chicago; the 07:00 digest is due.
+	now := time.Date(2026, 3, 10, 8, 30, 0, 0, chicago)
+	lastSent := time.Date(2026, 3, 9, 7, 1, 0, 0, chicago)
+
+	hvac := domain.ScheduledTask{
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		Title:          "Change HVAC filter",
+		RRule:          "FREQ=WEEKLY",
+		StartDate:      time.Date(2026, 2, 2, 9, 0, 0, 0, chicago),
+		Timezone:       org.Timezone,
+		Enabled:        true,
+	}
+	changed := domain.Ticket{ID: uuid.New(), Title: "Leaky faucet", StatusID: domain.TicketStatusInProgress, AssigneeUserID: &user.ID}
+	created := domain.Ticket{ID: uuid.New(), Title: "Broken window", StatusID: domain.TicketStatusNew, Location: "Hall"}
+	overdue := domain.Ticket{
+		ID:              uuid.New(),
+		Title:           "Change HVAC filter",
+		StatusID:        domain.TicketStatusNew,
+		AssigneeUserID:  &user.ID,
+		ScheduledTaskID: &hvac.ID,
+		CreatedAt:       time.Date(2026, 3, 2, 9, 0, 0, 0, chicago),
+	}
+	notDue := overdue
+	notDue.ID = uuid.New()
+	notDue.CreatedAt = time.Date(2026, 3, 9, 9, 0, 0, 0, chicago)
+
+	setup := func(settings domain.DigestSettings) (*DigestService, *fakeDigestRepository, *fakeNotificationQueue) {
+		repo := &fakeDigestRepository{settings: map[uuid.UUID]domain.DigestSettings{user.ID: settings}}
+		users := new(MockUserRepository)
+		users.On("GetByID", ctx, user.ID).Return(user, nil)
+		orgs := new(MockOrganizationRepository)
+		orgs.On("ListByUser", ctx, user.ID).Return([]domain.UserMembership{{Organization: org, Role: "member"}}, nil)
+		statuses := new(MockTicketStatusRepository)
+		statuses.On("ListByOrganization", ctx, org.ID).Return(defaultTicketStatuses(org.ID), nil)
+		tasks := new(MockScheduledTaskRepository)
+		tasks.On("List", ctx, org.ID).Return([]domain.ScheduledTask{hvac}, nil)
+
+		tickets := new(MockTicketRepository)
+		tickets.On("List", ctx, mock.MatchedBy(func(f port.TicketFilter) bool { return f.UpdatedAfter != nil })).Return([]domain.Ticket{changed}, nil)
+		tickets.On("List", ctx, mock.MatchedBy(func(f port.TicketFilter) bool { return f.CreatedAfter != nil })).Return([]domain.Ticket{created}, nil)
+		tickets.On("List", ctx, mock.MatchedBy(func(f port.TicketFilter) bool {
+			return f.StatusState == domain.TicketStatusStateOpen && f.AssigneeID != nil && *f.AssigneeID == user.ID
+		})).Return([]domain.Ticket{overdue, notDue}, nil)
+
+		queue := &fakeNotificationQueue{}
+		return NewDigestService(repo, users, orgs, tickets, statuses, tasks, queue, &fakeTxManager{}), repo, queue
+	}
+
+	t.Run("Sends the due digest once", func(t *testing.T) {
+		service, repo, queue := setup(domain.DigestSettings{UserID: user.ID, Frequency: domain.DigestFrequencyDaily, LastSentAt: &lastSent})
+
+		sent, err := service.SendDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, queue.emails, 1)
+
+		msg := queue.emails[0]
+		assert.Equal(t, user.Email, msg.To)
+		assert.Equal(t, "Your daily OpsDeck digest", msg.Subject)
+		assert.Contains(t, msg.Body, "Here is what happened since Mon Mar 9 07:01.")
+		assert.Contains(t, msg.Body, "== St. Mark ==")
+		assert.Contains(t, msg.Body, "Overdue:\n- Change HVAC filter (New)\n\n")
+		assert.Contains(t, msg.Body, "Your tickets that changed:\n- Leaky faucet (In Progress)\n")
+		assert.Contains(t, msg.Body, "New tickets:\n- Broken window (New) at Hall\n")
+		assert.Contains(t, msg.Body, "Scheduled this week:\n- Mon Mar 16 09:00: Change HVAC filter\n")
+		assert.Contains(t, msg.HTMLBody, "<h3 style=\"font-size: 15px;\">New tickets</h3>")
+		assert.True(t, repo.settings[user.ID].LastSentAt.Equal(now))
+
+		sent, err = service.SendDue(ctx, now.Add(15*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Len(t, queue.emails, 1)
+	})
+
+	t.Run("Waits for the local hour", func(t *testing.T) {
+		service, _, queue := setup(domain.DigestSettings{UserID: user.ID, Frequency: domain.DigestFrequencyDaily, LastSentAt: &lastSent})
+
+		// 07:30 UTC is still the night before in Chicago.
+		sent, err := service.SendDue(ctx, time.Date(2026, 3, 10, 7, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Empty(t, queue.emails)
+	})
+
+	t.Run("A user timezone overrides the organization's", func(t *testing.T) {
+		service, _, queue := setup(domain.DigestSettings{UserID: user.ID, Frequency: domain.DigestFrequencyDaily, Timezone: "Europe/London", LastSentAt: &lastSent})
+
+		// 07:30 in London is well before the Chicago hour.
+		sent, err := service.SendDue(ctx, time.Date(2026, 3, 10, 7, 30, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Len(t, queue.emails, 1)
+	})
+}
+
+func TestUpdateDigestSettings(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := &fakeDigestRepository{settings: map[uuid.UUID]domain.DigestSettings{}}
+	service := NewDigestService(repo, nil, nil, nil, nil, nil, nil, &fakeTxManager{})
+
+	settings, err := service.GetSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DigestFrequencyNone, settings.Frequency)
+
+	settings, err = service.UpdateSettings(ctx, userID, domain.DigestSettings{Frequency: domain.DigestFrequencyWeekly, Timezone: "America/Chicago"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DigestFrequencyWeekly, settings.Frequency)
+	assert.Equal(t, domain.DigestFrequencyWeekly, repo.settings[userID].Frequency)
+
+	_, err = service.UpdateSettings(ctx, userID, domain.DigestSettings{Frequency: "hourly"})
+	assert.ErrorIs(t, err, port.ErrInvalidDigestSettings)
+
+	_, err = service.UpdateSettings(ctx, userID, domain.DigestSettings{Frequency: domain.DigestFrequencyDaily, Timezone: "Mars/Olympus"})
+	assert.ErrorIs(t, err, port.ErrInvalidDigestSettings)
+}
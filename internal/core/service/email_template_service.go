@@ -0,0 +1,297 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// defaultEmailTemplates holds the built-in subject, text and HTML template for
+// every event, plus the layouts that frame them. Each layout defines a
+// "details" template summarizing the ticket and calls the event's "content".
+//
+//go:embed email_templates/*.tmpl
+var defaultEmailTemplates embed.FS
+
+const (
+	// maxEmailTemplateSize bounds each part of a template override.
+	maxEmailTemplateSize = 64 << 10
+	// maxEmailFooterLength bounds the branding footer.
+	maxEmailFooterLength = 2000
+)
+
+// EmailTemplateService implements port.EmailTemplateService.
+type EmailTemplateService struct {
+	repo port.EmailTemplateRepository
+	orgs port.OrganizationRepository
+}
+
+// NewEmailTemplateService creates a new EmailTemplateService.
+func NewEmailTemplateService(repo port.EmailTemplateRepository, orgs port.OrganizationRepository) *EmailTemplateService {
+	return &EmailTemplateService{repo: repo, orgs: orgs}
+}
+
+func (s *EmailTemplateService) ListTemplates(ctx context.Context, orgID uuid.UUID) ([]domain.EmailTemplate, error) {
+	overrides, err := s.repo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	custom := make(map[domain.NotificationEvent]domain.EmailTemplate, len(overrides))
+	for _, t := range overrides {
+		custom[t.Event] = t
+	}
+
+	templates := make([]domain.EmailTemplate, 0, len(domain.NotificationEvents))
+	for _, event := range domain.NotificationEvents {
+		if t, ok := custom[event]; ok {
+			templates = append(templates, t)
+			continue
+		}
+		templates = append(templates, defaultEmailTemplate(orgID, event))
+	}
+	return templates, nil
+}
+
+// SetTemplate saves an override after checking that it renders for both
+// members and the public.
+func (s *EmailTemplateService) SetTemplate(ctx context.Context, cmd port.SetEmailTemplateCmd) (*domain.EmailTemplate, error) {
+	if !cmd.Event.Valid() {
+		return nil, fmt.Errorf("%w: unknown event %q", port.ErrInvalidEmailTemplate, cmd.Event)
+	}
+	if strings.TrimSpace(cmd.Subject) == "" || strings.TrimSpace(cmd.TextBody) == "" {
+		return nil, fmt.Errorf("%w: subject and text body are required", port.ErrInvalidEmailTemplate)
+	}
+	if len(cmd.Subject) > maxEmailTemplateSize || len(cmd.TextBody) > maxEmailTemplateSize || len(cmd.HTMLBody) > maxEmailTemplateSize {
+		return nil, fmt.Errorf("%w: templates are limited to %d bytes", port.ErrInvalidEmailTemplate, maxEmailTemplateSize)
+	}
+
+	tmpl := &domain.EmailTemplate{
+		OrganizationID: cmd.OrganizationID,
+		Event:          cmd.Event,
+		Subject:        cmd.Subject,
+		TextBody:       cmd.TextBody,
+		HTMLBody:       cmd.HTMLBody,
+	}
+	sample := sampleEmailTemplateData(cmd.Event, "Sample Organization")
+	for _, data := range []domain.EmailTemplateData{sample, sample.ForPublic()} {
+		if _, err := renderEmail(*tmpl, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func (s *EmailTemplateService) ResetTemplate(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (*domain.EmailTemplate, error) {
+	if !event.Valid() {
+		return nil, fmt.Errorf("%w: unknown event %q", port.ErrInvalidEmailTemplate, event)
+	}
+	if err := s.repo.Delete(ctx, orgID, event); err != nil {
+		return nil, err
+	}
+	tmpl := defaultEmailTemplate(orgID, event)
+	return &tmpl, nil
+}
+
+func (s *EmailTemplateService) GetBranding(ctx context.Context, orgID uuid.UUID) (*domain.EmailBranding, error) {
+	return s.repo.GetBranding(ctx, orgID)
+}
+
+// UpdateBranding saves the organization's branding. The logo must be an
+// absolute http(s) URL since mail clients fetch it themselves.
+func (s *EmailTemplateService) UpdateBranding(ctx context.Context, branding domain.EmailBranding) (*domain.EmailBranding, error) {
+	branding.LogoURL = strings.TrimSpace(branding.LogoURL)
+	branding.Footer = strings.TrimSpace(branding.Footer)
+
+	if branding.LogoURL != "" {
+		u, err := url.Parse(branding.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: logo URL must be an absolute http or https URL", port.ErrInvalidEmailTemplate)
+		}
+	}
+	if len(branding.Footer) > maxEmailFooterLength {
+		return nil, fmt.Errorf("%w: footer is limited to %d characters", port.ErrInvalidEmailTemplate, maxEmailFooterLength)
+	}
+
+	if err := s.repo.UpsertBranding(ctx, &branding); err != nil {
+		return nil, err
+	}
+	return &branding, nil
+}
+
+// Preview renders the organization's email for an event against a sample
+// ticket, with any template parts in cmd replacing the saved ones.
+func (s *EmailTemplateService) Preview(ctx context.Context, cmd port.PreviewEmailCmd) (*domain.EmailMessage, error) {
+	if !cmd.Event.Valid() {
+		return nil, fmt.Errorf("%w: unknown event %q", port.ErrInvalidEmailTemplate, cmd.Event)
+	}
+	org, err := s.orgs.GetByID(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	name := ""
+	if org != nil {
+		name = org.Name
+	}
+
+	tmpl, err := s.template(ctx, cmd.OrganizationID, cmd.Event)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Subject != "" {
+		tmpl.Subject = cmd.Subject
+	}
+	if cmd.TextBody != "" {
+		tmpl.TextBody = cmd.TextBody
+	}
+	if cmd.HTMLBody != "" {
+		tmpl.HTMLBody = cmd.HTMLBody
+	}
+
+	data := sampleEmailTemplateData(cmd.Event, name)
+	data.Public = cmd.Public
+	return s.render(ctx, cmd.OrganizationID, tmpl, data)
+}
+
+func (s *EmailTemplateService) Render(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent, data domain.EmailTemplateData) (*domain.EmailMessage, error) {
+	tmpl, err := s.template(ctx, orgID, event)
+	if err != nil {
+		return nil, err
+	}
+	return s.render(ctx, orgID, tmpl, data)
+}
+
+// template returns the organization's override for event or the default.
+func (s *EmailTemplateService) template(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (domain.EmailTemplate, error) {
+	override, err := s.repo.Get(ctx, orgID, event)
+	if err != nil {
+		return domain.EmailTemplate{}, err
+	}
+	if override != nil {
+		return *override, nil
+	}
+	return defaultEmailTemplate(orgID, event), nil
+}
+
+func (s *EmailTemplateService) render(ctx context.Context, orgID uuid.UUID, tmpl domain.EmailTemplate, data domain.EmailTemplateData) (*domain.EmailMessage, error) {
+	branding, err := s.repo.GetBranding(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	data.Organization.LogoURL = branding.LogoURL
+	data.Organization.Footer = branding.Footer
+	if data.Public {
+		data = data.ForPublic()
+	}
+	return renderEmail(tmpl, data)
+}
+
+// renderEmail executes tmpl against data. The subject is collapsed onto one
+// line and the bodies are wrapped in the default layouts.
+func renderEmail(tmpl domain.EmailTemplate, data domain.EmailTemplateData) (*domain.EmailMessage, error) {
+	invalid := func(part string, err error) error {
+		return fmt.Errorf("%w: %s: %v", port.ErrInvalidEmailTemplate, part, err)
+	}
+
+	subject, err := texttemplate.New("subject").Parse(tmpl.Subject)
+	if err != nil {
+		return nil, invalid("subject", err)
+	}
+	var buf bytes.Buffer
+	if err := subject.Execute(&buf, data); err != nil {
+		return nil, invalid("subject", err)
+	}
+	msg := &domain.EmailMessage{Subject: strings.Join(strings.Fields(buf.String()), " ")}
+
+	text, err := texttemplate.New("layout").Parse(readEmailTemplate("layout.txt.tmpl"))
+	if err == nil {
+		_, err = text.New("content").Parse(tmpl.TextBody)
+	}
+	if err != nil {
+		return nil, invalid("text body", err)
+	}
+	buf.Reset()
+	if err := text.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return nil, invalid("text body", err)
+	}
+	msg.Body = buf.String()
+
+	if tmpl.HTMLBody == "" {
+		return msg, nil
+	}
+	html, err := htmltemplate.New("layout").Parse(readEmailTemplate("layout.html.tmpl"))
+	if err == nil {
+		_, err = html.New("content").Parse(tmpl.HTMLBody)
+	}
+	if err != nil {
+		return nil, invalid("HTML body", err)
+	}
+	buf.Reset()
+	if err := html.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return nil, invalid("HTML body", err)
+	}
+	msg.HTMLBody = buf.String()
+	return msg, nil
+}
+
+func defaultEmailTemplate(orgID uuid.UUID, event domain.NotificationEvent) domain.EmailTemplate {
+	return domain.EmailTemplate{
+		OrganizationID: orgID,
+		Event:          event,
+		Subject:        strings.TrimSpace(readEmailTemplate(string(event) + ".subject.tmpl")),
+		TextBody:       readEmailTemplate(string(event) + ".txt.tmpl"),
+		HTMLBody:       readEmailTemplate(string(event) + ".html.tmpl"),
+	}
+}
+
+// readEmailTemplate returns an embedded template. Every event has all three
+// files, so a missing one is a build mistake.
+func readEmailTemplate(name string) string {
+	b, err := defaultEmailTemplates.ReadFile("email_templates/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("missing embedded email template %s", name))
+	}
+	return string(b)
+}
+
+// sampleEmailTemplateID is the ticket ID shown in previews.
+var sampleEmailTemplateID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// sampleEmailTemplateData is the ticket previews and validation render
+// against. It fills in every field the event would have.
+func sampleEmailTemplateData(event domain.NotificationEvent, orgName string) domain.EmailTemplateData {
+	data := domain.EmailTemplateData{
+		Organization: domain.EmailOrganizationData{Name: orgName},
+		Recipient:    domain.EmailPersonData{Name: "Pat Reporter"},
+		Actor:        domain.EmailPersonData{Name: "Sam Staff"},
+		Ticket: domain.EmailTicketData{
+			ID:          sampleEmailTemplateID,
+			Reference:   domain.TicketReference(sampleEmailTemplateID),
+			Title:       "Leaky faucet in the kitchen",
+			Description: "The cold tap drips constantly, even when fully closed.",
+			Location:    "Parish hall",
+			Status:      "In Progress",
+			Reporter:    "Pat Reporter",
+			Assignee:    "Sam Staff",
+		},
+		OldStatus:  "New",
+		NewStatus:  "In Progress",
+		IsReporter: true,
+	}
+	if event == domain.NotificationTicketCommented {
+		data.Comment = &domain.EmailCommentData{Body: "The plumber is booked for Tuesday morning."}
+	}
+	return data
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockEmailTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailTemplateRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]domain.EmailTemplate, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]domain.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) Get(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) (*domain.EmailTemplate, error) {
+	args := m.Called(ctx, orgID, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) Upsert(ctx context.Context, tmpl *domain.EmailTemplate) error {
+	args := m.Called(ctx, tmpl)
+	return args.Error(0)
+}
+
+func (m *MockEmailTemplateRepository) Delete(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent) error {
+	args := m.Called(ctx, orgID, event)
+	return args.Error(0)
+}
+
+func (m *MockEmailTemplateRepository) GetBranding(ctx context.Context, orgID uuid.UUID) (*domain.EmailBranding, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(*domain.EmailBranding), args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) UpsertBranding(ctx context.Context, branding *domain.EmailBranding) error {
+	args := m.Called(ctx, branding)
+	return args.Error(0)
+}
+
+func emailTemplateData() domain.EmailTemplateData {
+	id := uuid.New()
+	return domain.EmailTemplateData{
+		Organization: domain.EmailOrganizationData{Name: "St. Mark"},
+		Actor:        domain.EmailPersonData{Name: "Max"},
+		Ticket: domain.EmailTicketData{
+			ID:        id,
+			Title:     "Leaky faucet",
+			Status:    "In Progress",
+			Location:  "Parish hall",
+			Reporter:  "Pat",
+			Assignee:  "Sam",
+			Reference: domain.TicketReference(id),
+		},
+		Comment: &domain.EmailCommentData{Body: "Vendor quote is $400", Sensitive: true},
+	}
+}
+
+func TestEmailTemplateRender_Defaults(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	repo := new(MockEmailTemplateRepository)
+	repo.On("Get", ctx, orgID, domain.NotificationTicketCommented).Return(nil, nil)
+	repo.On("GetBranding", ctx, orgID).Return(&domain.EmailBranding{OrganizationID: orgID, LogoURL: "https://example.com/logo.png", Footer: "St. Mark Parish"}, nil)
+	svc := NewEmailTemplateService(repo, new(MockOrganizationRepository))
+
+	data := emailTemplateData()
+	msg, err := svc.Render(ctx, orgID, domain.NotificationTicketCommented, data)
+	require.NoError(t, err)
+	assert.Equal(t, "[St. Mark] New comment: Leaky faucet "+domain.TicketReference(data.Ticket.ID), msg.Subject)
+	assert.Contains(t, msg.Body, "Max commented:\n\nVendor quote is $400\n")
+	assert.Contains(t, msg.Body, "Location: Parish hall\n")
+	assert.Contains(t, msg.Body, "Assigned to: Sam\n")
+	assert.Contains(t, msg.Body, "Ticket ID: "+data.Ticket.ID.String())
+	assert.Contains(t, msg.Body, "-- \nSt. Mark Parish\n")
+	assert.Contains(t, msg.HTMLBody, `<img src="https://example.com/logo.png"`)
+	assert.Contains(t, msg.HTMLBody, "Vendor quote is $400")
+
+	t.Run("Public recipients never see internal fields", func(t *testing.T) {
+		data := emailTemplateData()
+		data.Public = true
+		msg, err := svc.Render(ctx, orgID, domain.NotificationTicketCommented, data)
+		require.NoError(t, err)
+		assert.NotContains(t, msg.Body, "Vendor quote")
+		assert.NotContains(t, msg.Body, "Sam")
+		assert.NotContains(t, msg.HTMLBody, "Vendor quote")
+		assert.NotContains(t, msg.HTMLBody, "Sam")
+	})
+}
+
+func TestEmailTemplateRender_Override(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	repo := new(MockEmailTemplateRepository)
+	repo.On("Get", ctx, orgID, domain.NotificationTicketAssigned).Return(&domain.EmailTemplate{
+		OrganizationID: orgID,
+		Event:          domain.NotificationTicketAssigned,
+		Subject:        "Over to you,\n{{.Ticket.Title}}",
+		TextBody:       "{{.Actor.Name}} needs a hand. Reported by {{.Ticket.Reporter}}.\n",
+		HTMLBody:       "<p>{{.Ticket.Title}}</p>",
+		Custom:         true,
+	}, nil)
+	repo.On("GetBranding", ctx, orgID).Return(&domain.EmailBranding{OrganizationID: orgID}, nil)
+	svc := NewEmailTemplateService(repo, new(MockOrganizationRepository))
+
+	data := emailTemplateData()
+	data.Ticket.Title = "<script>alert(1)</script>"
+	msg, err := svc.Render(ctx, orgID, domain.NotificationTicketAssigned, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Over to you, <script>alert(1)</script>", msg.Subject)
+	assert.Contains(t, msg.Body, "Max needs a hand. Reported by Pat.\n")
+	assert.Contains(t, msg.HTMLBody, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>")
+
+	data.Public = true
+	msg, err = svc.Render(ctx, orgID, domain.NotificationTicketAssigned, data)
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "Reported by .\n")
+}
+
+func TestEmailTemplateSetTemplate(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	tests := []struct {
+		name    string
+		cmd     port.SetEmailTemplateCmd
+		wantErr bool
+	}{
+		{
+			name: "Valid override",
+			cmd:  port.SetEmailTemplateCmd{Event: domain.NotificationTicketCommented, Subject: "Re: {{.Ticket.Title}}", TextBody: "{{with .Comment}}{{.Body}}{{end}}"},
+		},
+		{
+			name:    "Unknown event",
+			cmd:     port.SetEmailTemplateCmd{Event: "ticket_deleted", Subject: "s", TextBody: "b"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing text body",
+			cmd:     port.SetEmailTemplateCmd{Event: domain.NotificationTicketCreated, Subject: "s"},
+			wantErr: true,
+		},
+		{
+			name:    "Parse error",
+			cmd:     port.SetEmailTemplateCmd{Event: domain.NotificationTicketCreated, Subject: "{{.Ticket.Title", TextBody: "b"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown field",
+			cmd:     port.SetEmailTemplateCmd{Event: domain.NotificationTicketCreated, Subject: "s", TextBody: "{{.Ticket.Secret}}"},
+			wantErr: true,
+		},
+		{
+			name:    "Comment on an event without one",
+			cmd:     port.SetEmailTemplateCmd{Event: domain.NotificationStatusChanged, Subject: "s", TextBody: "{{.Comment.Body}}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockEmailTemplateRepository)
+			repo.On("Upsert", ctx, mock.Anything).Return(nil)
+			svc := NewEmailTemplateService(repo, new(MockOrganizationRepository))
+
+			tt.cmd.OrganizationID = orgID
+			tmpl, err := svc.SetTemplate(ctx, tt.cmd)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, port.ErrInvalidEmailTemplate)
+				repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.cmd.Subject, tmpl.Subject)
+			repo.AssertCalled(t, "Upsert", ctx, tmpl)
+		})
+	}
+}
+
+func TestEmailTemplatePreview(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	repo := new(MockEmailTemplateRepository)
+	orgs := new(MockOrganizationRepository)
+	repo.On("Get", ctx, orgID, domain.NotificationTicketCreated).Return(nil, nil)
+	repo.On("GetBranding", ctx, orgID).Return(&domain.EmailBranding{OrganizationID: orgID}, nil)
+	orgs.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Name: "St. Mark"}, nil)
+	svc := NewEmailTemplateService(repo, orgs)
+
+	msg, err := svc.Preview(ctx, port.PreviewEmailCmd{OrganizationID: orgID, Event: domain.NotificationTicketCreated})
+	require.NoError(t, err)
+	assert.Equal(t, "[St. Mark] We received your request: Leaky faucet in the kitchen [#00000000-0000-0000-0000-000000000001]", msg.Subject)
+	assert.Contains(t, msg.Body, "Assigned to: Sam Staff")
+
+	msg, err = svc.Preview(ctx, port.PreviewEmailCmd{OrganizationID: orgID, Event: domain.NotificationTicketCreated, Public: true, Subject: "Thanks, {{.Recipient.Name}}"})
+	require.NoError(t, err)
+	assert.Equal(t, "Thanks, Pat Reporter", msg.Subject)
+	assert.NotContains(t, msg.Body, "Sam Staff")
+}
+
+func TestEmailTemplateUpdateBranding_RejectsUnsafeLogo(t *testing.T) {
+	svc := NewEmailTemplateService(new(MockEmailTemplateRepository), new(MockOrganizationRepository))
+
+	_, err := svc.UpdateBranding(context.Background(), domain.EmailBranding{OrganizationID: uuid.New(), LogoURL: "javascript:alert(1)"})
+	assert.ErrorIs(t, err, port.ErrInvalidEmailTemplate)
+}
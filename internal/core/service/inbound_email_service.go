@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// Limits match the public ticket form. Email cannot be bounced back for
+// editing, so longer input is truncated instead of rejected.
+const (
+	maxInboundTitle       = 200
+	maxInboundDescription = 5000
+	maxInboundName        = 100
+	maxInboundEmail       = 255
+)
+
+// InboundEmailService implements port.InboundEmailService.
+type InboundEmailService struct {
+	orgs       port.OrganizationRepository
+	users      port.UserRepository
+	tickets    port.TicketService
+	ticketRepo port.TicketRepository
+	priorities port.TicketPriorityRepository
+	comments   port.CommentService
+	tx         port.TxManager
+}
+
+// NewInboundEmailService creates a new InboundEmailService.
+func NewInboundEmailService(
+	orgs port.OrganizationRepository,
+	users port.UserRepository,
+	tickets port.TicketService,
+	ticketRepo port.TicketRepository,
+	priorities port.TicketPriorityRepository,
+	comments port.CommentService,
+	tx port.TxManager,
+) *InboundEmailService {
+	return &InboundEmailService{
+		orgs:       orgs,
+		users:      users,
+		tickets:    tickets,
+		ticketRepo: ticketRepo,
+		priorities: priorities,
+		comments:   comments,
+		tx:         tx,
+	}
+}
+
+// Receive accepts mail for organizations that have their public share link
+// enabled, the same switch that governs the public ticket form. Senders are
+// resolved like public form submissions: unknown addresses get a public
+// account and addresses belonging to staff are refused. Auto-submitted mail
+// is dropped.
+func (s *InboundEmailService) Receive(ctx context.Context, msg domain.InboundEmail) error {
+	org, err := s.orgs.GetBySlug(ctx, strings.ToLower(msg.Mailbox))
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil || !org.ShareLinkEnabled {
+		return fmt.Errorf("%w: %s", port.ErrUnknownMailbox, msg.Mailbox)
+	}
+	if msg.AutoSubmitted {
+		return nil
+	}
+
+	reporter, err := s.resolveSender(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	if id, subject, ok := domain.ParseTicketReference(msg.Subject); ok {
+		ticket, err := s.tickets.GetTicket(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		// A reference to someone else's ticket is most likely a forwarded
+		// notification, so it opens a ticket of its own.
+		if ticket != nil && ticket.OrganizationID == org.ID && ticket.ReporterID == reporter.ID {
+			return s.reply(ctx, ticket, reporter, msg)
+		}
+		msg.Subject = subject
+	}
+
+	priorities, err := s.priorities.ListByOrganization(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list ticket priorities: %w", err)
+	}
+	priority := priorities.Lowest()
+	if priority == nil {
+		return fmt.Errorf("organization has no priorities")
+	}
+
+	title := truncateRunes(strings.Join(strings.Fields(msg.Subject), " "), maxInboundTitle)
+	if title == "" {
+		title = "(no subject)"
+	}
+	_, err = s.tickets.CreateTicket(ctx, port.CreateTicketCmd{
+		OrganizationID: org.ID,
+		ReporterID:     reporter.ID,
+		Title:          title,
+		Description:    truncateRunes(strings.TrimSpace(msg.Text), maxInboundDescription),
+		PriorityID:     priority.ID,
+		Files:          msg.Attachments,
+	})
+	return err
+}
+
+// reply adds msg to ticket as a comment, with its attachments added to the
+// ticket's files.
+func (s *InboundEmailService) reply(ctx context.Context, ticket *domain.Ticket, reporter *domain.User, msg domain.InboundEmail) error {
+	body := truncateRunes(stripQuotedReply(msg.Text), maxInboundDescription)
+	if body == "" && len(msg.Attachments) == 0 {
+		return nil
+	}
+	if body == "" {
+		body = "(attachments only)"
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.comments.CreateComment(ctx, port.CreateCommentCmd{
+			TicketID: ticket.ID,
+			UserID:   reporter.ID,
+			Body:     body,
+		}); err != nil {
+			return err
+		}
+		for _, file := range msg.Attachments {
+			file.TicketID = ticket.ID
+			if err := s.ticketRepo.AddFile(ctx, &file); err != nil {
+				return fmt.Errorf("failed to add file: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// resolveSender finds or creates the public user behind msg.From, as the
+// public ticket form does.
+func (s *InboundEmailService) resolveSender(ctx context.Context, msg domain.InboundEmail) (*domain.User, error) {
+	if msg.From == "" || len(msg.From) > maxInboundEmail {
+		return nil, fmt.Errorf("%w: invalid address", port.ErrSenderRejected)
+	}
+
+	user, err := s.users.GetByEmail(ctx, msg.From)
+	if err != nil || user == nil {
+		user = &domain.User{
+			Email: msg.From,
+			Name:  truncateRunes(msg.FromName, maxInboundName),
+			Role:  domain.RolePublic,
+		}
+		if err := s.users.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create public user: %w", err)
+		}
+	}
+
+	if user.Role != domain.RolePublic {
+		return nil, fmt.Errorf("%w: %s must log in to submit tickets", port.ErrSenderRejected, msg.From)
+	}
+	return user, nil
+}
+
+// quoteHeaderPattern matches the attribution line mail clients put above the
+// quoted original, such as "On Tue, Jan 2, 2024 at 9:00 AM Pat wrote:".
+var quoteHeaderPattern = regexp.MustCompile(`^On .+ wrote:$`)
+
+// stripQuotedReply returns the new part of a reply: everything before the
+// quoted original.
+func stripQuotedReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") || quoteHeaderPattern.MatchString(trimmed) ||
+			trimmed == "-----Original Message-----" {
+			lines = lines[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+func TestInboundEmailReceive(t *testing.T) {
+	ctx := context.Background()
+	org := &domain.Organization{ID: uuid.New(), Slug: "st-mark", ShareLinkEnabled: true}
+	reporter := &domain.User{ID: uuid.New(), Email: "pat@example.com", Name: "Pat", Role: domain.RolePublic}
+
+	type deps struct {
+		users    *MockUserRepository
+		tickets  *MockTicketService
+		repo     *MockTicketRepository
+		comments *MockCommentRepository
+		svc      *InboundEmailService
+	}
+	setup := func() deps {
+		orgs := new(MockOrganizationRepository)
+		orgs.On("GetBySlug", ctx, "st-mark").Return(org, nil)
+		orgs.On("GetBySlug", ctx, "closed").Return(&domain.Organization{ID: uuid.New(), Slug: "closed"}, nil)
+		orgs.On("GetBySlug", ctx, mock.Anything).Return(nil, nil)
+
+		users := new(MockUserRepository)
+		users.On("GetByEmail", ctx, reporter.Email).Return(reporter, nil)
+		priorities := new(MockTicketPriorityRepository)
+		priorities.On("ListByOrganization", ctx, org.ID).Return(defaultTicketPriorities(org.ID), nil)
+
+		d := deps{
+			users:    users,
+			tickets:  new(MockTicketService),
+			repo:     new(MockTicketRepository),
+			comments: new(MockCommentRepository),
+		}
+		d.svc = NewInboundEmailService(orgs, users, d.tickets, d.repo, priorities, NewCommentService(d.comments, &fakeNotificationQueue{}, &fakeTxManager{}), &fakeTxManager{})
+		return d
+	}
+
+	t.Run("New mail opens a ticket", func(t *testing.T) {
+		d := setup()
+		files := []domain.File{{Filename: "light.png", Data: []byte("png")}}
+		d.tickets.On("CreateTicket", ctx, port.CreateTicketCmd{
+			OrganizationID: org.ID,
+			ReporterID:     reporter.ID,
+			Title:          "Broken light",
+			Description:    "By the door.",
+			PriorityID:     domain.TicketPriorityLow,
+			Files:          files,
+		}).Return(&domain.Ticket{ID: uuid.New()}, nil)
+
+		err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: "St-Mark", From: reporter.Email, Subject: "Broken\n light", Text: "By the door.\n", Attachments: files})
+		require.NoError(t, err)
+		d.tickets.AssertExpectations(t)
+	})
+
+	t.Run("Unknown senders get a public account", func(t *testing.T) {
+		d := setup()
+		d.users.On("GetByEmail", ctx, "new@example.com").Return(nil, nil)
+		d.users.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == "new@example.com" && u.Name == "New Person" && u.Role == domain.RolePublic
+		})).Return(nil)
+		d.tickets.On("CreateTicket", ctx, mock.MatchedBy(func(cmd port.CreateTicketCmd) bool {
+			return cmd.Title == "(no subject)"
+		})).Return(&domain.Ticket{ID: uuid.New()}, nil)
+
+		err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: "st-mark", From: "new@example.com", FromName: "New Person"})
+		require.NoError(t, err)
+		d.users.AssertCalled(t, "Create", ctx, mock.Anything)
+	})
+
+	t.Run("Replies become comments", func(t *testing.T) {
+		d := setup()
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: org.ID, ReporterID: reporter.ID}
+		d.tickets.On("GetTicket", ctx, ticket.ID).Return(ticket, nil)
+		d.comments.On("Create", ctx, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.TicketID == ticket.ID && c.UserID == reporter.ID && c.Body == "Still flickering." && !c.Sensitive
+		})).Return(nil)
+		d.repo.On("AddFile", ctx, mock.MatchedBy(func(f *domain.File) bool { return f.TicketID == ticket.ID })).Return(nil)
+
+		err := d.svc.Receive(ctx, domain.InboundEmail{
+			Mailbox:     "st-mark",
+			From:        reporter.Email,
+			Subject:     "Re: [St. Mark] Status changed to Done: Broken light " + domain.TicketReference(ticket.ID),
+			Text:        "Still flickering.\n\nOn Tue, Jan 2, 2024 at 9:00 AM OpsDeck wrote:\n> The status changed.",
+			Attachments: []domain.File{{Filename: "light.png"}},
+		})
+		require.NoError(t, err)
+		d.comments.AssertExpectations(t)
+		d.repo.AssertExpectations(t)
+		d.tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("References to someone else's ticket open a new one", func(t *testing.T) {
+		d := setup()
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: org.ID, ReporterID: uuid.New()}
+		d.tickets.On("GetTicket", ctx, ticket.ID).Return(ticket, nil)
+		d.tickets.On("CreateTicket", ctx, mock.MatchedBy(func(cmd port.CreateTicketCmd) bool {
+			return cmd.Title == "Fwd: Broken light"
+		})).Return(&domain.Ticket{ID: uuid.New()}, nil)
+
+		err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: "st-mark", From: reporter.Email, Subject: "Fwd: Broken light " + domain.TicketReference(ticket.ID)})
+		require.NoError(t, err)
+		d.tickets.AssertExpectations(t)
+		d.comments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Staff addresses are refused", func(t *testing.T) {
+		d := setup()
+		d.users.On("GetByEmail", ctx, "staff@example.com").Return(&domain.User{ID: uuid.New(), Role: domain.RoleStaff}, nil)
+
+		err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: "st-mark", From: "staff@example.com", Subject: "Hi"})
+		assert.ErrorIs(t, err, port.ErrSenderRejected)
+	})
+
+	t.Run("Mailboxes must accept public submissions", func(t *testing.T) {
+		d := setup()
+		for _, mailbox := range []string{"closed", "nobody"} {
+			err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: mailbox, From: reporter.Email, Subject: "Hi"})
+			assert.ErrorIs(t, err, port.ErrUnknownMailbox, mailbox)
+		}
+	})
+
+	t.Run("Auto-replies are dropped", func(t *testing.T) {
+		d := setup()
+		err := d.svc.Receive(ctx, domain.InboundEmail{Mailbox: "st-mark", From: reporter.Email, Subject: "Out of office", AutoSubmitted: true})
+		require.NoError(t, err)
+		d.tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	defaultInboxLimit = 20
+	maxInboxLimit     = 100
+)
+
+// InboxService implements port.InboxService.
+type InboxService struct {
+	repo port.InboxRepository
+}
+
+// NewInboxService creates a new InboxService.
+func NewInboxService(repo port.InboxRepository) *InboxService {
+	return &InboxService{repo: repo}
+}
+
+// List returns a page of the user's inbox, newest first.
+func (s *InboxService) List(ctx context.Context, userID uuid.UUID, query port.InboxQuery) (*domain.InboxPage, error) {
+	before, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultInboxLimit
+	}
+	if limit > maxInboxLimit {
+		limit = maxInboxLimit
+	}
+
+	filter := port.InboxFilter{
+		UserID:     userID,
+		UnreadOnly: query.UnreadOnly,
+		// One extra row tells us whether another page follows.
+		Limit: limit + 1,
+	}
+	if before != nil {
+		filter.BeforeCreatedAt = &before.createdAt
+		filter.BeforeID = before.id
+	}
+
+	notifications, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.InboxPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		last := page.Notifications[limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+// CountUnread returns how many of the user's notifications are unread.
+func (s *InboxService) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead marks one of the user's notifications read.
+func (s *InboxService) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	return s.repo.MarkRead(ctx, userID, id)
+}
+
+// MarkAllRead marks every notification in the user's inbox read.
+func (s *InboxService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.MarkAllRead(ctx, userID)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockInboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockInboxRepository) Create(ctx context.Context, n *domain.InboxNotification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func (m *MockInboxRepository) List(ctx context.Context, filter port.InboxFilter) ([]domain.InboxNotification, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.InboxNotification), args.Error(1)
+}
+
+func (m *MockInboxRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxRepository) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockInboxRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestInboxList_Paginates(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	notifications := make([]domain.InboxNotification, 3)
+	for i := range notifications {
+		notifications[i] = domain.InboxNotification{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-time.Duration(i) * time.Minute)}
+	}
+
+	repo := new(MockInboxRepository)
+	svc := NewInboxService(repo)
+
+	repo.On("List", ctx, port.InboxFilter{UserID: userID, UnreadOnly: true, Limit: 3}).Return(notifications, nil).Once()
+	page, err := svc.List(ctx, userID, port.InboxQuery{UnreadOnly: true, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Notifications, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	last := notifications[1]
+	repo.On("List", ctx, mock.MatchedBy(func(f port.InboxFilter) bool {
+		return f.UnreadOnly && f.BeforeCreatedAt.Equal(last.CreatedAt) && f.BeforeID == last.ID
+	})).Return(notifications[2:], nil).Once()
+	page, err = svc.List(ctx, userID, port.InboxQuery{UnreadOnly: true, Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Notifications, 1)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = svc.List(ctx, userID, port.InboxQuery{Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, port.ErrInvalidCursor)
+}
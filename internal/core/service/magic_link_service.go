@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"net/url"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// MagicLinkTTL is how long an emailed sign-in link works.
+	MagicLinkTTL = 15 * time.Minute
+	// MagicLinkCallbackPath is where sign-in links point, relative to the
+	// base URL.
+	MagicLinkCallbackPath = "/auth/magic-link/callback"
+	// magicLinkRateWindow is the period the limits below apply to.
+	magicLinkRateWindow = time.Hour
+	// magicLinksPerEmail bounds how many links one address receives.
+	magicLinksPerEmail = 5
+	// magicLinksPerIP bounds how many links one client can request, so it
+	// cannot flood many addresses.
+	magicLinksPerIP = 20
+	// magicLinkRetention is how long issued links are kept for rate
+	// limiting after they were requested.
+	magicLinkRetention = 24 * time.Hour
+	maxEmailLength     = 254
+)
+
+var (
+	magicLinkTextTemplate = texttemplate.Must(texttemplate.New("magic_link").Parse(readEmailTemplate("magic_link.txt.tmpl")))
+	magicLinkHTMLTemplate = htmltemplate.Must(htmltemplate.New("magic_link").Parse(readEmailTemplate("magic_link.html.tmpl")))
+)
+
+// MagicLinkConfig configures sign-in links.
+type MagicLinkConfig struct {
+	// BaseURL is the public URL of this server that links point at. It is
+	// configured rather than taken from the request so that a forged Host
+	// header cannot send links elsewhere.
+	BaseURL string
+	// Secret signs link tokens so that forged ones are refused without a
+	// database lookup.
+	Secret []byte
+}
+
+// MagicLinkService implements port.MagicLinkService.
+type MagicLinkService struct {
+	repo   port.LoginTokenRepository
+	auth   port.AuthService
+	queue  port.NotificationQueue
+	tx     port.TxManager
+	config MagicLinkConfig
+	now    func() time.Time
+}
+
+// NewMagicLinkService creates a new MagicLinkService.
+func NewMagicLinkService(repo port.LoginTokenRepository, auth port.AuthService, queue port.NotificationQueue, tx port.TxManager, config MagicLinkConfig) *MagicLinkService {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &MagicLinkService{repo: repo, auth: auth, queue: queue, tx: tx, config: config, now: time.Now}
+}
+
+// RequestLink emails a sign-in link to email unless the address or the
+// client has asked for too many recently. Addresses without an account get
+// a link too, since signing in provisions them.
+func (s *MagicLinkService) RequestLink(ctx context.Context, email, ipAddress string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.newToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate login token: %w", err)
+	}
+	now := s.now()
+	msg, err := renderMagicLink(s.config.BaseURL + MagicLinkCallbackPath + "?token=" + url.QueryEscape(token))
+	if err != nil {
+		return err
+	}
+	msg.To = email
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		byEmail, byIP, err := s.repo.CountSince(ctx, email, ipAddress, now.Add(-magicLinkRateWindow))
+		if err != nil {
+			return err
+		}
+		if byEmail >= magicLinksPerEmail || (ipAddress != "" && byIP >= magicLinksPerIP) {
+			return port.ErrRateLimited
+		}
+		if err := s.repo.Create(ctx, email, ipAddress, hashToken(token), now, now.Add(MagicLinkTTL)); err != nil {
+			return err
+		}
+		return s.queue.EnqueueEmail(ctx, *msg)
+	})
+}
+
+// Login consumes the link's token and signs in its email's owner. The
+// token is consumed in the same transaction, so a failed sign-in leaves the
+// link usable.
+func (s *MagicLinkService) Login(ctx context.Context, token string) (*domain.User, error) {
+	if !s.validToken(token) {
+		return nil, nil
+	}
+
+	var user *domain.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		email, err := s.repo.Consume(ctx, hashToken(token), s.now())
+		if err != nil || email == "" {
+			return err
+		}
+		user, err = s.auth.LoginWithEmail(ctx, email)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeExpired deletes links requested more than a day before now. They
+// are kept that long after expiring to count towards the rate limits.
+func (s *MagicLinkService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteBefore(ctx, now.Add(-magicLinkRetention))
+}
+
+// newToken returns a random token followed by its signature.
+func (s *MagicLinkService) newToken() (string, error) {
+	random, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	return random + "." + s.sign(random), nil
+}
+
+func (s *MagicLinkService) validToken(token string) bool {
+	random, signature, ok := strings.Cut(token, ".")
+	if !ok || random == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(random)))
+}
+
+func (s *MagicLinkService) sign(random string) string {
+	mac := hmac.New(sha256.New, s.config.Secret)
+	mac.Write([]byte(random))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeEmail accepts a bare email address and lower-cases it, so case
+// variants share one rate limit quota. Accounts are looked up ignoring case,
+// so the lower-cased address still finds one stored with capitals.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" || len(email) > maxEmailLength {
+		return "", fmt.Errorf("%w: %q", port.ErrInvalidEmail, email)
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("%w: %q", port.ErrInvalidEmail, email)
+	}
+	return strings.ToLower(email), nil
+}
+
+// renderMagicLink executes the built-in sign-in link templates.
+func renderMagicLink(link string) (*domain.EmailMessage, error) {
+	data := struct {
+		URL              string
+		ExpiresInMinutes int
+	}{link, int(MagicLinkTTL / time.Minute)}
+	msg := &domain.EmailMessage{Subject: "Sign in to OpsDeck"}
+
+	var buf bytes.Buffer
+	if err := magicLinkTextTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render sign-in link: %w", err)
+	}
+	msg.Body = buf.String()
+
+	buf.Reset()
+	if err := magicLinkHTMLTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render sign-in link: %w", err)
+	}
+	msg.HTMLBody = buf.String()
+	return msg, nil
+}
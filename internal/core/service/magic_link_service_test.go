@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type fakeLoginToken struct {
+	email, ipAddress string
+	createdAt        time.Time
+	expiresAt        time.Time
+	used             bool
+}
+
+// fakeLoginTokenRepository keeps tokens in memory, keyed by token hash.
+type fakeLoginTokenRepository struct {
+	tokens map[string]*fakeLoginToken
+}
+
+func (r *fakeLoginTokenRepository) Create(ctx context.Context, email, ipAddress string, tokenHash []byte, createdAt, expiresAt time.Time) error {
+	r.tokens[string(tokenHash)] = &fakeLoginToken{email: email, ipAddress: ipAddress, createdAt: createdAt, expiresAt: expiresAt}
+	return nil
+}
+
+func (r *fakeLoginTokenRepository) Consume(ctx context.Context, tokenHash []byte, now time.Time) (string, error) {
+	t, ok := r.tokens[string(tokenHash)]
+	if !ok || t.used || !t.expiresAt.After(now) {
+		return "", nil
+	}
+	t.used = true
+	return t.email, nil
+}
+
+func (r *fakeLoginTokenRepository) CountSince(ctx context.Context, email, ipAddress string, since time.Time) (int, int, error) {
+	var byEmail, byIP int
+	for _, t := range r.tokens {
+		if !t.createdAt.After(since) {
+			continue
+		}
+		if t.email == email {
+			byEmail++
+		}
+		if t.ipAddress == ipAddress {
+			byIP++
+		}
+	}
+	return byEmail, byIP, nil
+}
+
+func (r *fakeLoginTokenRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	for hash, t := range r.tokens {
+		if t.createdAt.Before(before) {
+			delete(r.tokens, hash)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestMagicLinkService(t *testing.T) {
+	ctx := context.Background()
+	existing := &domain.User{ID: uuid.New(), Email: "sam@example.com", Name: "Sam Jones"}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func() (*MagicLinkService, *fakeLoginTokenRepository, *fakeNotificationQueue, *MockOrganizationRepository, *time.Time) {
+		repo := &fakeLoginTokenRepository{tokens: map[string]*fakeLoginToken{}}
+		users := new(MockUserRepository)
+		users.On("GetByEmail", mock.Anything, existing.Email).Return(existing, nil)
+		users.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, nil)
+		users.On("Create", mock.Anything, mock.Anything).Return(nil)
+		orgs := new(MockOrganizationRepository)
+		auth := NewAuthService(users, orgs, nil, slog.Default())
+		queue := &fakeNotificationQueue{}
+		now := start
+		service := NewMagicLinkService(repo, auth, queue, &fakeTxManager{}, MagicLinkConfig{
+			BaseURL: "https://opsdeck.example.org/",
+			Secret:  []byte("secret"),
+		})
+		service.now = func() time.Time { return now }
+		return service, repo, queue, orgs, &now
+	}
+
+	// tokenFrom reads the token out of the link in an emailed message.
+	tokenFrom := func(t *testing.T, msg domain.EmailMessage) string {
+		t.Helper()
+		i := strings.Index(msg.Body, "https://")
+		require.GreaterOrEqual(t, i, 0)
+		link, err := url.Parse(strings.Fields(msg.Body[i:])[0])
+		require.NoError(t, err)
+		assert.Equal(t, "opsdeck.example.org", link.Host)
+		assert.Equal(t, MagicLinkCallbackPath, link.Path)
+		return link.Query().Get("token")
+	}
+
+	t.Run("A link signs in once", func(t *testing.T) {
+		service, repo, queue, _, _ := setup()
+
+		require.NoError(t, service.RequestLink(ctx, " sam@example.com ", "203.0.113.1"))
+		require.Len(t, queue.emails, 1)
+		msg := queue.emails[0]
+		assert.Equal(t, "sam@example.com", msg.To)
+		assert.Contains(t, msg.HTMLBody, "Sign in to OpsDeck")
+		token := tokenFrom(t, msg)
+		for hash := range repo.tokens {
+			assert.NotContains(t, hash, token)
+		}
+
+		user, err := service.Login(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, existing, user)
+
+		user, err = service.Login(ctx, token)
+		require.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Links expire", func(t *testing.T) {
+		service, _, queue, _, now := setup()
+		require.NoError(t, service.RequestLink(ctx, existing.Email, "203.0.113.1"))
+
+		*now = start.Add(MagicLinkTTL)
+		user, err := service.Login(ctx, tokenFrom(t, queue.emails[0]))
+		require.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Forged tokens are refused", func(t *testing.T) {
+		service, repo, queue, _, _ := setup()
+		require.NoError(t, service.RequestLink(ctx, existing.Email, "203.0.113.1"))
+		token := tokenFrom(t, queue.emails[0])
+		random, _, _ := strings.Cut(token, ".")
+
+		for _, forged := range []string{"", random, random + ".", random + ".AAAA", token + "x"} {
+			user, err := service.Login(ctx, forged)
+			require.NoError(t, err)
+			assert.Nil(t, user, forged)
+		}
+		for _, tok := range repo.tokens {
+			assert.False(t, tok.used)
+		}
+	})
+
+	t.Run("New addresses are provisioned", func(t *testing.T) {
+		service, _, queue, orgs, _ := setup()
+		orgs.On("Create", mock.Anything, mock.Anything).Return(nil)
+		orgs.On("AddMember", mock.Anything, mock.Anything, mock.Anything, domain.OrgRoleOwner).Return(nil)
+
+		require.NoError(t, service.RequestLink(ctx, "new.person@example.com", "203.0.113.1"))
+		user, err := service.Login(ctx, tokenFrom(t, queue.emails[0]))
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, "new.person@example.com", user.Email)
+		assert.Equal(t, "new.person", user.Name)
+		assert.Equal(t, domain.RolePublic, user.Role)
+		orgs.AssertExpectations(t)
+	})
+
+	t.Run("Requests are rate limited per email and IP", func(t *testing.T) {
+		service, _, queue, _, now := setup()
+
+		// Case variants of an address share its quota.
+		variants := []string{existing.Email, "Sam@Example.com", "sam@EXAMPLE.COM"}
+		for i := 0; i < magicLinksPerEmail; i++ {
+			require.NoError(t, service.RequestLink(ctx, variants[i%len(variants)], "203.0.113.1"))
+		}
+		for _, msg := range queue.emails {
+			assert.Equal(t, existing.Email, msg.To)
+		}
+		assert.ErrorIs(t, service.RequestLink(ctx, "SAM@example.com", "198.51.100.7"), port.ErrRateLimited)
+
+		for i := magicLinksPerEmail; i < magicLinksPerIP; i++ {
+			require.NoError(t, service.RequestLink(ctx, "user"+strings.Repeat("x", i)+"@example.com", "203.0.113.1"))
+		}
+		assert.ErrorIs(t, service.RequestLink(ctx, "other@example.com", "203.0.113.1"), port.ErrRateLimited)
+		assert.Len(t, queue.emails, magicLinksPerIP)
+
+		*now = start.Add(magicLinkRateWindow)
+		require.NoError(t, service.RequestLink(ctx, existing.Email, "203.0.113.1"))
+
+		purged, err := service.PurgeExpired(ctx, start.Add(magicLinkRetention+time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(magicLinksPerIP), purged)
+	})
+
+	t.Run("Invalid addresses are rejected", func(t *testing.T) {
+		service, _, queue, _, _ := setup()
+		for _, email := range []string{"", "sam", "Sam <sam@example.com>", "sam@example.com, eve@example.com", strings.Repeat("x", 250) + "@example.com"} {
+			assert.ErrorIs(t, service.RequestLink(ctx, email, "203.0.113.1"), port.ErrInvalidEmail, email)
+		}
+		assert.Empty(t, queue.emails)
+	})
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// NotificationPreferenceService implements port.NotificationPreferenceService.
+type NotificationPreferenceService struct {
+	repo port.NotificationPreferenceRepository
+	tx   port.TxManager
+}
+
+// NewNotificationPreferenceService creates a new NotificationPreferenceService.
+func NewNotificationPreferenceService(repo port.NotificationPreferenceRepository, tx port.TxManager) *NotificationPreferenceService {
+	return &NotificationPreferenceService{repo: repo, tx: tx}
+}
+
+func (s *NotificationPreferenceService) ListUserPreferences(ctx context.Context, userID uuid.UUID) (domain.NotificationPreferences, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// SetUserPreferences replaces the user's preferences. Events left out inherit
+// the organization default again.
+func (s *NotificationPreferenceService) SetUserPreferences(ctx context.Context, userID uuid.UUID, prefs domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	if err := prefs.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidNotificationPreference, err)
+	}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.ReplaceForUser(ctx, userID, prefs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *NotificationPreferenceService) ListOrganizationPreferences(ctx context.Context, orgID uuid.UUID) (domain.NotificationPreferences, error) {
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+// SetOrganizationPreferences replaces the organization's defaults. Events left
+// out fall back to domain.DefaultNotificationChannel.
+func (s *NotificationPreferenceService) SetOrganizationPreferences(ctx context.Context, orgID uuid.UUID, prefs domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	if err := prefs.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidNotificationPreference, err)
+	}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.ReplaceForOrganization(ctx, orgID, prefs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+// ResolveChannels applies domain.ResolveNotificationChannel to each user.
+func (s *NotificationPreferenceService) ResolveChannels(ctx context.Context, orgID uuid.UUID, event domain.NotificationEvent, userIDs []uuid.UUID) (map[uuid.UUID]domain.NotificationChannel, error) {
+	orgPrefs, err := s.repo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization preferences: %w", err)
+	}
+	userPrefs, err := s.repo.ListByUsers(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user preferences: %w", err)
+	}
+
+	channels := make(map[uuid.UUID]domain.NotificationChannel, len(userIDs))
+	for _, id := range userIDs {
+		channels[id] = domain.ResolveNotificationChannel(event, userPrefs[id], orgPrefs)
+	}
+	return channels, nil
+}
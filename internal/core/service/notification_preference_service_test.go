@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockNotificationPreferenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferenceRepository) ListByUser(ctx context.Context, userID uuid.UUID) (domain.NotificationPreferences, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.NotificationPreferences, error) {
+	args := m.Called(ctx, userIDs)
+	return args.Get(0).(map[uuid.UUID]domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, prefs domain.NotificationPreferences) error {
+	args := m.Called(ctx, userID, prefs)
+	return args.Error(0)
+}
+
+func (m *MockNotificationPreferenceRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.NotificationPreferences, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceRepository) ReplaceForOrganization(ctx context.Context, orgID uuid.UUID, prefs domain.NotificationPreferences) error {
+	args := m.Called(ctx, orgID, prefs)
+	return args.Error(0)
+}
+
+func TestResolveChannels(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	optedOut := uuid.New()
+	prefersSMS := uuid.New()
+	noPreference := uuid.New()
+	users := []uuid.UUID{optedOut, prefersSMS, noPreference}
+
+	repo := new(MockNotificationPreferenceRepository)
+	repo.On("ListByOrganization", ctx, orgID).Return(domain.NotificationPreferences{
+		{Event: domain.NotificationTicketCommented, Channel: domain.NotificationChannelNone},
+	}, nil)
+	repo.On("ListByUsers", ctx, users).Return(map[uuid.UUID]domain.NotificationPreferences{
+		optedOut:   {{Event: domain.NotificationStatusChanged, Channel: domain.NotificationChannelNone}},
+		prefersSMS: {{Event: domain.NotificationTicketCommented, Channel: domain.NotificationChannelSMS}},
+	}, nil)
+	svc := NewNotificationPreferenceService(repo, &fakeTxManager{})
+
+	tests := []struct {
+		name  string
+		event domain.NotificationEvent
+		want  map[uuid.UUID]domain.NotificationChannel
+	}{
+		{
+			name:  "User preference wins over the organization default",
+			event: domain.NotificationTicketCommented,
+			want: map[uuid.UUID]domain.NotificationChannel{
+				optedOut:     domain.NotificationChannelNone,
+				prefersSMS:   domain.NotificationChannelSMS,
+				noPreference: domain.NotificationChannelNone,
+			},
+		},
+		{
+			name:  "Falls back to the global default",
+			event: domain.NotificationStatusChanged,
+			want: map[uuid.UUID]domain.NotificationChannel{
+				optedOut:     domain.NotificationChannelNone,
+				prefersSMS:   domain.DefaultNotificationChannel,
+				noPreference: domain.DefaultNotificationChannel,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ResolveChannels(ctx, orgID, tt.event, users)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetUserPreferences(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Replaces preferences", func(t *testing.T) {
+		repo := new(MockNotificationPreferenceRepository)
+		tx := &fakeTxManager{}
+		svc := NewNotificationPreferenceService(repo, tx)
+		prefs := domain.NotificationPreferences{{Event: domain.NotificationTicketAssigned, Channel: domain.NotificationChannelSMS}}
+		repo.On("ReplaceForUser", ctx, userID, prefs).Return(nil)
+		repo.On("ListByUser", ctx, userID).Return(prefs, nil)
+
+		saved, err := svc.SetUserPreferences(ctx, userID, prefs)
+		require.NoError(t, err)
+		assert.Equal(t, prefs, saved)
+		assert.Equal(t, 1, tx.calls)
+	})
+
+	t.Run("Rejects invalid preferences", func(t *testing.T) {
+		repo := new(MockNotificationPreferenceRepository)
+		svc := NewNotificationPreferenceService(repo, &fakeTxManager{})
+
+		tests := []domain.NotificationPreferences{
+			{{Event: "ticket_deleted", Channel: domain.NotificationChannelEmail}},
+			{{Event: domain.NotificationTicketCreated, Channel: "pager"}},
+			{
+				{Event: domain.NotificationTicketCreated, Channel: domain.NotificationChannelEmail},
+				{Event: domain.NotificationTicketCreated, Channel: domain.NotificationChannelNone},
+			},
+		}
+		for _, prefs := range tests {
+			_, err := svc.SetUserPreferences(ctx, userID, prefs)
+			assert.ErrorIs(t, err, port.ErrInvalidNotificationPreference)
+		}
+		repo.AssertNotCalled(t, "ReplaceForUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// NotificationService turns ticket activity into inbox entries, emails and
+// text messages for the people involved in the ticket.
+type NotificationService struct {
+	queue       port.NotificationQueue
+	preferences port.NotificationPreferenceService
+	inbox       port.InboxRepository
+	templates   port.EmailTemplateService
+	tickets     port.TicketRepository
+	comments    port.CommentRepository
+	statuses    port.TicketStatusRepository
+	users       port.UserRepository
+	orgs        port.OrganizationRepository
+	tx          port.TxManager
+}
+
+// NewNotificationService creates a new NotificationService.
+func NewNotificationService(
+	queue port.NotificationQueue,
+	preferences port.NotificationPreferenceService,
+	inbox port.InboxRepository,
+	templates port.EmailTemplateService,
+	tickets port.TicketRepository,
+	comments port.CommentRepository,
+	statuses port.TicketStatusRepository,
+	users port.UserRepository,
+	orgs port.OrganizationRepository,
+	tx port.TxManager,
+) *NotificationService {
+	return &NotificationService{
+		queue:       queue,
+		preferences: preferences,
+		inbox:       inbox,
+		templates:   templates,
+		tickets:     tickets,
+		comments:    comments,
+		statuses:    statuses,
+		users:       users,
+		orgs:        orgs,
+		tx:          tx,
+	}
+}
+
+// Dispatch resolves the recipients of n, adds an entry to each recipient's
+// inbox and enqueues an email or a text message for those whose preferences
+// choose email or SMS for the event. Recipients without an address for their
+// channel only get the inbox entry. Everything is written in a single
+// transaction so a retried dispatch never duplicates. Recipients who are not
+// members of the ticket's organization never receive sensitive comments, and
+// their messages are rendered without the ticket's internal details.
+func (s *NotificationService) Dispatch(ctx context.Context, n domain.Notification) error {
+	ticket, err := s.tickets.GetByID(ctx, n.TicketID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket == nil {
+		return nil
+	}
+
+	var comment *domain.Comment
+	if n.CommentID != nil {
+		comment, err = s.comments.GetByID(ctx, *n.CommentID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if comment == nil {
+			return nil
+		}
+	}
+
+	recipients := notificationRecipients(n, ticket)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	ids := append([]uuid.UUID{}, recipients...)
+	if n.ActorID != nil {
+		ids = append(ids, *n.ActorID)
+	}
+	ids = append(ids, ticket.ReporterID)
+	if ticket.AssigneeUserID != nil {
+		ids = append(ids, *ticket.AssigneeUserID)
+	}
+	users, err := s.users.GetByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	org, err := s.orgs.GetByID(ctx, ticket.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil {
+		return nil
+	}
+	members, err := s.orgs.ListMembers(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list members: %w", err)
+	}
+	isMember := make(map[uuid.UUID]bool, len(members))
+	for _, m := range members {
+		isMember[m.UserID] = true
+	}
+
+	statuses, err := s.statuses.ListByOrganization(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list ticket statuses: %w", err)
+	}
+
+	channels, err := s.preferences.ResolveChannels(ctx, org.ID, n.Event, recipients)
+	if err != nil {
+		return fmt.Errorf("failed to resolve notification channels: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]domain.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	var actor *domain.User
+	if n.ActorID != nil {
+		if u, ok := byID[*n.ActorID]; ok {
+			actor = &u
+		}
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, id := range recipients {
+			recipient, ok := byID[id]
+			if !ok {
+				continue
+			}
+			if comment != nil && comment.Sensitive && !isMember[id] {
+				continue
+			}
+
+			view := notificationView{
+				event:     n,
+				org:       org,
+				ticket:    ticket,
+				comment:   comment,
+				statuses:  statuses,
+				users:     byID,
+				actor:     actor,
+				recipient: &recipient,
+			}
+			if err := s.inbox.Create(ctx, &domain.InboxNotification{
+				UserID:         id,
+				OrganizationID: org.ID,
+				TicketID:       ticket.ID,
+				Event:          n.Event,
+				ActorID:        n.ActorID,
+				Summary:        view.summary(),
+			}); err != nil {
+				return fmt.Errorf("failed to add inbox notification: %w", err)
+			}
+
+			switch channels[id] {
+			case domain.NotificationChannelEmail:
+				if recipient.Email == "" {
+					continue
+				}
+				msg, err := s.templates.Render(ctx, org.ID, n.Event, view.templateData(!isMember[id]))
+				if err != nil {
+					return fmt.Errorf("failed to render email: %w", err)
+				}
+				msg.To = recipient.Email
+				if err := s.queue.EnqueueEmail(ctx, *msg); err != nil {
+					return fmt.Errorf("failed to enqueue email: %w", err)
+				}
+			case domain.NotificationChannelSMS:
+				if recipient.Phone == "" {
+					continue
+				}
+				if err := s.queue.EnqueueSMS(ctx, domain.SMSMessage{To: recipient.Phone, Body: view.text()}); err != nil {
+					return fmt.Errorf("failed to enqueue sms: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// notificationRecipients returns who should hear about n, without the actor.
+// Reporters always get an acknowledgement for tickets they submitted, even
+// when they submitted it themselves; tickets generated by scheduled tasks
+// have nobody waiting on them.
+func notificationRecipients(n domain.Notification, ticket *domain.Ticket) []uuid.UUID {
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	add := func(id *uuid.UUID, allowActor bool) {
+		if id == nil || *id == uuid.Nil || seen[*id] {
+			return
+		}
+		if !allowActor && n.ActorID != nil && *n.ActorID == *id {
+			return
+		}
+		seen[*id] = true
+		ids = append(ids, *id)
+	}
+
+	switch n.Event {
+	case domain.NotificationTicketCreated:
+		if ticket.ScheduledTaskID == nil {
+			add(&ticket.ReporterID, true)
+		}
+		add(ticket.AssigneeUserID, false)
+	case domain.NotificationTicketAssigned:
+		add(ticket.AssigneeUserID, false)
+	case domain.NotificationTicketCommented, domain.NotificationStatusChanged:
+		add(&ticket.ReporterID, false)
+		add(ticket.AssigneeUserID, false)
+	}
+	return ids
+}
+
+// notificationView is everything needed to render one recipient's email.
+type notificationView struct {
+	event     domain.Notification
+	org       *domain.Organization
+	ticket    *domain.Ticket
+	comment   *domain.Comment
+	statuses  domain.TicketStatuses
+	users     map[uuid.UUID]domain.User
+	actor     *domain.User
+	recipient *domain.User
+}
+
+func (v notificationView) statusLabel(id string) string {
+	if status := v.statuses.Find(id); status != nil {
+		return status.Label
+	}
+	return id
+}
+
+func (v notificationView) actorName() string {
+	if v.actor != nil && v.actor.Name != "" {
+		return v.actor.Name
+	}
+	return "Someone"
+}
+
+// maxSummaryComment is how much of a comment an inbox summary quotes.
+const maxSummaryComment = 140
+
+// summary is the one-line description shown in the recipient's inbox.
+func (v notificationView) summary() string {
+	switch v.event.Event {
+	case domain.NotificationTicketCreated:
+		if v.recipient.ID == v.ticket.ReporterID {
+			return "Your request was received"
+		}
+		return "New ticket submitted"
+	case domain.NotificationTicketAssigned:
+		return v.actorName() + " assigned this ticket to you"
+	case domain.NotificationTicketCommented:
+		body := strings.Join(strings.Fields(v.comment.Body), " ")
+		if r := []rune(body); len(r) > maxSummaryComment {
+			body = string(r[:maxSummaryComment]) + "…"
+		}
+		return v.actorName() + " commented: " + body
+	case domain.NotificationStatusChanged:
+		return fmt.Sprintf("Status changed from %s to %s",
+			v.statusLabel(v.event.OldStatusID), v.statusLabel(v.event.NewStatusID))
+	}
+	return ""
+}
+
+// maxSMSLength keeps a text message within two SMS segments.
+const maxSMSLength = 300
+
+// text is the body of the recipient's text message: the organization, the
+// ticket title and the inbox summary. It never carries more than the inbox
+// entry, so it is as safe for public recipients as the inbox is.
+func (v notificationView) text() string {
+	body := fmt.Sprintf("[%s] %s: %s", v.org.Name, v.ticket.Title, v.summary())
+	if r := []rune(body); len(r) > maxSMSLength {
+		body = string(r[:maxSMSLength-1]) + "…"
+	}
+	return body
+}
+
+// templateData is what the recipient's email templates render. Public is set
+// for recipients outside the organization.
+func (v notificationView) templateData(public bool) domain.EmailTemplateData {
+	data := domain.EmailTemplateData{
+		Organization: domain.EmailOrganizationData{Name: v.org.Name},
+		Recipient:    domain.EmailPersonData{Name: v.recipient.Name},
+		Actor:        domain.EmailPersonData{Name: v.actorName()},
+		Ticket: domain.EmailTicketData{
+			ID:          v.ticket.ID,
+			Reference:   domain.TicketReference(v.ticket.ID),
+			Title:       v.ticket.Title,
+			Description: v.ticket.Description,
+			Location:    v.ticket.Location,
+			Status:      v.statusLabel(v.ticket.StatusID),
+			Reporter:    v.users[v.ticket.ReporterID].Name,
+		},
+		IsReporter: v.recipient.ID == v.ticket.ReporterID,
+		Public:     public,
+	}
+	if v.ticket.AssigneeUserID != nil {
+		data.Ticket.Assignee = v.users[*v.ticket.AssigneeUserID].Name
+	}
+	if v.comment != nil {
+		data.Comment = &domain.EmailCommentData{Body: v.comment.Body, Sensitive: v.comment.Sensitive}
+	}
+	if v.event.Event == domain.NotificationStatusChanged {
+		data.OldStatus = v.statusLabel(v.event.OldStatusID)
+		data.NewStatus = v.statusLabel(v.event.NewStatusID)
+	}
+	return data
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeNotificationQueue records what was enqueued instead of running jobs.
+type fakeNotificationQueue struct {
+	notifications []domain.Notification
+	emails        []domain.EmailMessage
+	texts         []domain.SMSMessage
+}
+
+func (q *fakeNotificationQueue) Enqueue(ctx context.Context, n domain.Notification) error {
+	q.notifications = append(q.notifications, n)
+	return nil
+}
+
+func (q *fakeNotificationQueue) EnqueueEmail(ctx context.Context, msg domain.EmailMessage) error {
+	q.emails = append(q.emails, msg)
+	return nil
+}
+
+func (q *fakeNotificationQueue) EnqueueSMS(ctx context.Context, msg domain.SMSMessage) error {
+	q.texts = append(q.texts, msg)
+	return nil
+}
+
+func TestCreateTicket_EnqueuesNotification(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	repo := new(MockTicketRepository)
+	statuses := new(MockTicketStatusRepository)
+	priorities := new(MockTicketPriorityRepository)
+	statuses.On("ListByOrganization", ctx, orgID).Return(defaultTicketStatuses(orgID), nil)
+	priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+	repo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Ticket).ID = uuid.New()
+	}).Return(nil)
+
+	queue := &fakeNotificationQueue{}
+	tx := &fakeTxManager{}
+	service := NewTicketService(repo, statuses, priorities, new(MockTicketCategoryRepository), queue, tx)
+
+	ticket, err := service.CreateTicket(ctx, port.CreateTicketCmd{OrganizationID: orgID, Title: "Leaky faucet", PriorityID: domain.TicketPriorityLow})
+	require.NoError(t, err)
+	assert.Equal(t, 1, tx.calls)
+	assert.Equal(t, []domain.Notification{{Event: domain.NotificationTicketCreated, TicketID: ticket.ID}}, queue.notifications)
+}
+
+func TestDispatchNotification(t *testing.T) {
+	ctx := context.Background()
+	org := &domain.Organization{ID: uuid.New(), Name: "St. Mark"}
+	reporter := domain.User{ID: uuid.New(), Email: "reporter@example.com", Name: "Pat", Role: domain.RolePublic}
+	staff := domain.User{ID: uuid.New(), Email: "staff@example.com", Name: "Sam", Role: domain.RoleStaff}
+	manager := domain.User{ID: uuid.New(), Email: "manager@example.com", Name: "Max", Role: domain.RoleManager, Phone: "+15550000001"}
+	members := []domain.Member{{UserID: staff.ID}, {UserID: manager.ID}}
+
+	newTicket := func() *domain.Ticket {
+		return &domain.Ticket{
+			ID:             uuid.New(),
+			OrganizationID: org.ID,
+			Title:          "Leaky faucet",
+			StatusID:       domain.TicketStatusInProgress,
+			ReporterID:     reporter.ID,
+			AssigneeUserID: &staff.ID,
+		}
+	}
+
+	setup := func(ticket *domain.Ticket, comment *domain.Comment, userPrefs map[uuid.UUID]domain.NotificationPreferences) (*NotificationService, *fakeNotificationQueue, *[]domain.InboxNotification) {
+		if userPrefs == nil {
+			userPrefs = map[uuid.UUID]domain.NotificationPreferences{}
+		}
+		preferences := new(MockNotificationPreferenceRepository)
+		preferences.On("ListByOrganization", ctx, org.ID).Return(domain.NotificationPreferences{}, nil)
+		preferences.On("ListByUsers", ctx, mock.Anything).Return(userPrefs, nil)
+
+		inbox := new(MockInboxRepository)
+		var added []domain.InboxNotification
+		inbox.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			added = append(added, *args.Get(1).(*domain.InboxNotification))
+		}).Return(nil)
+
+		tickets := new(MockTicketRepository)
+		comments := new(MockCommentRepository)
+		statuses := new(MockTicketStatusRepository)
+		users := new(MockUserRepository)
+		orgs := new(MockOrganizationRepository)
+
+		tickets.On("GetByID", ctx, ticket.ID).Return(ticket, nil)
+		if comment != nil {
+			comments.On("GetByID", ctx, comment.ID).Return(comment, nil)
+		}
+		statuses.On("ListByOrganization", ctx, org.ID).Return(defaultTicketStatuses(org.ID), nil)
+		users.On("GetByIDs", ctx, mock.Anything).Return([]domain.User{reporter, staff, manager}, nil)
+		orgs.On("GetByID", ctx, org.ID).Return(org, nil)
+		orgs.On("ListMembers", ctx, org.ID).Return(members, nil)
+
+		templateRepo := new(MockEmailTemplateRepository)
+		templateRepo.On("Get", ctx, org.ID, mock.Anything).Return(nil, nil)
+		templateRepo.On("GetBranding", ctx, org.ID).Return(&domain.EmailBranding{OrganizationID: org.ID}, nil)
+		templates := NewEmailTemplateService(templateRepo, orgs)
+
+		queue := &fakeNotificationQueue{}
+		return NewNotificationService(queue, NewNotificationPreferenceService(preferences, &fakeTxManager{}), inbox, templates, tickets, comments, statuses, users, orgs, &fakeTxManager{}), queue, &added
+	}
+
+	recipients := func(queue *fakeNotificationQueue) []string {
+		var to []string
+		for _, msg := range queue.emails {
+			to = append(to, msg.To)
+		}
+		return to
+	}
+
+	t.Run("Ticket created acknowledges the reporter", func(t *testing.T) {
+		ticket := newTicket()
+		service, queue, _ := setup(ticket, nil, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{Event: domain.NotificationTicketCreated, TicketID: ticket.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{reporter.Email, staff.Email}, recipients(queue))
+		assert.Equal(t, "[St. Mark] We received your request: Leaky faucet"+" "+domain.TicketReference(ticket.ID), queue.emails[0].Subject)
+		assert.Equal(t, "[St. Mark] New ticket: Leaky faucet"+" "+domain.TicketReference(ticket.ID), queue.emails[1].Subject)
+
+		// The reporter is not a member, so their email leaves out who is
+		// working the ticket.
+		assert.NotContains(t, queue.emails[0].Body, "Assigned to:")
+		assert.Contains(t, queue.emails[1].Body, "Assigned to: Sam")
+		assert.NotEmpty(t, queue.emails[0].HTMLBody)
+	})
+
+	t.Run("Scheduled tickets only notify the assignee", func(t *testing.T) {
+		ticket := newTicket()
+		taskID := uuid.New()
+		ticket.ScheduledTaskID = &taskID
+		service, queue, _ := setup(ticket, nil, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{Event: domain.NotificationTicketCreated, TicketID: ticket.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{staff.Email}, recipients(queue))
+	})
+
+	t.Run("Assignment notifies the new assignee", func(t *testing.T) {
+		ticket := newTicket()
+		service, queue, _ := setup(ticket, nil, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{Event: domain.NotificationTicketAssigned, TicketID: ticket.ID, ActorID: &manager.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{staff.Email}, recipients(queue))
+		assert.Contains(t, queue.emails[0].Body, "Max assigned this ticket to you.")
+	})
+
+	t.Run("Status change skips the actor", func(t *testing.T) {
+		ticket := newTicket()
+		service, queue, _ := setup(ticket, nil, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{
+			Event:       domain.NotificationStatusChanged,
+			TicketID:    ticket.ID,
+			ActorID:     &staff.ID,
+			OldStatusID: domain.TicketStatusNew,
+			NewStatusID: domain.TicketStatusInProgress,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{reporter.Email}, recipients(queue))
+		assert.Equal(t, "[St. Mark] Status changed to In Progress: Leaky faucet"+" "+domain.TicketReference(ticket.ID), queue.emails[0].Subject)
+		assert.Contains(t, queue.emails[0].Body, "The status changed from New to In Progress.")
+	})
+
+	t.Run("Recipients who opted out are skipped", func(t *testing.T) {
+		ticket := newTicket()
+		service, queue, inbox := setup(ticket, nil, map[uuid.UUID]domain.NotificationPreferences{
+			reporter.ID: {{Event: domain.NotificationStatusChanged, Channel: domain.NotificationChannelNone}},
+		})
+
+		err := service.Dispatch(ctx, domain.Notification{
+			Event:       domain.NotificationStatusChanged,
+			TicketID:    ticket.ID,
+			ActorID:     &manager.ID,
+			OldStatusID: domain.TicketStatusNew,
+			NewStatusID: domain.TicketStatusInProgress,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{staff.Email}, recipients(queue))
+
+		// Preferences only govern external delivery; the inbox gets everything.
+		if assert.Len(t, *inbox, 2) {
+			assert.Equal(t, reporter.ID, (*inbox)[0].UserID)
+			assert.Equal(t, "Status changed from New to In Progress", (*inbox)[0].Summary)
+			assert.Equal(t, org.ID, (*inbox)[0].OrganizationID)
+		}
+	})
+
+	t.Run("SMS preference sends a text instead of an email", func(t *testing.T) {
+		ticket := newTicket()
+		ticket.AssigneeUserID = &manager.ID
+		service, queue, _ := setup(ticket, nil, map[uuid.UUID]domain.NotificationPreferences{
+			manager.ID: {{Event: domain.NotificationTicketAssigned, Channel: domain.NotificationChannelSMS}},
+		})
+
+		err := service.Dispatch(ctx, domain.Notification{Event: domain.NotificationTicketAssigned, TicketID: ticket.ID, ActorID: &staff.ID})
+		require.NoError(t, err)
+		assert.Empty(t, queue.emails)
+		assert.Equal(t, []domain.SMSMessage{{
+			To:   manager.Phone,
+			Body: "[St. Mark] Leaky faucet: Sam assigned this ticket to you",
+		}}, queue.texts)
+	})
+
+	t.Run("SMS preference without a phone number only reaches the inbox", func(t *testing.T) {
+		ticket := newTicket()
+		service, queue, inbox := setup(ticket, nil, map[uuid.UUID]domain.NotificationPreferences{
+			staff.ID: {{Event: domain.NotificationTicketAssigned, Channel: domain.NotificationChannelSMS}},
+		})
+
+		err := service.Dispatch(ctx, domain.Notification{Event: domain.NotificationTicketAssigned, TicketID: ticket.ID, ActorID: &manager.ID})
+		require.NoError(t, err)
+		assert.Empty(t, queue.emails)
+		assert.Empty(t, queue.texts)
+		assert.Len(t, *inbox, 1)
+	})
+
+	t.Run("Sensitive comments stay with members", func(t *testing.T) {
+		ticket := newTicket()
+		comment := &domain.Comment{ID: uuid.New(), TicketID: ticket.ID, UserID: manager.ID, Body: "Vendor quote is $400", Sensitive: true}
+		service, queue, inbox := setup(ticket, comment, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{
+			Event:     domain.NotificationTicketCommented,
+			TicketID:  ticket.ID,
+			ActorID:   &manager.ID,
+			CommentID: &comment.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{staff.Email}, recipients(queue))
+		if assert.Len(t, *inbox, 1) {
+			assert.Equal(t, staff.ID, (*inbox)[0].UserID)
+			assert.Equal(t, "Max commented: Vendor quote is $400", (*inbox)[0].Summary)
+		}
+		assert.Contains(t, queue.emails[0].Body, "Vendor quote is $400")
+	})
+
+	t.Run("Public comments reach the reporter", func(t *testing.T) {
+		ticket := newTicket()
+		comment := &domain.Comment{ID: uuid.New(), TicketID: ticket.ID, UserID: staff.ID, Body: "Plumber booked for Tuesday"}
+		service, queue, _ := setup(ticket, comment, nil)
+
+		err := service.Dispatch(ctx, domain.Notification{
+			Event:     domain.NotificationTicketCommented,
+			TicketID:  ticket.ID,
+			ActorID:   &staff.ID,
+			CommentID: &comment.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{reporter.Email}, recipients(queue))
+	})
+}
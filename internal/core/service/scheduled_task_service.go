@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,12 +11,34 @@ import (
 	"github.com/wsciaroni/opsdeck/internal/core/port"
 )
 
+// dueTaskBatchSize caps how many tasks a single RunDueTasks call processes.
+const dueTaskBatchSize = 100
+
 type ScheduledTaskService struct {
-	repo port.ScheduledTaskRepository
+	repo          port.ScheduledTaskRepository
+	orgRepo       port.OrganizationRepository
+	priorities    port.TicketPriorityRepository
+	categories    port.TicketCategoryRepository
+	ticketService port.TicketService
+	tx            port.TxManager
 }
 
-func NewScheduledTaskService(repo port.ScheduledTaskRepository) *ScheduledTaskService {
-	return &ScheduledTaskService{repo: repo}
+func NewScheduledTaskService(
+	repo port.ScheduledTaskRepository,
+	orgRepo port.OrganizationRepository,
+	priorities port.TicketPriorityRepository,
+	categories port.TicketCategoryRepository,
+	ticketService port.TicketService,
+	tx port.TxManager,
+) *ScheduledTaskService {
+	return &ScheduledTaskService{
+		repo:          repo,
+		orgRepo:       orgRepo,
+		priorities:    priorities,
+		categories:    categories,
+		ticketService: ticketService,
+		tx:            tx,
+	}
 }
 
 func (s *ScheduledTaskService) GetTask(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
@@ -27,21 +50,41 @@ func (s *ScheduledTaskService) ListTasks(ctx context.Context, organizationID uui
 }
 
 func (s *ScheduledTaskService) CreateTask(ctx context.Context, cmd port.CreateScheduledTaskCmd) (*domain.ScheduledTask, error) {
-	nextRun := calculateNextRun(cmd.StartDate, cmd.Frequency)
+	rule, err := resolveRRule(cmd.RRule, cmd.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPriority(ctx, s.priorities, cmd.OrganizationID, cmd.PriorityID); err != nil {
+		return nil, err
+	}
+	var categoryID *string
+	if cmd.CategoryID != nil && *cmd.CategoryID != "" {
+		if err := checkCategory(ctx, s.categories, cmd.OrganizationID, *cmd.CategoryID); err != nil {
+			return nil, err
+		}
+		categoryID = cmd.CategoryID
+	}
 
 	task := &domain.ScheduledTask{
 		OrganizationID: cmd.OrganizationID,
 		Title:          cmd.Title,
 		Description:    cmd.Description,
-		Frequency:      cmd.Frequency,
+		RRule:          rule.String(),
+		Frequency:      domain.FrequencyFromRRule(rule.String()),
 		StartDate:      cmd.StartDate,
-		NextRunAt:      nextRun,
 		CreatedBy:      cmd.CreatedBy,
 		AssigneeUserID: cmd.AssigneeUserID,
 		PriorityID:     cmd.PriorityID,
+		CategoryID:     categoryID,
 		Location:       cmd.Location,
 		Enabled:        cmd.Enabled,
+		Timezone:       org.Timezone,
 	}
+	scheduleNext(task, rule, time.Now())
 
 	if err := s.repo.Create(ctx, task); err != nil {
 		return nil, err
@@ -65,15 +108,41 @@ func (s *ScheduledTaskService) UpdateTask(ctx context.Context, id uuid.UUID, cmd
 	if cmd.Description != nil {
 		task.Description = *cmd.Description
 	}
-	if cmd.Frequency != nil {
-		task.Frequency = *cmd.Frequency
+	if cmd.RRule != nil || cmd.Frequency != nil {
+		var ruleStr string
+		var freq domain.Frequency
+		if cmd.RRule != nil {
+			ruleStr = *cmd.RRule
+		}
+		if cmd.Frequency != nil {
+			freq = *cmd.Frequency
+		}
+		rule, err := resolveRRule(ruleStr, freq)
+		if err != nil {
+			return nil, err
+		}
+		task.RRule = rule.String()
+		task.Frequency = domain.FrequencyFromRRule(task.RRule)
 	}
 	if cmd.StartDate != nil {
 		task.StartDate = *cmd.StartDate
 	}
-	if cmd.PriorityID != nil {
+	if cmd.PriorityID != nil && *cmd.PriorityID != task.PriorityID {
+		if err := checkPriority(ctx, s.priorities, task.OrganizationID, *cmd.PriorityID); err != nil {
+			return nil, err
+		}
 		task.PriorityID = *cmd.PriorityID
 	}
+	if cmd.CategoryID != nil {
+		if *cmd.CategoryID == "" {
+			task.CategoryID = nil
+		} else if task.CategoryID == nil || *cmd.CategoryID != *task.CategoryID {
+			if err := checkCategory(ctx, s.categories, task.OrganizationID, *cmd.CategoryID); err != nil {
+				return nil, err
+			}
+			task.CategoryID = cmd.CategoryID
+		}
+	}
 	if cmd.Location != nil {
 		task.Location = *cmd.Location
 	}
@@ -88,8 +157,12 @@ func (s *ScheduledTaskService) UpdateTask(ctx context.Context, id uuid.UUID, cmd
 		}
 	}
 
-	if cmd.Frequency != nil || cmd.StartDate != nil {
-		task.NextRunAt = calculateNextRun(task.StartDate, task.Frequency)
+	if cmd.RRule != nil || cmd.Frequency != nil || cmd.StartDate != nil {
+		rule, err := domain.ParseRRule(task.RRule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rrule: %w", err)
+		}
+		scheduleNext(task, rule, time.Now())
 	}
 
 	if err := s.repo.Update(ctx, task); err != nil {
@@ -103,25 +176,224 @@ func (s *ScheduledTaskService) DeleteTask(ctx context.Context, id uuid.UUID) err
 	return s.repo.Delete(ctx, id)
 }
 
-func calculateNextRun(start time.Time, freq domain.Frequency) time.Time {
-	now := time.Now()
-	next := start
+// RunDueTasks creates a ticket for every enabled task whose next run is at or
+// before now and advances the task to its following occurrence. Each task is
+// handled in its own transaction with the row locked, so concurrent runners
+// never generate the same occurrence twice and one failing task does not block
+// the others. Every attempt is recorded as a ScheduledTaskRun, and a failed
+// occurrence is skipped rather than retried. It returns the number of
+// tickets created.
+func (s *ScheduledTaskService) RunDueTasks(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.repo.ListDueIDs(ctx, now, dueTaskBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, id := range ids {
+		var run *domain.ScheduledTaskRun
+		err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			task, err := s.repo.GetForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			// Locked by another runner, deleted, disabled or already advanced.
+			if task == nil || !task.Enabled || task.NextRunAt.After(now) {
+				return nil
+			}
+
+			run = &domain.ScheduledTaskRun{
+				ScheduledTaskID: task.ID,
+				RunAt:           task.NextRunAt,
+			}
+			rule, err := domain.ParseRRule(task.RRule)
+			if err != nil {
+				return fmt.Errorf("invalid rrule: %w", err)
+			}
+
+			ticket, err := s.ticketService.CreateTicket(ctx, port.CreateTicketCmd{
+				OrganizationID:  task.OrganizationID,
+				ReporterID:      task.CreatedBy,
+				Title:           task.Title,
+				Description:     task.Description,
+				Location:        task.Location,
+				PriorityID:      task.PriorityID,
+				CategoryID:      task.CategoryID,
+				AssigneeUserID:  task.AssigneeUserID,
+				ScheduledTaskID: &task.ID,
+			})
+			if err != nil {
+				return err
+			}
 
-	if next.After(now) {
-		return next
+			run.TicketID = &ticket.ID
+			run.Outcome = domain.ScheduledTaskRunOutcomeCreated
+			if err := s.repo.CreateRun(ctx, run); err != nil {
+				return err
+			}
+
+			// Occurrences missed while the runner was down are skipped rather
+			// than replayed one by one.
+			scheduleNext(task, rule, now)
+			return s.repo.Update(ctx, task)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scheduled task %s: %w", id, err))
+			// The transaction rolled back, so record the failure on its own.
+			if run != nil {
+				if err := s.recordFailure(ctx, run, err, now); err != nil {
+					errs = append(errs, fmt.Errorf("scheduled task %s: %w", id, err))
+				}
+			}
+			continue
+		}
+		if run != nil {
+			created++
+		}
 	}
 
-	for !next.After(now) {
-		switch freq {
-		case domain.FrequencyDaily:
-			next = next.AddDate(0, 0, 1)
-		case domain.FrequencyWeekly:
-			next = next.AddDate(0, 0, 7)
-		case domain.FrequencyMonthly:
-			next = next.AddDate(0, 1, 0)
-		case domain.FrequencyYearly:
-			next = next.AddDate(1, 0, 0)
+	return created, errors.Join(errs...)
+}
+
+// recordFailure records a failed run and still advances the task past it.
+// Otherwise a task that keeps failing would be picked up again on every
+// poll, adding a run each time and crowding healthy tasks out of the due
+// batch. A task whose rule no longer parses can never run, so it is
+// disabled.
+func (s *ScheduledTaskService) recordFailure(ctx context.Context, run *domain.ScheduledTaskRun, cause error, now time.Time) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		run.TicketID = nil
+		run.Outcome = domain.ScheduledTaskRunOutcomeFailed
+		run.Error = cause.Error()
+		if err := s.repo.CreateRun(ctx, run); err != nil {
+			return err
+		}
+
+		task, err := s.repo.GetForUpdate(ctx, run.ScheduledTaskID)
+		if err != nil {
+			return err
+		}
+		if task == nil || !task.NextRunAt.Equal(run.RunAt) {
+			return nil
+		}
+		rule, err := domain.ParseRRule(task.RRule)
+		if err != nil {
+			task.Enabled = false
+		} else {
+			scheduleNext(task, rule, now)
+		}
+		return s.repo.Update(ctx, task)
+	})
+}
+
+// ListRuns returns the most recent runs of a task, newest first.
+func (s *ScheduledTaskService) ListRuns(ctx context.Context, taskID uuid.UUID, limit int) ([]domain.ScheduledTaskRun, error) {
+	return s.repo.ListRuns(ctx, taskID, limit)
+}
+
+// ListOccurrences computes up to limit upcoming runs of a saved task in the
+// range [from, to). A zero to means no upper bound.
+func (s *ScheduledTaskService) ListOccurrences(ctx context.Context, id uuid.UUID, from, to time.Time, limit int) (*domain.ScheduledTaskOccurrences, error) {
+	task, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("scheduled task not found")
+	}
+	rule, err := domain.ParseRRule(task.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+	return occurrences(task.StartDate, task.Timezone, rule, from, to, limit), nil
+}
+
+// PreviewOccurrences is the dry-run form of ListOccurrences for a task that
+// has not been saved yet.
+func (s *ScheduledTaskService) PreviewOccurrences(ctx context.Context, cmd port.CreateScheduledTaskCmd, from, to time.Time, limit int) (*domain.ScheduledTaskOccurrences, error) {
+	rule, err := resolveRRule(cmd.RRule, cmd.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return occurrences(cmd.StartDate, org.Timezone, rule, from, to, limit), nil
+}
+
+// UpdateOrganizationTimezone saves the organization's new timezone and, in the
+// same transaction, recomputes the next run of each enabled task in that zone.
+// Without this a stored next_run_at would still fire at the old local time.
+func (s *ScheduledTaskService) UpdateOrganizationTimezone(ctx context.Context, org *domain.Organization, timezone string) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		org.Timezone = timezone
+		if err := s.orgRepo.Update(ctx, org); err != nil {
+			return err
+		}
+
+		tasks, err := s.repo.List(ctx, org.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for i := range tasks {
+			task := &tasks[i]
+			if !task.Enabled {
+				continue
+			}
+			rule, err := domain.ParseRRule(task.RRule)
+			if err != nil {
+				return fmt.Errorf("scheduled task %s: invalid rrule: %w", task.ID, err)
+			}
+			task.Timezone = timezone
+			scheduleNext(task, rule, now)
+			if err := s.repo.Update(ctx, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func occurrences(start time.Time, timezone string, rule *domain.RRule, from, to time.Time, limit int) *domain.ScheduledTaskOccurrences {
+	loc := domain.LoadLocation(timezone)
+	return &domain.ScheduledTaskOccurrences{
+		Timezone:    loc.String(),
+		Occurrences: rule.Between(start.In(loc), from, to, limit),
+	}
+}
+
+// resolveRRule parses rule, falling back to the simple frequency shorthand
+// when rule is empty.
+func resolveRRule(rule string, freq domain.Frequency) (*domain.RRule, error) {
+	if rule == "" {
+		if freq == "" {
+			return nil, fmt.Errorf("rrule or frequency is required")
+		}
+		rule = freq.RRule()
+	}
+	r, err := domain.ParseRRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+	return r, nil
+}
+
+// scheduleNext moves the task to the first occurrence strictly after the given
+// time. The rule is evaluated in the organization's timezone so occurrences
+// keep their local time across DST changes. A task whose rule has no further
+// occurrences is disabled.
+func scheduleNext(task *domain.ScheduledTask, rule *domain.RRule, after time.Time) {
+	dtstart := task.StartDate.In(domain.LoadLocation(task.Timezone))
+	next, ok := rule.Next(dtstart, after)
+	if !ok {
+		task.Enabled = false
+		if task.NextRunAt.IsZero() {
+			task.NextRunAt = task.StartDate
 		}
+		return
 	}
-	return next
+	task.NextRunAt = next
 }
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockScheduledTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduledTaskRepository) Get(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledTask), args.Error(1)
+}
+
+func (m *MockScheduledTaskRepository) List(ctx context.Context, organizationID uuid.UUID) ([]domain.ScheduledTask, error) {
+	args := m.Called(ctx, organizationID)
+	return args.Get(0).([]domain.ScheduledTask), args.Error(1)
+}
+
+func (m *MockScheduledTaskRepository) Create(ctx context.Context, task *domain.ScheduledTask) error {
+	return m.Called(ctx, task).Error(0)
+}
+
+func (m *MockScheduledTaskRepository) Update(ctx context.Context, task *domain.ScheduledTask) error {
+	return m.Called(ctx, task).Error(0)
+}
+
+func (m *MockScheduledTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockScheduledTaskRepository) ListDueIDs(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockScheduledTaskRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.ScheduledTask, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledTask), args.Error(1)
+}
+
+func (m *MockScheduledTaskRepository) CreateRun(ctx context.Context, run *domain.ScheduledTaskRun) error {
+	return m.Called(ctx, run).Error(0)
+}
+
+func (m *MockScheduledTaskRepository) ListRuns(ctx context.Context, taskID uuid.UUID, limit int) ([]domain.ScheduledTaskRun, error) {
+	args := m.Called(ctx, taskID, limit)
+	return args.Get(0).([]domain.ScheduledTaskRun), args.Error(1)
+}
+
+type MockTicketService struct {
+	mock.Mock
+}
+
+func (m *MockTicketService) CreateTicket(ctx context.Context, cmd port.CreateTicketCmd) (*domain.Ticket, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port.UpdateTicketCmd) (*domain.Ticket, error) {
+	args := m.Called(ctx, id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) ListTickets(ctx context.Context, filter port.TicketFilter) ([]domain.Ticket, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketService) GetTicketFile(ctx context.Context, id uuid.UUID) (*domain.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.File), args.Error(1)
+}
+
+func (m *MockTicketService) ListTicketFiles(ctx context.Context, ticketID uuid.UUID) ([]domain.File, error) {
+	args := m.Called(ctx, ticketID)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func (m *MockTicketService) ListTicketEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error) {
+	args := m.Called(ctx, ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TicketEvent), args.Error(1)
+}
+
+// fakeTxManager runs the unit of work inline and counts invocations.
+type fakeTxManager struct {
+	calls int
+}
+
+func (f *fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	return fn(ctx)
+}
+
+func TestScheduleNext(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		rule     string
+		timezone string
+		expected time.Time
+		enabled  bool
+	}{
+		{"future start is returned as is", now.Add(time.Hour), "FREQ=DAILY", "", now.Add(time.Hour), true},
+		{"daily skips missed days", time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), "FREQ=DAILY", "", time.Date(2025, 3, 11, 8, 0, 0, 0, time.UTC), true},
+		{"weekly", time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC), "FREQ=WEEKLY", "", time.Date(2025, 3, 17, 8, 0, 0, 0, time.UTC), true},
+		{"monthly", time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC), "FREQ=MONTHLY", "", time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC), true},
+		{"yearly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), "FREQ=YEARLY", "", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{"first monday", time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), "FREQ=MONTHLY;BYDAY=1MO", "", time.Date(2025, 4, 7, 9, 0, 0, 0, time.UTC), true},
+		{"exhausted rule disables the task", time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=5", "", time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), false},
+		// 8am EST on March 8th; after the switch it is 8am EDT, an hour earlier in UTC.
+		{"keeps local time across DST", time.Date(2025, 3, 8, 13, 0, 0, 0, time.UTC), "FREQ=DAILY", "America/New_York", time.Date(2025, 3, 11, 12, 0, 0, 0, time.UTC), true},
+		{"unknown timezone falls back to UTC", time.Date(2025, 3, 8, 13, 0, 0, 0, time.UTC), "FREQ=DAILY", "Mars/Olympus", time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := domain.ParseRRule(tt.rule)
+			assert.NoError(t, err)
+
+			task := &domain.ScheduledTask{StartDate: tt.start, Timezone: tt.timezone, Enabled: true}
+			scheduleNext(task, rule, now)
+			assert.True(t, tt.expected.Equal(task.NextRunAt), "expected %s, got %s", tt.expected, task.NextRunAt)
+			assert.Equal(t, tt.enabled, task.Enabled)
+		})
+	}
+}
+
+func TestCreateTask(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	orgID := uuid.New()
+
+	t.Run("Frequency shorthand is stored as an rrule", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		orgRepo := new(MockOrganizationRepository)
+		orgRepo.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+		priorities := new(MockTicketPriorityRepository)
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		service := NewScheduledTaskService(repo, orgRepo, priorities, new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		repo.On("Create", ctx, mock.MatchedBy(func(st *domain.ScheduledTask) bool {
+			return st.RRule == "FREQ=WEEKLY" && st.Frequency == domain.FrequencyWeekly && st.NextRunAt.Equal(start)
+		})).Return(nil)
+
+		_, err := service.CreateTask(ctx, port.CreateScheduledTaskCmd{OrganizationID: orgID, Frequency: domain.FrequencyWeekly, PriorityID: domain.TicketPriorityMedium, StartDate: start, Enabled: true})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RRule is stored in canonical form", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		orgRepo := new(MockOrganizationRepository)
+		orgRepo.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+		priorities := new(MockTicketPriorityRepository)
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		service := NewScheduledTaskService(repo, orgRepo, priorities, new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		repo.On("Create", ctx, mock.MatchedBy(func(st *domain.ScheduledTask) bool {
+			return st.RRule == "FREQ=MONTHLY;INTERVAL=3" && st.Frequency == domain.FrequencyCustom
+		})).Return(nil)
+
+		_, err := service.CreateTask(ctx, port.CreateScheduledTaskCmd{OrganizationID: orgID, RRule: "interval=3;freq=monthly", PriorityID: domain.TicketPriorityMedium, StartDate: start, Enabled: true})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid rrule is rejected", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		orgRepo := new(MockOrganizationRepository)
+		orgRepo.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+		service := NewScheduledTaskService(repo, orgRepo, new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		_, err := service.CreateTask(ctx, port.CreateScheduledTaskCmd{OrganizationID: orgID, RRule: "FREQ=HOURLY", StartDate: start})
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown priority is rejected", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		orgRepo := new(MockOrganizationRepository)
+		orgRepo.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+		priorities := new(MockTicketPriorityRepository)
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		service := NewScheduledTaskService(repo, orgRepo, priorities, new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		_, err := service.CreateTask(ctx, port.CreateScheduledTaskCmd{OrganizationID: orgID, Frequency: domain.FrequencyWeekly, PriorityID: "urgent", StartDate: start})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRunDueTasks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Creates ticket and advances next run", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		tickets := new(MockTicketService)
+		tx := &fakeTxManager{}
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), tickets, tx)
+
+		assignee := uuid.New()
+		ticketID := uuid.New()
+		task := &domain.ScheduledTask{
+			ID:             uuid.New(),
+			OrganizationID: uuid.New(),
+			CreatedBy:      uuid.New(),
+			Title:          "Replace HVAC filter",
+			Description:    "Both units",
+			Location:       "Basement",
+			PriorityID:     domain.TicketPriorityMedium,
+			AssigneeUserID: &assignee,
+			RRule:          "FREQ=MONTHLY",
+			StartDate:      time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			NextRunAt:      time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+			Enabled:        true,
+		}
+
+		repo.On("ListDueIDs", ctx, now, dueTaskBatchSize).Return([]uuid.UUID{task.ID}, nil)
+		repo.On("GetForUpdate", ctx, task.ID).Return(task, nil)
+		tickets.On("CreateTicket", ctx, port.CreateTicketCmd{
+			OrganizationID:  task.OrganizationID,
+			ReporterID:      task.CreatedBy,
+			Title:           task.Title,
+			Description:     task.Description,
+			Location:        task.Location,
+			PriorityID:      task.PriorityID,
+			AssigneeUserID:  &assignee,
+			ScheduledTaskID: &task.ID,
+		}).Return(&domain.Ticket{ID: ticketID}, nil)
+		repo.On("CreateRun", ctx, mock.MatchedBy(func(run *domain.ScheduledTaskRun) bool {
+			return run.ScheduledTaskID == task.ID &&
+				run.RunAt.Equal(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)) &&
+				run.TicketID != nil && *run.TicketID == ticketID &&
+				run.Outcome == domain.ScheduledTaskRunOutcomeCreated
+		})).Return(nil)
+		repo.On("Update", ctx, mock.MatchedBy(func(st *domain.ScheduledTask) bool {
+			return st.NextRunAt.Equal(time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC))
+		})).Return(nil)
+
+		created, err := service.RunDueTasks(ctx, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 1, tx.calls)
+		repo.AssertExpectations(t)
+		tickets.AssertExpectations(t)
+	})
+
+	t.Run("Skips tasks claimed by another runner", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		tickets := new(MockTicketService)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), tickets, &fakeTxManager{})
+
+		id := uuid.New()
+		repo.On("ListDueIDs", ctx, now, dueTaskBatchSize).Return([]uuid.UUID{id}, nil)
+		repo.On("GetForUpdate", ctx, id).Return(nil, nil)
+
+		created, err := service.RunDueTasks(ctx, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skips tasks already advanced", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		tickets := new(MockTicketService)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), tickets, &fakeTxManager{})
+
+		task := &domain.ScheduledTask{
+			ID:        uuid.New(),
+			RRule:     "FREQ=DAILY",
+			NextRunAt: now.Add(time.Hour),
+			Enabled:   true,
+		}
+		repo.On("ListDueIDs", ctx, now, dueTaskBatchSize).Return([]uuid.UUID{task.ID}, nil)
+		repo.On("GetForUpdate", ctx, task.ID).Return(task, nil)
+
+		created, err := service.RunDueTasks(ctx, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		tickets.AssertNotCalled(t, "CreateTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure in one task does not stop the others and is recorded", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		tickets := new(MockTicketService)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), tickets, &fakeTxManager{})
+
+		bad := &domain.ScheduledTask{ID: uuid.New(), Title: "Bad", PriorityID: "bogus", RRule: "FREQ=DAILY", StartDate: now, NextRunAt: now, Enabled: true}
+		good := &domain.ScheduledTask{ID: uuid.New(), Title: "Good", PriorityID: domain.TicketPriorityLow, RRule: "FREQ=DAILY", StartDate: now, NextRunAt: now, Enabled: true}
+
+		repo.On("ListDueIDs", ctx, now, dueTaskBatchSize).Return([]uuid.UUID{bad.ID, good.ID}, nil)
+		repo.On("GetForUpdate", ctx, bad.ID).Return(bad, nil)
+		repo.On("GetForUpdate", ctx, good.ID).Return(good, nil)
+		tickets.On("CreateTicket", ctx, mock.MatchedBy(func(cmd port.CreateTicketCmd) bool { return cmd.Title == "Bad" })).
+			Return(nil, errors.New("invalid priority: bogus"))
+		tickets.On("CreateTicket", ctx, mock.MatchedBy(func(cmd port.CreateTicketCmd) bool { return cmd.Title == "Good" })).
+			Return(&domain.Ticket{ID: uuid.New()}, nil)
+		repo.On("Update", ctx, good).Return(nil)
+		repo.On("Update", ctx, bad).Return(nil)
+		repo.On("CreateRun", ctx, mock.MatchedBy(func(run *domain.ScheduledTaskRun) bool {
+			return run.ScheduledTaskID == good.ID && run.Outcome == domain.ScheduledTaskRunOutcomeCreated
+		})).Return(nil)
+		repo.On("CreateRun", ctx, mock.MatchedBy(func(run *domain.ScheduledTaskRun) bool {
+			return run.ScheduledTaskID == bad.ID && run.Outcome == domain.ScheduledTaskRunOutcomeFailed &&
+				run.TicketID == nil && run.Error != ""
+		})).Return(nil)
+
+		created, err := service.RunDueTasks(ctx, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), bad.ID.String())
+		assert.Equal(t, 1, created)
+		assert.Equal(t, now.AddDate(0, 0, 1), bad.NextRunAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("A task that keeps failing is retried at its next occurrence, not every poll", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		tickets := new(MockTicketService)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), tickets, &fakeTxManager{})
+
+		task := &domain.ScheduledTask{ID: uuid.New(), Title: "Bad", PriorityID: "bogus", RRule: "FREQ=DAILY", StartDate: now, NextRunAt: now, Enabled: true}
+		repo.On("ListDueIDs", mock.Anything, mock.Anything, dueTaskBatchSize).Return([]uuid.UUID{task.ID}, nil)
+		repo.On("GetForUpdate", ctx, task.ID).Return(task, nil)
+		tickets.On("CreateTicket", ctx, mock.Anything).Return(nil, errors.New("invalid priority: bogus"))
+		repo.On("CreateRun", ctx, mock.Anything).Return(nil)
+		repo.On("Update", ctx, task).Return(nil)
+
+		for _, poll := range []time.Time{now, now.Add(time.Minute), now.Add(2 * time.Minute)} {
+			_, _ = service.RunDueTasks(ctx, poll)
+		}
+		repo.AssertNumberOfCalls(t, "CreateRun", 1)
+		tickets.AssertNumberOfCalls(t, "CreateTicket", 1)
+		assert.True(t, task.Enabled)
+		assert.Equal(t, now.AddDate(0, 0, 1), task.NextRunAt)
+	})
+
+	t.Run("A task whose rule no longer parses is disabled", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		task := &domain.ScheduledTask{ID: uuid.New(), RRule: "FREQ=SOMETIMES", StartDate: now, NextRunAt: now, Enabled: true}
+		repo.On("ListDueIDs", ctx, now, dueTaskBatchSize).Return([]uuid.UUID{task.ID}, nil)
+		repo.On("GetForUpdate", ctx, task.ID).Return(task, nil)
+		repo.On("CreateRun", ctx, mock.MatchedBy(func(run *domain.ScheduledTaskRun) bool {
+			return run.Outcome == domain.ScheduledTaskRunOutcomeFailed
+		})).Return(nil)
+		repo.On("Update", ctx, task).Return(nil)
+
+		_, err := service.RunDueTasks(ctx, now)
+		assert.Error(t, err)
+		assert.False(t, task.Enabled)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOccurrences(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Saved task is evaluated in its organization's timezone", func(t *testing.T) {
+		repo := new(MockScheduledTaskRepository)
+		service := NewScheduledTaskService(repo, new(MockOrganizationRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		task := &domain.ScheduledTask{
+			ID:        uuid.New(),
+			RRule:     "FREQ=WEEKLY;BYDAY=SA",
+			StartDate: time.Date(2025, 1, 4, 14, 0, 0, 0, time.UTC), // 9am EST
+			Timezone:  "America/New_York",
+		}
+		repo.On("Get", ctx, task.ID).Return(task, nil)
+
+		got, err := service.ListOccurrences(ctx, task.ID, from, time.Time{}, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "America/New_York", got.Timezone)
+		assert.Len(t, got.Occurrences, 3)
+		for _, occ := range got.Occurrences {
+			assert.Equal(t, 9, occ.Hour())
+			assert.Equal(t, time.Saturday, occ.Weekday())
+		}
+		// The DST switch on March 9th moves the run from 14:00 to 13:00 UTC.
+		assert.Equal(t, 14, got.Occurrences[1].UTC().Hour())
+		assert.Equal(t, 13, got.Occurrences[2].UTC().Hour())
+	})
+
+	t.Run("Dry run uses the command's rule", func(t *testing.T) {
+		orgRepo := new(MockOrganizationRepository)
+		service := NewScheduledTaskService(new(MockScheduledTaskRepository), orgRepo, new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), new(MockTicketService), &fakeTxManager{})
+
+		orgID := uuid.New()
+		orgRepo.On("GetByID", ctx, orgID).Return(&domain.Organization{ID: orgID, Timezone: "UTC"}, nil)
+
+		got, err := service.PreviewOccurrences(ctx, port.CreateScheduledTaskCmd{
+			OrganizationID: orgID,
+			RRule:          "FREQ=MONTHLY;BYMONTHDAY=-1",
+			StartDate:      time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC),
+		}, from, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 3, 31, 8, 0, 0, 0, time.UTC),
+			time.Date(2025, 4, 30, 8, 0, 0, 0, time.UTC),
+			time.Date(2025, 5, 31, 8, 0, 0, 0, time.UTC),
+		}, got.Occurrences)
+	})
+}
+
+func TestUpdateOrganizationTimezone(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	repo := new(MockScheduledTaskRepository)
+	orgRepo := new(MockOrganizationRepository)
+	tx := &fakeTxManager{}
+	service := NewScheduledTaskService(repo, orgRepo, new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), new(MockTicketService), tx)
+
+	stale := time.Now().Add(time.Hour).Truncate(time.Second)
+	enabled := domain.ScheduledTask{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		RRule:          "FREQ=DAILY",
+		StartDate:      time.Date(2025, 1, 4, 14, 0, 0, 0, time.UTC), // 9am EST
+		NextRunAt:      stale,
+		Enabled:        true,
+		Timezone:       "UTC",
+	}
+	disabled := enabled
+	disabled.ID = uuid.New()
+	disabled.Enabled = false
+
+	org := &domain.Organization{ID: orgID, Timezone: "UTC"}
+	orgRepo.On("Update", ctx, mock.MatchedBy(func(o *domain.Organization) bool {
+		return o.Timezone == "America/New_York"
+	})).Return(nil)
+	repo.On("List", ctx, orgID).Return([]domain.ScheduledTask{enabled, disabled}, nil)
+	repo.On("Update", ctx, mock.MatchedBy(func(st *domain.ScheduledTask) bool {
+		return st.ID == enabled.ID
+	})).Return(nil).Run(func(args mock.Arguments) {
+		st := args.Get(1).(*domain.ScheduledTask)
+		assert.Equal(t, "America/New_York", st.Timezone)
+		assert.True(t, st.NextRunAt.After(time.Now()))
+		// The next run keeps 9am local time in the new zone, whatever the season.
+		assert.Equal(t, 9, st.NextRunAt.In(ny).Hour())
+	})
+
+	err = service.UpdateOrganizationTimezone(ctx, org, "America/New_York")
+	assert.NoError(t, err)
+	assert.Equal(t, "America/New_York", org.Timezone)
+	assert.Equal(t, 1, tx.calls)
+	orgRepo.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+}
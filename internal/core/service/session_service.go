@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	// SessionIdleTimeout is how long a session survives without being used.
+	// Every use pushes its expiry out again.
+	SessionIdleTimeout = 7 * 24 * time.Hour
+	// SessionMaxAge bounds a session however often it is used.
+	SessionMaxAge = 30 * 24 * time.Hour
+	// sessionTouchInterval limits how often a busy session's last use is
+	// written back.
+	sessionTouchInterval = time.Minute
+	maxUserAgentLength   = 512
+)
+
+// SessionService implements port.SessionService.
+type SessionService struct {
+	repo  port.SessionRepository
+	users port.UserRepository
+	now   func() time.Time
+}
+
+// NewSessionService creates a new SessionService.
+func NewSessionService(repo port.SessionRepository, users port.UserRepository) *SessionService {
+	return &SessionService{repo: repo, users: users, now: time.Now}
+}
+
+// Create starts a session for the user. The returned token is random and
+// only its hash is stored.
+func (s *SessionService) Create(ctx context.Context, userID uuid.UUID, info port.SessionInfo) (string, *domain.Session, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+
+	userAgent := info.UserAgent
+	if r := []rune(userAgent); len(r) > maxUserAgentLength {
+		userAgent = string(r[:maxUserAgentLength])
+	}
+	now := s.now()
+	session := &domain.Session{
+		UserID:     userID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(SessionIdleTimeout),
+		IPAddress:  info.IPAddress,
+		UserAgent:  userAgent,
+	}
+	if err := s.repo.Create(ctx, session, hashToken(token)); err != nil {
+		return "", nil, err
+	}
+	return token, session, nil
+}
+
+// Authenticate looks the token up and, if the session is still live, slides
+// its expiry forward, capped at SessionMaxAge from when it was created.
+func (s *SessionService) Authenticate(ctx context.Context, token string) (*domain.User, *domain.Session, error) {
+	session, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := s.now()
+	if session == nil || !now.Before(session.ExpiresAt) {
+		return nil, nil, nil
+	}
+
+	user, err := s.users.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, nil
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		expiresAt := now.Add(SessionIdleTimeout)
+		if maxExpiry := session.CreatedAt.Add(SessionMaxAge); expiresAt.After(maxExpiry) {
+			expiresAt = maxExpiry
+		}
+		if err := s.repo.Touch(ctx, session.ID, now, expiresAt); err != nil {
+			return nil, nil, err
+		}
+		session.LastSeenAt = now
+		session.ExpiresAt = expiresAt
+	}
+	return user, session, nil
+}
+
+// List returns the user's live sessions, most recently used first.
+func (s *SessionService) List(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	return s.repo.ListByUser(ctx, userID, s.now())
+}
+
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ok, err := s.repo.Delete(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return port.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *SessionService) RevokeOthers(ctx context.Context, userID, keep uuid.UUID) (int64, error) {
+	return s.repo.DeleteByUser(ctx, userID, keep)
+}
+
+// RevokeToken ends the session the token belongs to, if any.
+func (s *SessionService) RevokeToken(ctx context.Context, token string) error {
+	session, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil || session == nil {
+		return err
+	}
+	_, err = s.repo.Delete(ctx, session.UserID, session.ID)
+	return err
+}
+
+// PurgeExpired deletes sessions that expired before now.
+func (s *SessionService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteExpired(ctx, now)
+}
+
+// newToken returns 32 random bytes encoded for use in a cookie or header.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is what is stored in place of a session or API key token.
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// fakeSessionRepository keeps sessions in memory, keyed by token hash.
+type fakeSessionRepository struct {
+	sessions map[string]*domain.Session
+}
+
+func newFakeSessionRepository() *fakeSessionRepository {
+	return &fakeSessionRepository{sessions: map[string]*domain.Session{}}
+}
+
+func (r *fakeSessionRepository) Create(ctx context.Context, session *domain.Session, tokenHash []byte) error {
+	session.ID = uuid.New()
+	copied := *session
+	r.sessions[string(tokenHash)] = &copied
+	return nil
+}
+
+func (r *fakeSessionRepository) GetByTokenHash(ctx context.Context, tokenHash []byte) (*domain.Session, error) {
+	s, ok := r.sessions[string(tokenHash)]
+	if !ok {
+		return nil, nil
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (r *fakeSessionRepository) ListByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	var sessions []domain.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.ExpiresAt.After(now) {
+			sessions = append(sessions, *s)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *fakeSessionRepository) Touch(ctx context.Context, id uuid.UUID, lastSeenAt, expiresAt time.Time) error {
+	for _, s := range r.sessions {
+		if s.ID == id {
+			s.LastSeenAt = lastSeenAt
+			s.ExpiresAt = expiresAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) Delete(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	for hash, s := range r.sessions {
+		if s.ID == id && s.UserID == userID {
+			delete(r.sessions, hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeSessionRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, keep uuid.UUID) (int64, error) {
+	var n int64
+	for hash, s := range r.sessions {
+		if s.UserID == userID && s.ID != keep {
+			delete(r.sessions, hash)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeSessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var n int64
+	for hash, s := range r.sessions {
+		if !s.ExpiresAt.After(now) {
+			delete(r.sessions, hash)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestSessionService(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "sam@example.com"}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func() (*SessionService, *fakeSessionRepository, *time.Time) {
+		repo := newFakeSessionRepository()
+		users := new(MockUserRepository)
+		users.On("GetByID", ctx, user.ID).Return(user, nil)
+		now := start
+		service := NewSessionService(repo, users)
+		service.now = func() time.Time { return now }
+		return service, repo, &now
+	}
+
+	t.Run("Tokens are opaque and stored hashed", func(t *testing.T) {
+		service, repo, _ := setup()
+
+		token, session, err := service.Create(ctx, user.ID, port.SessionInfo{IPAddress: "203.0.113.7", UserAgent: "Firefox"})
+		require.NoError(t, err)
+		assert.NotContains(t, token, user.ID.String())
+		assert.Len(t, token, 43)
+		assert.Equal(t, start.Add(SessionIdleTimeout), session.ExpiresAt)
+		for hash := range repo.sessions {
+			assert.NotEqual(t, token, hash)
+		}
+
+		got, gotSession, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, user, got)
+		assert.Equal(t, "203.0.113.7", gotSession.IPAddress)
+
+		got, _, err = service.Authenticate(ctx, token+"x")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Use slides the expiry up to the maximum age", func(t *testing.T) {
+		service, _, now := setup()
+		token, _, err := service.Create(ctx, user.ID, port.SessionInfo{})
+		require.NoError(t, err)
+
+		// Used every six days, the session lives until its maximum age.
+		for *now = start; now.Before(start.Add(SessionMaxAge)); *now = now.Add(6 * 24 * time.Hour) {
+			got, _, err := service.Authenticate(ctx, token)
+			require.NoError(t, err)
+			require.NotNil(t, got, "session ended early at %s", now)
+		}
+		*now = start.Add(SessionMaxAge)
+		got, _, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Idle sessions expire", func(t *testing.T) {
+		service, _, now := setup()
+		token, _, err := service.Create(ctx, user.ID, port.SessionInfo{})
+		require.NoError(t, err)
+
+		*now = start.Add(SessionIdleTimeout)
+		got, _, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Revoked tokens stop working", func(t *testing.T) {
+		service, _, _ := setup()
+		token, _, err := service.Create(ctx, user.ID, port.SessionInfo{})
+		require.NoError(t, err)
+
+		require.NoError(t, service.RevokeToken(ctx, token))
+		got, _, err := service.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Signing out other devices keeps the current one", func(t *testing.T) {
+		service, _, _ := setup()
+		current, session, err := service.Create(ctx, user.ID, port.SessionInfo{UserAgent: "Laptop"})
+		require.NoError(t, err)
+		other, _, err := service.Create(ctx, user.ID, port.SessionInfo{UserAgent: "Phone"})
+		require.NoError(t, err)
+
+		n, err := service.RevokeOthers(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		got, _, err := service.Authenticate(ctx, current)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		got, _, err = service.Authenticate(ctx, other)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Users can only revoke their own sessions", func(t *testing.T) {
+		service, _, _ := setup()
+		_, session, err := service.Create(ctx, user.ID, port.SessionInfo{})
+		require.NoError(t, err)
+
+		err = service.Revoke(ctx, uuid.New(), session.ID)
+		assert.ErrorIs(t, err, port.ErrSessionNotFound)
+		require.NoError(t, service.Revoke(ctx, user.ID, session.ID))
+	})
+}
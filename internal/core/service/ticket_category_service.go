@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// TicketCategoryService implements port.TicketCategoryService.
+type TicketCategoryService struct {
+	repo port.TicketCategoryRepository
+	tx   port.TxManager
+}
+
+// NewTicketCategoryService creates a new TicketCategoryService.
+func NewTicketCategoryService(repo port.TicketCategoryRepository, tx port.TxManager) *TicketCategoryService {
+	return &TicketCategoryService{repo: repo, tx: tx}
+}
+
+// ListCategories returns the organization's categories ordered by label.
+func (s *TicketCategoryService) ListCategories(ctx context.Context, orgID uuid.UUID) (domain.TicketCategories, error) {
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+// CreateCategory adds a category to an organization.
+func (s *TicketCategoryService) CreateCategory(ctx context.Context, cmd port.CreateTicketCategoryCmd) (*domain.TicketCategory, error) {
+	category := &domain.TicketCategory{
+		OrganizationID: cmd.OrganizationID,
+		ID:             cmd.ID,
+		Label:          cmd.Label,
+		Description:    cmd.Description,
+	}
+	if category.ID == "" {
+		category.ID = domain.LookupIDFromLabel(cmd.Label)
+	}
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidTicketCategory, err)
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.ListByOrganization(ctx, cmd.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if existing.Find(category.ID) != nil {
+			return fmt.Errorf("%w: %q already exists", port.ErrInvalidTicketCategory, category.ID)
+		}
+		return s.repo.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory changes a category's label or description.
+func (s *TicketCategoryService) UpdateCategory(ctx context.Context, orgID uuid.UUID, id string, cmd port.UpdateTicketCategoryCmd) (*domain.TicketCategory, error) {
+	categories, err := s.repo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	category := categories.Find(id)
+	if category == nil {
+		return nil, port.ErrTicketCategoryNotFound
+	}
+
+	if cmd.Label != nil {
+		category.Label = *cmd.Label
+	}
+	if cmd.Description != nil {
+		category.Description = *cmd.Description
+	}
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidTicketCategory, err)
+	}
+
+	if err := s.repo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes a category that no ticket or scheduled task uses.
+func (s *TicketCategoryService) DeleteCategory(ctx context.Context, orgID uuid.UUID, id string) error {
+	return s.repo.Delete(ctx, orgID, id)
+}
+
+// checkCategory returns ErrInvalidTicketCategory unless id is one of the
+// organization's categories.
+func checkCategory(ctx context.Context, repo port.TicketCategoryRepository, orgID uuid.UUID, id string) error {
+	categories, err := repo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list ticket categories: %w", err)
+	}
+	if categories.Find(id) == nil {
+		return fmt.Errorf("%w: %s", port.ErrInvalidTicketCategory, id)
+	}
+	return nil
+}
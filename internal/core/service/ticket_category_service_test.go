@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockTicketCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTicketCategoryRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketCategories, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(domain.TicketCategories), args.Error(1)
+}
+
+func (m *MockTicketCategoryRepository) Create(ctx context.Context, category *domain.TicketCategory) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockTicketCategoryRepository) Update(ctx context.Context, category *domain.TicketCategory) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockTicketCategoryRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	args := m.Called(ctx, orgID, id)
+	return args.Error(0)
+}
+
+func testTicketCategories(orgID uuid.UUID) domain.TicketCategories {
+	return domain.TicketCategories{
+		{OrganizationID: orgID, ID: "electrical", Label: "Electrical"},
+		{OrganizationID: orgID, ID: "grounds", Label: "Grounds"},
+		{OrganizationID: orgID, ID: "plumbing", Label: "Plumbing"},
+	}
+}
+
+func TestCreateCategory(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Derives ID from label", func(t *testing.T) {
+		repo := new(MockTicketCategoryRepository)
+		svc := NewTicketCategoryService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(testTicketCategories(orgID), nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		category, err := svc.CreateCategory(ctx, port.CreateTicketCategoryCmd{
+			OrganizationID: orgID,
+			Label:          "HVAC & Heating",
+			Description:    "Furnaces, vents and thermostats",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hvac_heating", category.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate ID is rejected", func(t *testing.T) {
+		repo := new(MockTicketCategoryRepository)
+		svc := NewTicketCategoryService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(testTicketCategories(orgID), nil)
+
+		_, err := svc.CreateCategory(ctx, port.CreateTicketCategoryCmd{OrganizationID: orgID, Label: "Plumbing"})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketCategory)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Label is required", func(t *testing.T) {
+		repo := new(MockTicketCategoryRepository)
+		svc := NewTicketCategoryService(repo, &fakeTxManager{})
+
+		_, err := svc.CreateCategory(ctx, port.CreateTicketCategoryCmd{OrganizationID: orgID, ID: "misc"})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketCategory)
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Renames", func(t *testing.T) {
+		repo := new(MockTicketCategoryRepository)
+		svc := NewTicketCategoryService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(testTicketCategories(orgID), nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		label := "Landscaping"
+		category, err := svc.UpdateCategory(ctx, orgID, "grounds", port.UpdateTicketCategoryCmd{Label: &label})
+		require.NoError(t, err)
+		assert.Equal(t, "grounds", category.ID)
+		assert.Equal(t, "Landscaping", category.Label)
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		repo := new(MockTicketCategoryRepository)
+		svc := NewTicketCategoryService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(testTicketCategories(orgID), nil)
+
+		label := "Roofing"
+		_, err := svc.UpdateCategory(ctx, orgID, "roofing", port.UpdateTicketCategoryCmd{Label: &label})
+		assert.ErrorIs(t, err, port.ErrTicketCategoryNotFound)
+	})
+}
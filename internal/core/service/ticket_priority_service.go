@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// TicketPriorityService implements port.TicketPriorityService.
+type TicketPriorityService struct {
+	repo port.TicketPriorityRepository
+	tx   port.TxManager
+}
+
+// NewTicketPriorityService creates a new TicketPriorityService.
+func NewTicketPriorityService(repo port.TicketPriorityRepository, tx port.TxManager) *TicketPriorityService {
+	return &TicketPriorityService{repo: repo, tx: tx}
+}
+
+// ListPriorities returns the organization's priorities ordered by level.
+func (s *TicketPriorityService) ListPriorities(ctx context.Context, orgID uuid.UUID) (domain.TicketPriorities, error) {
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+// CreatePriority adds a priority to an organization.
+func (s *TicketPriorityService) CreatePriority(ctx context.Context, cmd port.CreateTicketPriorityCmd) (*domain.TicketPriority, error) {
+	priority := &domain.TicketPriority{
+		OrganizationID: cmd.OrganizationID,
+		ID:             cmd.ID,
+		Label:          cmd.Label,
+		Description:    cmd.Description,
+		Color:          cmd.Color,
+	}
+	if priority.ID == "" {
+		priority.ID = domain.LookupIDFromLabel(cmd.Label)
+	}
+	if err := priority.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidTicketPriority, err)
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.ListByOrganization(ctx, cmd.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if existing.Find(priority.ID) != nil {
+			return fmt.Errorf("%w: %q already exists", port.ErrInvalidTicketPriority, priority.ID)
+		}
+		if cmd.Level != nil {
+			priority.Level = *cmd.Level
+		} else if len(existing) > 0 {
+			priority.Level = existing[len(existing)-1].Level + 1
+		} else {
+			priority.Level = 1
+		}
+		return s.repo.Create(ctx, priority)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return priority, nil
+}
+
+// UpdatePriority changes a priority's label, level, description or color.
+func (s *TicketPriorityService) UpdatePriority(ctx context.Context, orgID uuid.UUID, id string, cmd port.UpdateTicketPriorityCmd) (*domain.TicketPriority, error) {
+	var priority *domain.TicketPriority
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		priorities, err := s.repo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		priority = priorities.Find(id)
+		if priority == nil {
+			return port.ErrTicketPriorityNotFound
+		}
+
+		if cmd.Label != nil {
+			priority.Label = *cmd.Label
+		}
+		if cmd.Level != nil {
+			priority.Level = *cmd.Level
+		}
+		if cmd.Description != nil {
+			priority.Description = *cmd.Description
+		}
+		if cmd.Color != nil {
+			priority.Color = *cmd.Color
+		}
+		if err := priority.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", port.ErrInvalidTicketPriority, err)
+		}
+
+		return s.repo.Update(ctx, priority)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return priority, nil
+}
+
+// DeletePriority removes a priority that no ticket or scheduled task uses. An
+// organization always keeps at least one priority so tickets can be created.
+func (s *TicketPriorityService) DeletePriority(ctx context.Context, orgID uuid.UUID, id string) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		priorities, err := s.repo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		if priorities.Find(id) == nil {
+			return port.ErrTicketPriorityNotFound
+		}
+		if len(priorities) == 1 {
+			return fmt.Errorf("%w: at least one priority is required", port.ErrInvalidTicketPriority)
+		}
+		return s.repo.Delete(ctx, orgID, id)
+	})
+}
+
+// checkPriority returns ErrInvalidTicketPriority unless id is one of the
+// organization's priorities.
+func checkPriority(ctx context.Context, repo port.TicketPriorityRepository, orgID uuid.UUID, id string) error {
+	priorities, err := repo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list ticket priorities: %w", err)
+	}
+	if priorities.Find(id) == nil {
+		return fmt.Errorf("%w: %s", port.ErrInvalidTicketPriority, id)
+	}
+	return nil
+}
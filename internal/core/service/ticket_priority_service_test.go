@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockTicketPriorityRepository struct {
+	mock.Mock
+}
+
+func (m *MockTicketPriorityRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketPriorities, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(domain.TicketPriorities), args.Error(1)
+}
+
+func (m *MockTicketPriorityRepository) Create(ctx context.Context, priority *domain.TicketPriority) error {
+	args := m.Called(ctx, priority)
+	return args.Error(0)
+}
+
+func (m *MockTicketPriorityRepository) Update(ctx context.Context, priority *domain.TicketPriority) error {
+	args := m.Called(ctx, priority)
+	return args.Error(0)
+}
+
+func (m *MockTicketPriorityRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	args := m.Called(ctx, orgID, id)
+	return args.Error(0)
+}
+
+// defaultTicketPriorities mirrors the seed in the ticket_priorities table.
+func defaultTicketPriorities(orgID uuid.UUID) domain.TicketPriorities {
+	return domain.TicketPriorities{
+		{OrganizationID: orgID, ID: domain.TicketPriorityLow, Label: "Low", Level: 1},
+		{OrganizationID: orgID, ID: domain.TicketPriorityMedium, Label: "Medium", Level: 2},
+		{OrganizationID: orgID, ID: domain.TicketPriorityHigh, Label: "High", Level: 3},
+		{OrganizationID: orgID, ID: domain.TicketPriorityCritical, Label: "Critical", Level: 4},
+	}
+}
+
+func TestCreatePriority(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Derives ID and places above the most urgent", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		priority, err := svc.CreatePriority(ctx, port.CreateTicketPriorityCmd{
+			OrganizationID: orgID,
+			Label:          "Life Safety",
+			Description:    "Respond within 15 minutes",
+			Color:          "#dc2626",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "life_safety", priority.ID)
+		assert.Equal(t, 5, priority.Level)
+		assert.Equal(t, "Respond within 15 minutes", priority.Description)
+	})
+
+	t.Run("Duplicate ID is rejected", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+
+		_, err := svc.CreatePriority(ctx, port.CreateTicketPriorityCmd{OrganizationID: orgID, Label: "High"})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid color is rejected", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+
+		_, err := svc.CreatePriority(ctx, port.CreateTicketPriorityCmd{OrganizationID: orgID, Label: "Urgent", Color: "red"})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "ListByOrganization", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdatePriority(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Changes level and description", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		level := 10
+		description := "Page the on-call technician"
+		priority, err := svc.UpdatePriority(ctx, orgID, domain.TicketPriorityCritical, port.UpdateTicketPriorityCmd{
+			Level:       &level,
+			Description: &description,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 10, priority.Level)
+		assert.Equal(t, description, priority.Description)
+		assert.Equal(t, "Critical", priority.Label)
+	})
+
+	t.Run("Unknown priority", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+
+		label := "Whenever"
+		_, err := svc.UpdatePriority(ctx, orgID, "someday", port.UpdateTicketPriorityCmd{Label: &label})
+		assert.ErrorIs(t, err, port.ErrTicketPriorityNotFound)
+	})
+}
+
+func TestDeletePriority(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Deletes", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		repo.On("Delete", ctx, orgID, domain.TicketPriorityLow).Return(nil)
+
+		assert.NoError(t, svc.DeletePriority(ctx, orgID, domain.TicketPriorityLow))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Keeps the last priority", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID)[:1], nil)
+
+		err := svc.DeletePriority(ctx, orgID, domain.TicketPriorityLow)
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("In use", func(t *testing.T) {
+		repo := new(MockTicketPriorityRepository)
+		svc := NewTicketPriorityService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		repo.On("Delete", ctx, orgID, domain.TicketPriorityHigh).Return(port.ErrTicketPriorityInUse)
+
+		err := svc.DeletePriority(ctx, orgID, domain.TicketPriorityHigh)
+		assert.True(t, errors.Is(err, port.ErrTicketPriorityInUse))
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -12,12 +13,24 @@ import (
 
 // TicketService implements business logic for ticket management.
 type TicketService struct {
-	repo port.TicketRepository
+	repo       port.TicketRepository
+	statuses   port.TicketStatusRepository
+	priorities port.TicketPriorityRepository
+	categories port.TicketCategoryRepository
+	notify     port.NotificationQueue
+	tx         port.TxManager
 }
 
 // NewTicketService creates a new TicketService.
-func NewTicketService(repo port.TicketRepository) *TicketService {
-	return &TicketService{repo: repo}
+func NewTicketService(
+	repo port.TicketRepository,
+	statuses port.TicketStatusRepository,
+	priorities port.TicketPriorityRepository,
+	categories port.TicketCategoryRepository,
+	notify port.NotificationQueue,
+	tx port.TxManager,
+) *TicketService {
+	return &TicketService{repo: repo, statuses: statuses, priorities: priorities, categories: categories, notify: notify, tx: tx}
 }
 
 // GetTicket retrieves a ticket by its ID.
@@ -25,38 +38,68 @@ func (s *TicketService) GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ti
 	return s.repo.GetByID(ctx, id)
 }
 
-// CreateTicket creates a new ticket.
+// CreateTicket creates a new ticket in its organization's first open status.
 func (s *TicketService) CreateTicket(ctx context.Context, cmd port.CreateTicketCmd) (*domain.Ticket, error) {
 	if cmd.Title == "" {
 		return nil, fmt.Errorf("title is required")
 	}
 
-	if !isValidPriority(cmd.PriorityID) {
-		return nil, fmt.Errorf("invalid priority: %s", cmd.PriorityID)
+	if err := checkPriority(ctx, s.priorities, cmd.OrganizationID, cmd.PriorityID); err != nil {
+		return nil, err
+	}
+
+	var categoryID *string
+	if cmd.CategoryID != nil && *cmd.CategoryID != "" {
+		if err := checkCategory(ctx, s.categories, cmd.OrganizationID, *cmd.CategoryID); err != nil {
+			return nil, err
+		}
+		categoryID = cmd.CategoryID
+	}
+
+	statuses, err := s.statuses.ListByOrganization(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket statuses: %w", err)
+	}
+	initial := statuses.Initial()
+	if initial == nil {
+		return nil, fmt.Errorf("organization has no open status")
 	}
 
 	ticket := &domain.Ticket{
-		OrganizationID: cmd.OrganizationID,
-		ReporterID:     cmd.ReporterID,
-		Title:          cmd.Title,
-		Description:    cmd.Description,
-		Location:       cmd.Location,
-		StatusID:       domain.TicketStatusNew,
-		PriorityID:     cmd.PriorityID,
-		Sensitive:      cmd.Sensitive,
-	}
-
-	if err := s.repo.Create(ctx, ticket); err != nil {
-		return nil, fmt.Errorf("failed to create ticket: %w", err)
-	}
-
-	for _, file := range cmd.Files {
-		file.TicketID = ticket.ID
-		if err := s.repo.AddFile(ctx, &file); err != nil {
-			// Log error but continue? Or fail?
-			// For now, let's return error, but the ticket is already created.
-			return nil, fmt.Errorf("failed to add file: %w", err)
+		OrganizationID:  cmd.OrganizationID,
+		ReporterID:      cmd.ReporterID,
+		Title:           cmd.Title,
+		Description:     cmd.Description,
+		Location:        cmd.Location,
+		StatusID:        initial.ID,
+		PriorityID:      cmd.PriorityID,
+		CategoryID:      categoryID,
+		AssigneeUserID:  cmd.AssigneeUserID,
+		ScheduledTaskID: cmd.ScheduledTaskID,
+		Sensitive:       cmd.Sensitive,
+	}
+
+	// The ticket, its files and the TicketCreated notification are stored
+	// together so a failed upload never leaves a half-created ticket behind.
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, ticket); err != nil {
+			return fmt.Errorf("failed to create ticket: %w", err)
+		}
+
+		for _, file := range cmd.Files {
+			file.TicketID = ticket.ID
+			if err := s.repo.AddFile(ctx, &file); err != nil {
+				return fmt.Errorf("failed to add file: %w", err)
+			}
 		}
+
+		return s.enqueue(ctx, domain.Notification{
+			Event:    domain.NotificationTicketCreated,
+			TicketID: ticket.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return ticket, nil
@@ -72,9 +115,25 @@ func (s *TicketService) ListTicketFiles(ctx context.Context, ticketID uuid.UUID)
 	return s.repo.ListFiles(ctx, ticketID)
 }
 
-// UpdateTicket updates an existing ticket.
+// UpdateTicket updates an existing ticket. Every changed field is recorded as
+// a TicketEvent in the same transaction as the update.
 func (s *TicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port.UpdateTicketCmd) (*domain.Ticket, error) {
-	ticket, err := s.repo.GetByID(ctx, id)
+	var ticket *domain.Ticket
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		ticket, err = s.updateTicket(ctx, id, cmd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) updateTicket(ctx context.Context, id uuid.UUID, cmd port.UpdateTicketCmd) (*domain.Ticket, error) {
+	// Lock the row so a concurrent update cannot diff against the same
+	// stale copy and overwrite this one.
+	ticket, err := s.repo.GetByIDForUpdate(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
@@ -82,7 +141,7 @@ func (s *TicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port
 		return nil, fmt.Errorf("ticket not found")
 	}
 
-	oldStatus := ticket.StatusID
+	before := *ticket
 
 	if cmd.Title != nil {
 		ticket.Title = *cmd.Title
@@ -93,12 +152,22 @@ func (s *TicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port
 	if cmd.Location != nil {
 		ticket.Location = *cmd.Location
 	}
-	if cmd.PriorityID != nil {
-		if !isValidPriority(*cmd.PriorityID) {
-			return nil, fmt.Errorf("invalid priority: %s", *cmd.PriorityID)
+	if cmd.PriorityID != nil && *cmd.PriorityID != ticket.PriorityID {
+		if err := checkPriority(ctx, s.priorities, ticket.OrganizationID, *cmd.PriorityID); err != nil {
+			return nil, err
 		}
 		ticket.PriorityID = *cmd.PriorityID
 	}
+	if cmd.CategoryID != nil {
+		if *cmd.CategoryID == "" {
+			ticket.CategoryID = nil
+		} else if ticket.CategoryID == nil || *cmd.CategoryID != *ticket.CategoryID {
+			if err := checkCategory(ctx, s.categories, ticket.OrganizationID, *cmd.CategoryID); err != nil {
+				return nil, err
+			}
+			ticket.CategoryID = cmd.CategoryID
+		}
+	}
 	if cmd.AssigneeUserID != nil {
 		if *cmd.AssigneeUserID == uuid.Nil {
 			ticket.AssigneeUserID = nil
@@ -111,19 +180,23 @@ func (s *TicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port
 		ticket.Sensitive = *cmd.Sensitive
 	}
 
-	if cmd.StatusID != nil {
-		if !isValidStatus(*cmd.StatusID) {
+	// CompletedAt follows the state the organization maps the status to:
+	// moving into a resolved status completes the ticket, moving into an open
+	// one reopens it.
+	if cmd.StatusID != nil && *cmd.StatusID != ticket.StatusID {
+		statuses, err := s.statuses.ListByOrganization(ctx, ticket.OrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ticket statuses: %w", err)
+		}
+		status := statuses.Find(*cmd.StatusID)
+		if status == nil {
 			return nil, fmt.Errorf("invalid status: %s", *cmd.StatusID)
 		}
-		ticket.StatusID = *cmd.StatusID
-	}
-
-	// Handle CompletedAt Logic
-	if oldStatus != ticket.StatusID {
-		if ticket.StatusID == domain.TicketStatusDone || ticket.StatusID == domain.TicketStatusCanceled {
+		ticket.StatusID = status.ID
+		if status.IsResolved() {
 			now := time.Now()
 			ticket.CompletedAt = &now
-		} else if oldStatus == domain.TicketStatusDone && ticket.StatusID == domain.TicketStatusNew {
+		} else {
 			ticket.CompletedAt = nil
 		}
 	}
@@ -134,31 +207,107 @@ func (s *TicketService) UpdateTicket(ctx context.Context, id uuid.UUID, cmd port
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
 	}
 
+	events, err := diffTicket(&before, ticket, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		if err := s.repo.CreateEvents(ctx, events); err != nil {
+			return nil, fmt.Errorf("failed to record ticket events: %w", err)
+		}
+	}
+
+	if err := s.notifyChanges(ctx, &before, ticket, cmd.ActorID); err != nil {
+		return nil, err
+	}
+
 	return ticket, nil
 }
 
-// ListTickets lists tickets based on the filter.
-func (s *TicketService) ListTickets(ctx context.Context, filter port.TicketFilter) ([]domain.Ticket, error) {
-	// Warning: OrganizationID can be nil (for Admin Export).
-	// The caller (Handler) is responsible for verifying proper authorization (e.g. RoleAdmin)
-	// before calling this with a nil org filter.
-	return s.repo.List(ctx, filter)
+// notifyChanges enqueues TicketAssigned and StatusChanged notifications for
+// an update. Unassigning a ticket notifies nobody.
+func (s *TicketService) notifyChanges(ctx context.Context, before, after *domain.Ticket, actorID *uuid.UUID) error {
+	if after.AssigneeUserID != nil && (before.AssigneeUserID == nil || *before.AssigneeUserID != *after.AssigneeUserID) {
+		if err := s.enqueue(ctx, domain.Notification{
+			Event:    domain.NotificationTicketAssigned,
+			TicketID: after.ID,
+			ActorID:  actorID,
+		}); err != nil {
+			return err
+		}
+	}
+	if before.StatusID != after.StatusID {
+		if err := s.enqueue(ctx, domain.Notification{
+			Event:       domain.NotificationStatusChanged,
+			TicketID:    after.ID,
+			ActorID:     actorID,
+			OldStatusID: before.StatusID,
+			NewStatusID: after.StatusID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func isValidPriority(p string) bool {
-	switch p {
-	case domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityCritical:
-		return true
-	default:
-		return false
+func (s *TicketService) enqueue(ctx context.Context, n domain.Notification) error {
+	if err := s.notify.Enqueue(ctx, n); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
 	}
+	return nil
+}
+
+// ListTicketEvents returns the audit trail of a ticket, oldest first.
+func (s *TicketService) ListTicketEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error) {
+	return s.repo.ListEvents(ctx, ticketID)
 }
 
-func isValidStatus(s string) bool {
-	switch s {
-	case domain.TicketStatusNew, domain.TicketStatusInProgress, domain.TicketStatusOnHold, domain.TicketStatusDone, domain.TicketStatusCanceled:
-		return true
-	default:
-		return false
+// diffTicket returns one field_changed event per audited field that differs
+// between before and after.
+func diffTicket(before, after *domain.Ticket, actorID *uuid.UUID) ([]domain.TicketEvent, error) {
+	fields := []struct {
+		name     string
+		old, new any
+	}{
+		{domain.TicketFieldStatus, before.StatusID, after.StatusID},
+		{domain.TicketFieldPriority, before.PriorityID, after.PriorityID},
+		{domain.TicketFieldCategory, before.CategoryID, after.CategoryID},
+		{domain.TicketFieldAssignee, before.AssigneeUserID, after.AssigneeUserID},
+		{domain.TicketFieldTitle, before.Title, after.Title},
+		{domain.TicketFieldDescription, before.Description, after.Description},
+		{domain.TicketFieldLocation, before.Location, after.Location},
+		{domain.TicketFieldSensitive, before.Sensitive, after.Sensitive},
 	}
+
+	var events []domain.TicketEvent
+	for _, f := range fields {
+		oldValue, err := json.Marshal(f.old)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", f.name, err)
+		}
+		newValue, err := json.Marshal(f.new)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", f.name, err)
+		}
+		if string(oldValue) == string(newValue) {
+			continue
+		}
+		events = append(events, domain.TicketEvent{
+			TicketID:  after.ID,
+			ActorID:   actorID,
+			EventType: domain.TicketEventFieldChanged,
+			Field:     f.name,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+		})
+	}
+	return events, nil
+}
+
+// ListTickets lists tickets based on the filter.
+func (s *TicketService) ListTickets(ctx context.Context, filter port.TicketFilter) ([]domain.Ticket, error) {
+	// Warning: OrganizationID can be nil (for Admin Export).
+	// The caller (Handler) is responsible for verifying proper authorization (e.g. RoleAdmin)
+	// before calling this with a nil org filter.
+	return s.repo.List(ctx, filter)
 }
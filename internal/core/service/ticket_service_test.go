@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockTicketRepository struct {
+	mock.Mock
+}
+
+func (m *MockTicketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepository) List(ctx context.Context, filter port.TicketFilter) ([]domain.Ticket, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Ticket), args.Error(1)
+}
+
+func (m *MockTicketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockTicketRepository) AddFile(ctx context.Context, file *domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockTicketRepository) GetFile(ctx context.Context, id uuid.UUID) (*domain.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.File), args.Error(1)
+}
+
+func (m *MockTicketRepository) ListFiles(ctx context.Context, ticketID uuid.UUID) ([]domain.File, error) {
+	args := m.Called(ctx, ticketID)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func (m *MockTicketRepository) CreateEvents(ctx context.Context, events []domain.TicketEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockTicketRepository) ListEvents(ctx context.Context, ticketID uuid.UUID) ([]domain.TicketEvent, error) {
+	args := m.Called(ctx, ticketID)
+	return args.Get(0).([]domain.TicketEvent), args.Error(1)
+}
+
+func TestUpdateTicket_RecordsEvents(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	assigneeID := uuid.New()
+
+	newTicket := func() *domain.Ticket {
+		return &domain.Ticket{
+			ID:         uuid.New(),
+			Title:      "Leaky faucet",
+			StatusID:   domain.TicketStatusNew,
+			PriorityID: domain.TicketPriorityLow,
+		}
+	}
+
+	t.Run("One event per changed field", func(t *testing.T) {
+		repo := new(MockTicketRepository)
+		statuses := new(MockTicketStatusRepository)
+		queue := &fakeNotificationQueue{}
+		tx := &fakeTxManager{}
+		service := NewTicketService(repo, statuses, new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), queue, tx)
+
+		ticket := newTicket()
+		status := domain.TicketStatusInProgress
+		title := "Leaky faucet" // unchanged
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+		statuses.On("ListByOrganization", ctx, ticket.OrganizationID).Return(defaultTicketStatuses(ticket.OrganizationID), nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		var recorded []domain.TicketEvent
+		repo.On("CreateEvents", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			recorded = args.Get(1).([]domain.TicketEvent)
+		}).Return(nil)
+
+		_, err := service.UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{
+			ActorID:        &actorID,
+			StatusID:       &status,
+			Title:          &title,
+			AssigneeUserID: &assigneeID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+
+		if assert.Len(t, recorded, 2) {
+			assert.Equal(t, domain.TicketFieldStatus, recorded[0].Field)
+			assert.JSONEq(t, `"new"`, string(recorded[0].OldValue))
+			assert.JSONEq(t, `"in_progress"`, string(recorded[0].NewValue))
+			assert.Equal(t, &actorID, recorded[0].ActorID)
+			assert.Equal(t, domain.TicketEventFieldChanged, recorded[0].EventType)
+
+			assert.Equal(t, domain.TicketFieldAssignee, recorded[1].Field)
+			assert.JSONEq(t, `null`, string(recorded[1].OldValue))
+			assert.JSONEq(t, `"`+assigneeID.String()+`"`, string(recorded[1].NewValue))
+		}
+
+		if assert.Len(t, queue.notifications, 2) {
+			assert.Equal(t, domain.NotificationTicketAssigned, queue.notifications[0].Event)
+			assert.Equal(t, &actorID, queue.notifications[0].ActorID)
+			assert.Equal(t, domain.NotificationStatusChanged, queue.notifications[1].Event)
+			assert.Equal(t, "new", queue.notifications[1].OldStatusID)
+			assert.Equal(t, "in_progress", queue.notifications[1].NewStatusID)
+		}
+	})
+
+	t.Run("No events when nothing changed", func(t *testing.T) {
+		repo := new(MockTicketRepository)
+		service := NewTicketService(repo, new(MockTicketStatusRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+
+		ticket := newTicket()
+		priority := domain.TicketPriorityLow
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		_, err := service.UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{ActorID: &actorID, PriorityID: &priority})
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CreateEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Event failure fails the update", func(t *testing.T) {
+		repo := new(MockTicketRepository)
+		service := NewTicketService(repo, new(MockTicketStatusRepository), new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+
+		ticket := newTicket()
+		sensitive := true
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+		repo.On("CreateEvents", ctx, mock.Anything).Return(errors.New("db down"))
+
+		_, err := service.UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{ActorID: &actorID, Sensitive: &sensitive})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateTicket_InitialStatus(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Starts in the first open status", func(t *testing.T) {
+		repo := new(MockTicketRepository)
+		statuses := new(MockTicketStatusRepository)
+		priorities := new(MockTicketPriorityRepository)
+		service := NewTicketService(repo, statuses, priorities, new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		statuses.On("ListByOrganization", ctx, orgID).Return(domain.TicketStatuses{
+			{OrganizationID: orgID, ID: "triage", Position: 1, State: domain.TicketStatusStateOpen},
+			{OrganizationID: orgID, ID: "new", Position: 2, State: domain.TicketStatusStateOpen},
+		}, nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		ticket, err := service.CreateTicket(ctx, port.CreateTicketCmd{
+			OrganizationID: orgID,
+			Title:          "Broken door",
+			PriorityID:     domain.TicketPriorityLow,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "triage", ticket.StatusID)
+	})
+
+	t.Run("Fails without an open status", func(t *testing.T) {
+		statuses := new(MockTicketStatusRepository)
+		priorities := new(MockTicketPriorityRepository)
+		service := NewTicketService(new(MockTicketRepository), statuses, priorities, new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		statuses.On("ListByOrganization", ctx, orgID).Return(domain.TicketStatuses{
+			{OrganizationID: orgID, ID: "done", Position: 1, State: domain.TicketStatusStateResolved},
+		}, nil)
+
+		_, err := service.CreateTicket(ctx, port.CreateTicketCmd{
+			OrganizationID: orgID,
+			Title:          "Broken door",
+			PriorityID:     domain.TicketPriorityLow,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateTicket_Status(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	orgStatuses := append(defaultTicketStatuses(orgID),
+		domain.TicketStatus{OrganizationID: orgID, ID: "waiting_on_parts", Position: 6, State: domain.TicketStatusStateOpen},
+		domain.TicketStatus{OrganizationID: orgID, ID: "wont_fix", Position: 7, State: domain.TicketStatusStateResolved},
+	)
+
+	setup := func(ticket *domain.Ticket) *TicketService {
+		repo := new(MockTicketRepository)
+		statuses := new(MockTicketStatusRepository)
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+		repo.On("CreateEvents", ctx, mock.Anything).Return(nil)
+		statuses.On("ListByOrganization", ctx, orgID).Return(orgStatuses, nil)
+		return NewTicketService(repo, statuses, new(MockTicketPriorityRepository), new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+	}
+
+	t.Run("Custom resolved status completes the ticket", func(t *testing.T) {
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, StatusID: "waiting_on_parts"}
+		status := "wont_fix"
+
+		updated, err := setup(ticket).UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{StatusID: &status})
+		assert.NoError(t, err)
+		assert.Equal(t, "wont_fix", updated.StatusID)
+		assert.NotNil(t, updated.CompletedAt)
+	})
+
+	t.Run("Open status reopens the ticket", func(t *testing.T) {
+		completed := time.Now()
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, StatusID: domain.TicketStatusDone, CompletedAt: &completed}
+		status := "waiting_on_parts"
+
+		updated, err := setup(ticket).UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{StatusID: &status})
+		assert.NoError(t, err)
+		assert.Nil(t, updated.CompletedAt)
+	})
+
+	t.Run("Unknown status is rejected", func(t *testing.T) {
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, StatusID: domain.TicketStatusNew}
+		status := "archived"
+
+		_, err := setup(ticket).UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{StatusID: &status})
+		assert.Error(t, err)
+	})
+}
+
+func TestTicketPriority(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	orgPriorities := append(defaultTicketPriorities(orgID),
+		domain.TicketPriority{OrganizationID: orgID, ID: "life_safety", Label: "Life Safety", Level: 5},
+	)
+
+	setup := func() (*MockTicketRepository, *TicketService) {
+		repo := new(MockTicketRepository)
+		statuses := new(MockTicketStatusRepository)
+		priorities := new(MockTicketPriorityRepository)
+		statuses.On("ListByOrganization", ctx, orgID).Return(defaultTicketStatuses(orgID), nil)
+		priorities.On("ListByOrganization", ctx, orgID).Return(orgPriorities, nil)
+		return repo, NewTicketService(repo, statuses, priorities, new(MockTicketCategoryRepository), &fakeNotificationQueue{}, &fakeTxManager{})
+	}
+
+	t.Run("Create accepts an organization priority", func(t *testing.T) {
+		repo, service := setup()
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		ticket, err := service.CreateTicket(ctx, port.CreateTicketCmd{OrganizationID: orgID, Title: "Gas smell", PriorityID: "life_safety"})
+		assert.NoError(t, err)
+		assert.Equal(t, "life_safety", ticket.PriorityID)
+	})
+
+	t.Run("Create rejects an unknown priority", func(t *testing.T) {
+		repo, service := setup()
+
+		_, err := service.CreateTicket(ctx, port.CreateTicketCmd{OrganizationID: orgID, Title: "Gas smell", PriorityID: "urgent"})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update rejects an unknown priority", func(t *testing.T) {
+		repo, service := setup()
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, StatusID: domain.TicketStatusNew, PriorityID: domain.TicketPriorityLow}
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+
+		priority := "urgent"
+		_, err := service.UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{PriorityID: &priority})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketPriority)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTicketCategory(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	plumbing := "plumbing"
+
+	setup := func() (*MockTicketRepository, *TicketService) {
+		repo := new(MockTicketRepository)
+		statuses := new(MockTicketStatusRepository)
+		priorities := new(MockTicketPriorityRepository)
+		categories := new(MockTicketCategoryRepository)
+		statuses.On("ListByOrganization", ctx, orgID).Return(defaultTicketStatuses(orgID), nil)
+		priorities.On("ListByOrganization", ctx, orgID).Return(defaultTicketPriorities(orgID), nil)
+		categories.On("ListByOrganization", ctx, orgID).Return(testTicketCategories(orgID), nil)
+		return repo, NewTicketService(repo, statuses, priorities, categories, &fakeNotificationQueue{}, &fakeTxManager{})
+	}
+
+	t.Run("Create stores the category", func(t *testing.T) {
+		repo, service := setup()
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		ticket, err := service.CreateTicket(ctx, port.CreateTicketCmd{
+			OrganizationID: orgID,
+			Title:          "Leaky faucet",
+			PriorityID:     domain.TicketPriorityLow,
+			CategoryID:     &plumbing,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &plumbing, ticket.CategoryID)
+	})
+
+	t.Run("Create without a category", func(t *testing.T) {
+		repo, service := setup()
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		empty := ""
+		ticket, err := service.CreateTicket(ctx, port.CreateTicketCmd{
+			OrganizationID: orgID,
+			Title:          "Leaky faucet",
+			PriorityID:     domain.TicketPriorityLow,
+			CategoryID:     &empty,
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, ticket.CategoryID)
+	})
+
+	t.Run("Create rejects an unknown category", func(t *testing.T) {
+		repo, service := setup()
+
+		roofing := "roofing"
+		_, err := service.CreateTicket(ctx, port.CreateTicketCmd{
+			OrganizationID: orgID,
+			Title:          "Missing shingles",
+			PriorityID:     domain.TicketPriorityLow,
+			CategoryID:     &roofing,
+		})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketCategory)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update clears the category and records it", func(t *testing.T) {
+		repo, service := setup()
+		ticket := &domain.Ticket{ID: uuid.New(), OrganizationID: orgID, StatusID: domain.TicketStatusNew, PriorityID: domain.TicketPriorityLow, CategoryID: &plumbing}
+		repo.On("GetByIDForUpdate", ctx, ticket.ID).Return(ticket, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		var recorded []domain.TicketEvent
+		repo.On("CreateEvents", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			recorded = args.Get(1).([]domain.TicketEvent)
+		}).Return(nil)
+
+		empty := ""
+		updated, err := service.UpdateTicket(ctx, ticket.ID, port.UpdateTicketCmd{CategoryID: &empty})
+		assert.NoError(t, err)
+		assert.Nil(t, updated.CategoryID)
+		if assert.Len(t, recorded, 1) {
+			assert.Equal(t, domain.TicketFieldCategory, recorded[0].Field)
+			assert.JSONEq(t, `"plumbing"`, string(recorded[0].OldValue))
+			assert.JSONEq(t, `null`, string(recorded[0].NewValue))
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// TicketStatusService implements port.TicketStatusService.
+type TicketStatusService struct {
+	repo port.TicketStatusRepository
+	tx   port.TxManager
+}
+
+// NewTicketStatusService creates a new TicketStatusService.
+func NewTicketStatusService(repo port.TicketStatusRepository, tx port.TxManager) *TicketStatusService {
+	return &TicketStatusService{repo: repo, tx: tx}
+}
+
+// ListStatuses returns the organization's statuses ordered by position.
+func (s *TicketStatusService) ListStatuses(ctx context.Context, orgID uuid.UUID) (domain.TicketStatuses, error) {
+	return s.repo.ListByOrganization(ctx, orgID)
+}
+
+// CreateStatus adds a status to an organization.
+func (s *TicketStatusService) CreateStatus(ctx context.Context, cmd port.CreateTicketStatusCmd) (*domain.TicketStatus, error) {
+	status := &domain.TicketStatus{
+		OrganizationID: cmd.OrganizationID,
+		ID:             cmd.ID,
+		Label:          cmd.Label,
+		Color:          cmd.Color,
+		State:          cmd.State,
+	}
+	if status.ID == "" {
+		status.ID = domain.LookupIDFromLabel(cmd.Label)
+	}
+	if err := status.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", port.ErrInvalidTicketStatus, err)
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.ListByOrganization(ctx, cmd.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if existing.Find(status.ID) != nil {
+			return fmt.Errorf("%w: %q already exists", port.ErrInvalidTicketStatus, status.ID)
+		}
+		if cmd.Position != nil {
+			status.Position = *cmd.Position
+		} else if len(existing) > 0 {
+			status.Position = existing[len(existing)-1].Position + 1
+		} else {
+			status.Position = 1
+		}
+		return s.repo.Create(ctx, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// UpdateStatus changes a status's label, position, color or state. An
+// organization always keeps at least one open status so new tickets have
+// somewhere to start. Tickets already in the status keep their CompletedAt
+// when its state changes.
+func (s *TicketStatusService) UpdateStatus(ctx context.Context, orgID uuid.UUID, id string, cmd port.UpdateTicketStatusCmd) (*domain.TicketStatus, error) {
+	var status *domain.TicketStatus
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		statuses, err := s.repo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		status = statuses.Find(id)
+		if status == nil {
+			return port.ErrTicketStatusNotFound
+		}
+
+		if cmd.Label != nil {
+			status.Label = *cmd.Label
+		}
+		if cmd.Position != nil {
+			status.Position = *cmd.Position
+		}
+		if cmd.Color != nil {
+			status.Color = *cmd.Color
+		}
+		if cmd.State != nil {
+			if status.State == domain.TicketStatusStateOpen && *cmd.State != domain.TicketStatusStateOpen &&
+				statuses.CountState(domain.TicketStatusStateOpen) == 1 {
+				return fmt.Errorf("%w: at least one open status is required", port.ErrInvalidTicketStatus)
+			}
+			status.State = *cmd.State
+		}
+		if err := status.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", port.ErrInvalidTicketStatus, err)
+		}
+
+		return s.repo.Update(ctx, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// DeleteStatus removes a status that no ticket uses.
+func (s *TicketStatusService) DeleteStatus(ctx context.Context, orgID uuid.UUID, id string) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		statuses, err := s.repo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		status := statuses.Find(id)
+		if status == nil {
+			return port.ErrTicketStatusNotFound
+		}
+		if status.State == domain.TicketStatusStateOpen && statuses.CountState(domain.TicketStatusStateOpen) == 1 {
+			return fmt.Errorf("%w: at least one open status is required", port.ErrInvalidTicketStatus)
+		}
+		return s.repo.Delete(ctx, orgID, id)
+	})
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+type MockTicketStatusRepository struct {
+	mock.Mock
+}
+
+func (m *MockTicketStatusRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) (domain.TicketStatuses, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(domain.TicketStatuses), args.Error(1)
+}
+
+func (m *MockTicketStatusRepository) Create(ctx context.Context, status *domain.TicketStatus) error {
+	args := m.Called(ctx, status)
+	return args.Error(0)
+}
+
+func (m *MockTicketStatusRepository) Update(ctx context.Context, status *domain.TicketStatus) error {
+	args := m.Called(ctx, status)
+	return args.Error(0)
+}
+
+func (m *MockTicketStatusRepository) Delete(ctx context.Context, orgID uuid.UUID, id string) error {
+	args := m.Called(ctx, orgID, id)
+	return args.Error(0)
+}
+
+// defaultTicketStatuses mirrors the seed in the ticket_statuses table.
+func defaultTicketStatuses(orgID uuid.UUID) domain.TicketStatuses {
+	return domain.TicketStatuses{
+		{OrganizationID: orgID, ID: domain.TicketStatusNew, Label: "New", Position: 1, State: domain.TicketStatusStateOpen},
+		{OrganizationID: orgID, ID: domain.TicketStatusInProgress, Label: "In Progress", Position: 2, State: domain.TicketStatusStateOpen},
+		{OrganizationID: orgID, ID: domain.TicketStatusOnHold, Label: "On Hold", Position: 3, State: domain.TicketStatusStateOpen},
+		{OrganizationID: orgID, ID: domain.TicketStatusDone, Label: "Done", Position: 4, State: domain.TicketStatusStateResolved},
+		{OrganizationID: orgID, ID: domain.TicketStatusCanceled, Label: "Canceled", Position: 5, State: domain.TicketStatusStateResolved},
+	}
+}
+
+func TestCreateStatus(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("Derives ID and appends", func(t *testing.T) {
+		repo := new(MockTicketStatusRepository)
+		svc := NewTicketStatusService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketStatuses(orgID), nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		status, err := svc.CreateStatus(ctx, port.CreateTicketStatusCmd{
+			OrganizationID: orgID,
+			Label:          "Waiting on Parts",
+			Color:          "#a855f7",
+			State:          domain.TicketStatusStateOpen,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "waiting_on_parts", status.ID)
+		assert.Equal(t, 6, status.Position)
+	})
+
+	t.Run("Rejects duplicates", func(t *testing.T) {
+		repo := new(MockTicketStatusRepository)
+		svc := NewTicketStatusService(repo, &fakeTxManager{})
+		repo.On("ListByOrganization", ctx, orgID).Return(defaultTicketStatuses(orgID), nil)
+
+		_, err := svc.CreateStatus(ctx, port.CreateTicketStatusCmd{
+			OrganizationID: orgID,
+			Label:          "Done",
+			State:          domain.TicketStatusStateResolved,
+		})
+		assert.ErrorIs(t, err, port.ErrInvalidTicketStatus)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects invalid fields", func(t *testing.T) {
+		svc := NewTicketStatusService(new(MockTicketStatusRepository), &fakeTxManager{})
+
+		tests := []port.CreateTicketStatusCmd{
+			{OrganizationID: orgID, Label: "", State: domain.TicketStatusStateOpen},
+			{OrganizationID: orgID, Label: "Blocked", State: "closed"},
+			{OrganizationID: orgID, Label: "Blocked", State: domain.TicketStatusStateOpen, Color: "red"},
+			{OrganizationID: orgID, ID: "Has Spaces", Label: "Blocked", State: domain.TicketStatusStateOpen},
+		}
+		for _, cmd := range tests {
+			_, err := svc.CreateStatus(ctx, cmd)
+			assert.ErrorIs(t, err, port.ErrInvalidTicketStatus)
+		}
+	})
+}
+
+func TestUpdateAndDeleteStatus_KeepsAnOpenStatus(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	statuses := domain.TicketStatuses{
+		{OrganizationID: orgID, ID: "open", Label: "Open", Position: 1, State: domain.TicketStatusStateOpen},
+		{OrganizationID: orgID, ID: "closed", Label: "Closed", Position: 2, State: domain.TicketStatusStateResolved},
+	}
+
+	repo := new(MockTicketStatusRepository)
+	svc := NewTicketStatusService(repo, &fakeTxManager{})
+	repo.On("ListByOrganization", ctx, orgID).Return(statuses, nil)
+
+	resolved := domain.TicketStatusStateResolved
+	_, err := svc.UpdateStatus(ctx, orgID, "open", port.UpdateTicketStatusCmd{State: &resolved})
+	assert.ErrorIs(t, err, port.ErrInvalidTicketStatus)
+
+	err = svc.DeleteStatus(ctx, orgID, "open")
+	assert.ErrorIs(t, err, port.ErrInvalidTicketStatus)
+
+	err = svc.DeleteStatus(ctx, orgID, "missing")
+	assert.ErrorIs(t, err, port.ErrTicketStatusNotFound)
+
+	repo.On("Delete", ctx, orgID, "closed").Return(nil)
+	assert.NoError(t, svc.DeleteStatus(ctx, orgID, "closed"))
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	defaultTimelineLimit = 50
+	maxTimelineLimit     = 200
+)
+
+// TimelineService implements port.TimelineService.
+type TimelineService struct {
+	tickets        port.TicketRepository
+	comments       port.CommentRepository
+	scheduledTasks port.ScheduledTaskRepository
+	users          port.UserRepository
+}
+
+// NewTimelineService creates a new TimelineService.
+func NewTimelineService(tickets port.TicketRepository, comments port.CommentRepository, scheduledTasks port.ScheduledTaskRepository, users port.UserRepository) *TimelineService {
+	return &TimelineService{
+		tickets:        tickets,
+		comments:       comments,
+		scheduledTasks: scheduledTasks,
+		users:          users,
+	}
+}
+
+// GetTimeline returns a page of the ticket's activity ordered oldest first.
+// Entries sharing a timestamp are ordered by ID so the cursor is stable.
+func (s *TimelineService) GetTimeline(ctx context.Context, ticket *domain.Ticket, query port.TimelineQuery) (*domain.TimelinePage, error) {
+	after, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTimelineLimit
+	}
+	if limit > maxTimelineLimit {
+		limit = maxTimelineLimit
+	}
+
+	entries, err := s.collect(ctx, ticket, query.Public)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return timelineLess(entries[i].CreatedAt, entries[i].ID, entries[j].CreatedAt, entries[j].ID)
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(entries), func(i int) bool {
+			return timelineLess(after.createdAt, after.id, entries[i].CreatedAt, entries[i].ID)
+		})
+	}
+	end := start + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	page := &domain.TimelinePage{Entries: entries[start:end]}
+	if end < len(entries) {
+		last := entries[end-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+func (s *TimelineService) collect(ctx context.Context, ticket *domain.Ticket, public bool) ([]domain.TimelineEntry, error) {
+	comments, err := s.comments.ListByTicket(ctx, ticket.ID, !public)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.tickets.ListEvents(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+	var files []domain.File
+	if !public {
+		files, err = s.tickets.ListFiles(ctx, ticket.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	actors, err := s.loadActors(ctx, ticket, comments, events, public)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.TimelineEntry, 0, 1+len(comments)+len(events)+len(files))
+
+	origin := domain.TimelineEntry{
+		Type:      domain.TimelineEntryCreated,
+		ID:        ticket.ID,
+		CreatedAt: ticket.CreatedAt,
+	}
+	if ticket.ScheduledTaskID != nil {
+		origin.Type = domain.TimelineEntryScheduledTask
+		origin.ScheduledTask = &domain.TimelineScheduledTask{ID: *ticket.ScheduledTaskID}
+		task, err := s.scheduledTasks.Get(ctx, *ticket.ScheduledTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if task != nil {
+			origin.ScheduledTask.Title = task.Title
+		}
+		if public {
+			origin.ScheduledTask.ID = uuid.Nil
+		}
+	} else if !public {
+		origin.Actor = actors[ticket.ReporterID]
+	}
+	entries = append(entries, origin)
+
+	for _, c := range comments {
+		c := c
+		entry := domain.TimelineEntry{
+			Type:      domain.TimelineEntryComment,
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			Actor:     actors[c.UserID],
+			Comment:   &c,
+		}
+		if public {
+			c.UserID = uuid.Nil
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, f := range files {
+		f := f
+		entries = append(entries, domain.TimelineEntry{
+			Type:      domain.TimelineEntryFile,
+			ID:        f.ID,
+			CreatedAt: f.CreatedAt,
+			File:      &f,
+		})
+	}
+
+	for _, e := range events {
+		e := e
+		if public && !redactEventForPublic(&e) {
+			continue
+		}
+		entry := domain.TimelineEntry{
+			Type:      domain.TimelineEntryEvent,
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+			Event:     &e,
+		}
+		if e.ActorID != nil {
+			entry.Actor = actors[*e.ActorID]
+		}
+		if public {
+			e.ActorID = nil
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// loadActors resolves every user referenced by the timeline. In the public
+// view the user IDs are blanked, matching PublicViewHandler.
+func (s *TimelineService) loadActors(ctx context.Context, ticket *domain.Ticket, comments []domain.Comment, events []domain.TicketEvent, public bool) (map[uuid.UUID]*domain.TimelineActor, error) {
+	seen := map[uuid.UUID]bool{ticket.ReporterID: true}
+	ids := []uuid.UUID{ticket.ReporterID}
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, c := range comments {
+		add(c.UserID)
+	}
+	for _, e := range events {
+		if e.ActorID != nil {
+			add(*e.ActorID)
+		}
+	}
+
+	users, err := s.users.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	actors := make(map[uuid.UUID]*domain.TimelineActor, len(ids))
+	for _, id := range ids {
+		actors[id] = &domain.TimelineActor{ID: id, Name: "Unknown"}
+	}
+	for _, u := range users {
+		actors[u.ID] = &domain.TimelineActor{ID: u.ID, Name: u.Name, AvatarURL: u.AvatarURL}
+	}
+	if public {
+		for _, a := range actors {
+			a.ID = uuid.Nil
+		}
+	}
+	return actors, nil
+}
+
+// redactEventForPublic strips user identifiers from an event and reports
+// whether it may be shown publicly at all.
+func redactEventForPublic(e *domain.TicketEvent) bool {
+	switch e.Field {
+	case domain.TicketFieldSensitive:
+		return false
+	case domain.TicketFieldAssignee:
+		e.OldValue = []byte("null")
+		e.NewValue = []byte("null")
+	}
+	e.ActorName = nil
+	return true
+}
+
+func timelineLess(aTime time.Time, aID uuid.UUID, bTime time.Time, bID uuid.UUID) bool {
+	if !aTime.Equal(bTime) {
+		return aTime.Before(bTime)
+	}
+	return aID.String() < bID.String()
+}
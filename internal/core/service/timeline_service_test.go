@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+func TestGetTimeline(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	reporter := domain.User{ID: uuid.New(), Name: "Reporter"}
+	staff := domain.User{ID: uuid.New(), Name: "Staff"}
+	taskID := uuid.New()
+	ticket := &domain.Ticket{
+		ID:              uuid.New(),
+		ReporterID:      reporter.ID,
+		ScheduledTaskID: &taskID,
+		CreatedAt:       base,
+	}
+
+	comments := []domain.Comment{
+		{ID: uuid.New(), UserID: staff.ID, Body: "On it", CreatedAt: base.Add(2 * time.Minute)},
+	}
+	sensitiveComment := domain.Comment{ID: uuid.New(), UserID: staff.ID, Body: "Code is 1234", Sensitive: true, CreatedAt: base.Add(3 * time.Minute)}
+	files := []domain.File{
+		{ID: uuid.New(), Filename: "photo.jpg", CreatedAt: base.Add(time.Minute)},
+	}
+	events := []domain.TicketEvent{
+		{ID: uuid.New(), ActorID: &staff.ID, Field: domain.TicketFieldStatus, OldValue: []byte(`"new"`), NewValue: []byte(`"in_progress"`), CreatedAt: base.Add(4 * time.Minute)},
+		{ID: uuid.New(), ActorID: &staff.ID, Field: domain.TicketFieldAssignee, OldValue: []byte(`null`), NewValue: []byte(`"` + staff.ID.String() + `"`), CreatedAt: base.Add(5 * time.Minute)},
+		{ID: uuid.New(), ActorID: &staff.ID, Field: domain.TicketFieldSensitive, OldValue: []byte(`false`), NewValue: []byte(`true`), CreatedAt: base.Add(6 * time.Minute)},
+	}
+
+	setup := func(public bool) *TimelineService {
+		tickets := new(MockTicketRepository)
+		commentRepo := new(MockCommentRepository)
+		tasks := new(MockScheduledTaskRepository)
+		users := new(MockUserRepository)
+
+		if public {
+			commentRepo.On("ListByTicket", ctx, ticket.ID, false).Return(comments, nil)
+		} else {
+			commentRepo.On("ListByTicket", ctx, ticket.ID, true).Return(append(append([]domain.Comment{}, comments...), sensitiveComment), nil)
+			tickets.On("ListFiles", ctx, ticket.ID).Return(files, nil)
+		}
+		// Fresh copies so redaction in one run does not leak into the next.
+		tickets.On("ListEvents", ctx, ticket.ID).Return(append([]domain.TicketEvent{}, events...), nil)
+		tasks.On("Get", ctx, taskID).Return(&domain.ScheduledTask{ID: taskID, Title: "Weekly walkthrough"}, nil)
+		users.On("GetByIDs", ctx, mock.Anything).Return([]domain.User{reporter, staff}, nil)
+
+		return NewTimelineService(tickets, commentRepo, tasks, users)
+	}
+
+	t.Run("Merges all sources in order", func(t *testing.T) {
+		page, err := setup(false).GetTimeline(ctx, ticket, port.TimelineQuery{})
+		require.NoError(t, err)
+
+		var types []string
+		for _, e := range page.Entries {
+			types = append(types, e.Type)
+		}
+		assert.Equal(t, []string{
+			domain.TimelineEntryScheduledTask,
+			domain.TimelineEntryFile,
+			domain.TimelineEntryComment,
+			domain.TimelineEntryComment,
+			domain.TimelineEntryEvent,
+			domain.TimelineEntryEvent,
+			domain.TimelineEntryEvent,
+		}, types)
+		assert.Equal(t, "Weekly walkthrough", page.Entries[0].ScheduledTask.Title)
+		assert.Equal(t, "Staff", page.Entries[2].Actor.Name)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Cursor pagination", func(t *testing.T) {
+		svc := setup(false)
+		first, err := svc.GetTimeline(ctx, ticket, port.TimelineQuery{Limit: 4})
+		require.NoError(t, err)
+		require.Len(t, first.Entries, 4)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := svc.GetTimeline(ctx, ticket, port.TimelineQuery{Limit: 4, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, second.Entries, 3)
+		assert.Empty(t, second.NextCursor)
+		assert.Equal(t, events[0].ID, second.Entries[0].ID)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		_, err := setup(false).GetTimeline(ctx, ticket, port.TimelineQuery{Cursor: "!!"})
+		assert.ErrorIs(t, err, port.ErrInvalidCursor)
+	})
+
+	t.Run("Public view is redacted", func(t *testing.T) {
+		page, err := setup(true).GetTimeline(ctx, ticket, port.TimelineQuery{Public: true})
+		require.NoError(t, err)
+
+		require.Len(t, page.Entries, 4)
+		for _, e := range page.Entries {
+			assert.NotEqual(t, domain.TimelineEntryFile, e.Type)
+			if e.Actor != nil {
+				assert.Equal(t, uuid.Nil, e.Actor.ID)
+			}
+			if e.Comment != nil {
+				assert.False(t, e.Comment.Sensitive)
+				assert.Equal(t, uuid.Nil, e.Comment.UserID)
+			}
+			if e.Event != nil {
+				assert.Nil(t, e.Event.ActorID)
+				assert.NotEqual(t, domain.TicketFieldSensitive, e.Event.Field)
+				if e.Event.Field == domain.TicketFieldAssignee {
+					assert.JSONEq(t, `null`, string(e.Event.NewValue))
+				}
+			}
+		}
+		assert.Equal(t, uuid.Nil, page.Entries[0].ScheduledTask.ID)
+	})
+}
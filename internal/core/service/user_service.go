@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+// UserService implements port.UserService.
+type UserService struct {
+	repo port.UserRepository
+}
+
+// NewUserService creates a new UserService.
+func NewUserService(repo port.UserRepository) *UserService {
+	return &UserService{repo: repo}
+}
+
+// UpdatePhone normalizes phone to E.164 and stores it on the user.
+func (s *UserService) UpdatePhone(ctx context.Context, userID uuid.UUID, phone string) (*domain.User, error) {
+	phone = strings.TrimSpace(phone)
+	if phone != "" {
+		normalized, err := domain.NormalizePhone(phone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", port.ErrInvalidPhone, err)
+		}
+		phone = normalized
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	user.Phone = phone
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
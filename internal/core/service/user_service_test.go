@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+func TestUpdatePhone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Normalizes to E.164", func(t *testing.T) {
+		user := &domain.User{ID: uuid.New(), Name: "Sam"}
+		repo := new(MockUserRepository)
+		repo.On("GetByID", ctx, user.ID).Return(user, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		updated, err := NewUserService(repo).UpdatePhone(ctx, user.ID, " +1 (555) 123-4567 ")
+		require.NoError(t, err)
+		assert.Equal(t, "+15551234567", updated.Phone)
+		repo.AssertCalled(t, "Update", ctx, mock.MatchedBy(func(u *domain.User) bool { return u.Phone == "+15551234567" }))
+	})
+
+	t.Run("Empty removes the number", func(t *testing.T) {
+		user := &domain.User{ID: uuid.New(), Phone: "+15551234567"}
+		repo := new(MockUserRepository)
+		repo.On("GetByID", ctx, user.ID).Return(user, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		updated, err := NewUserService(repo).UpdatePhone(ctx, user.ID, "")
+		require.NoError(t, err)
+		assert.Empty(t, updated.Phone)
+	})
+
+	t.Run("Rejects local numbers", func(t *testing.T) {
+		repo := new(MockUserRepository)
+
+		_, err := NewUserService(repo).UpdatePhone(ctx, uuid.New(), "555-1234")
+		assert.ErrorIs(t, err, port.ErrInvalidPhone)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
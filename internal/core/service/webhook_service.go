@@ -0,0 +1,531 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wsciaroni/opsdeck/internal/core/domain"
+	"github.com/wsciaroni/opsdeck/internal/core/port"
+)
+
+const (
+	defaultWebhookDeliveryLimit = 20
+	maxWebhookDeliveryLimit     = 100
+	maxWebhookURLLength         = 2048
+	maxWebhookDescriptionLength = 500
+)
+
+// WebhookService implements port.WebhookService.
+type WebhookService struct {
+	repo       port.WebhookRepository
+	queue      port.WebhookQueue
+	sender     port.WebhookSender
+	tickets    port.TicketRepository
+	comments   port.CommentRepository
+	statuses   port.TicketStatusRepository
+	priorities port.TicketPriorityRepository
+	categories port.TicketCategoryRepository
+	users      port.UserRepository
+	tx         port.TxManager
+}
+
+// NewWebhookService creates a new WebhookService.
+func NewWebhookService(
+	repo port.WebhookRepository,
+	queue port.WebhookQueue,
+	sender port.WebhookSender,
+	tickets port.TicketRepository,
+	comments port.CommentRepository,
+	statuses port.TicketStatusRepository,
+	priorities port.TicketPriorityRepository,
+	categories port.TicketCategoryRepository,
+	users port.UserRepository,
+	tx port.TxManager,
+) *WebhookService {
+	return &WebhookService{
+		repo:       repo,
+		queue:      queue,
+		sender:     sender,
+		tickets:    tickets,
+		comments:   comments,
+		statuses:   statuses,
+		priorities: priorities,
+		categories: categories,
+		users:      users,
+		tx:         tx,
+	}
+}
+
+// ListEndpoints returns the organization's endpoints without their secrets.
+func (s *WebhookService) ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]domain.WebhookEndpoint, error) {
+	endpoints, err := s.repo.ListEndpoints(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+	return endpoints, nil
+}
+
+// CreateEndpoint validates and registers an endpoint. It is enabled
+// immediately.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, cmd port.CreateWebhookCmd) (*domain.WebhookEndpoint, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &domain.WebhookEndpoint{
+		OrganizationID: cmd.OrganizationID,
+		URL:            strings.TrimSpace(cmd.URL),
+		Description:    strings.TrimSpace(cmd.Description),
+		Format:         cmd.Format,
+		Events:         cmd.Events,
+		PriorityIDs:    cmd.PriorityIDs,
+		CategoryIDs:    cmd.CategoryIDs,
+		Internal:       cmd.Internal,
+		Enabled:        true,
+		Secret:         secret,
+	}
+	if endpoint.Format == "" {
+		endpoint.Format = domain.WebhookFormatJSON
+	}
+	if err := s.validateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// UpdateEndpoint applies the non-nil fields of cmd to an endpoint. The secret
+// is never returned.
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, cmd port.UpdateWebhookCmd) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.getEndpoint(ctx, cmd.OrganizationID, cmd.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.URL != nil {
+		endpoint.URL = strings.TrimSpace(*cmd.URL)
+	}
+	if cmd.Description != nil {
+		endpoint.Description = strings.TrimSpace(*cmd.Description)
+	}
+	if cmd.Format != nil {
+		endpoint.Format = *cmd.Format
+	}
+	if cmd.Events != nil {
+		endpoint.Events = *cmd.Events
+	}
+	if cmd.PriorityIDs != nil {
+		endpoint.PriorityIDs = *cmd.PriorityIDs
+	}
+	if cmd.CategoryIDs != nil {
+		endpoint.CategoryIDs = *cmd.CategoryIDs
+	}
+	if cmd.Internal != nil {
+		endpoint.Internal = *cmd.Internal
+	}
+	if cmd.Enabled != nil {
+		endpoint.Enabled = *cmd.Enabled
+	}
+	if err := s.validateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	endpoint.Secret = ""
+	return endpoint, nil
+}
+
+// DeleteEndpoint removes an endpoint along with its delivery log.
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, orgID, endpointID uuid.UUID) error {
+	if _, err := s.getEndpoint(ctx, orgID, endpointID); err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(ctx, endpointID)
+}
+
+// ListDeliveries returns a page of the endpoint's delivery log, newest first.
+func (s *WebhookService) ListDeliveries(ctx context.Context, orgID, endpointID uuid.UUID, query port.WebhookDeliveryQuery) (*domain.WebhookDeliveryPage, error) {
+	if _, err := s.getEndpoint(ctx, orgID, endpointID); err != nil {
+		return nil, err
+	}
+	before, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+	if limit > maxWebhookDeliveryLimit {
+		limit = maxWebhookDeliveryLimit
+	}
+
+	filter := port.WebhookDeliveryFilter{
+		EndpointID: endpointID,
+		// One extra row tells us whether another page follows.
+		Limit: limit + 1,
+	}
+	if before != nil {
+		filter.BeforeCreatedAt = &before.createdAt
+		filter.BeforeID = before.id
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.WebhookDeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		last := page.Deliveries[limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+// Redeliver queues a new delivery of a past delivery's payload. The event ID
+// in the payload is kept so receivers can deduplicate. If the endpoint is no
+// longer internal, the payload is redacted again before it is resent.
+func (s *WebhookService) Redeliver(ctx context.Context, orgID, endpointID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	endpoint, err := s.getEndpoint(ctx, orgID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil || original.EndpointID != endpoint.ID {
+		return nil, port.ErrWebhookNotFound
+	}
+
+	payload := original.Payload
+	if !endpoint.Internal {
+		var p domain.WebhookPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+		}
+		p.Data.Redact()
+		if payload, err = json.Marshal(p); err != nil {
+			return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+		}
+	}
+
+	delivery := &domain.WebhookDelivery{
+		EndpointID:     endpoint.ID,
+		OrganizationID: endpoint.OrganizationID,
+		Event:          original.Event,
+		Payload:        payload,
+		Status:         domain.WebhookDeliveryPending,
+	}
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		return s.queue.EnqueueDelivery(ctx, delivery.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Publish builds the payload of n for every enabled endpoint that subscribes
+// to its event and whose filters match the ticket, and queues a delivery for
+// each. Endpoints that are not internal get the payload redacted. The
+// deliveries are recorded in one transaction, so a retried publish never
+// duplicates them.
+func (s *WebhookService) Publish(ctx context.Context, n domain.Notification, occurredAt time.Time) error {
+	ticket, err := s.tickets.GetByID(ctx, n.TicketID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket == nil {
+		return nil
+	}
+
+	endpoints, err := s.repo.ListEndpoints(ctx, ticket.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	matched := endpoints[:0]
+	for _, e := range endpoints {
+		if e.Enabled && e.Matches(n.Event, ticket) {
+			matched = append(matched, e)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	data, ok, err := s.eventData(ctx, n, ticket)
+	if err != nil || !ok {
+		return err
+	}
+
+	eventID := uuid.New()
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, e := range matched {
+			payload := domain.WebhookPayload{
+				Version:        domain.WebhookPayloadVersion,
+				ID:             eventID,
+				Event:          n.Event,
+				OccurredAt:     occurredAt.UTC(),
+				OrganizationID: ticket.OrganizationID,
+				Data:           data,
+			}
+			if !e.Internal {
+				payload.Data.Redact()
+			}
+			body, err := json.Marshal(payload)
+			if err != nil {
+				return fmt.Errorf("failed to encode webhook payload: %w", err)
+			}
+
+			delivery := &domain.WebhookDelivery{
+				EndpointID:     e.ID,
+				OrganizationID: e.OrganizationID,
+				Event:          n.Event,
+				Payload:        body,
+				Status:         domain.WebhookDeliveryPending,
+			}
+			if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+			if err := s.queue.EnqueueDelivery(ctx, delivery.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// eventData gathers the unredacted data of n, with the organization's labels
+// and the actor's name filled in. It reports false if the comment is gone.
+func (s *WebhookService) eventData(ctx context.Context, n domain.Notification, ticket *domain.Ticket) (domain.WebhookEventData, bool, error) {
+	data := domain.WebhookEventData{
+		Ticket:      domain.NewWebhookTicket(ticket),
+		ActorID:     n.ActorID,
+		OldStatusID: n.OldStatusID,
+		NewStatusID: n.NewStatusID,
+	}
+	if n.CommentID != nil {
+		comment, err := s.comments.GetByID(ctx, *n.CommentID)
+		if err != nil {
+			return data, false, fmt.Errorf("failed to get comment: %w", err)
+		}
+		if comment == nil {
+			return data, false, nil
+		}
+		data.Comment = domain.NewWebhookComment(comment)
+	}
+
+	statuses, err := s.statuses.ListByOrganization(ctx, ticket.OrganizationID)
+	if err != nil {
+		return data, false, fmt.Errorf("failed to list ticket statuses: %w", err)
+	}
+	if st := statuses.Find(ticket.StatusID); st != nil {
+		data.Ticket.StatusLabel = st.Label
+	}
+	if st := statuses.Find(n.OldStatusID); st != nil {
+		data.OldStatusLabel = st.Label
+	}
+	if st := statuses.Find(n.NewStatusID); st != nil {
+		data.NewStatusLabel = st.Label
+	}
+
+	priorities, err := s.priorities.ListByOrganization(ctx, ticket.OrganizationID)
+	if err != nil {
+		return data, false, fmt.Errorf("failed to list ticket priorities: %w", err)
+	}
+	if p := priorities.Find(ticket.PriorityID); p != nil {
+		data.Ticket.PriorityLabel = p.Label
+	}
+
+	if ticket.CategoryID != nil {
+		categories, err := s.categories.ListByOrganization(ctx, ticket.OrganizationID)
+		if err != nil {
+			return data, false, fmt.Errorf("failed to list ticket categories: %w", err)
+		}
+		if c := categories.Find(*ticket.CategoryID); c != nil {
+			data.Ticket.CategoryLabel = c.Label
+		}
+	}
+
+	if n.ActorID != nil {
+		users, err := s.users.GetByIDs(ctx, []uuid.UUID{*n.ActorID})
+		if err != nil {
+			return data, false, fmt.Errorf("failed to get actor: %w", err)
+		}
+		if len(users) > 0 {
+			data.ActorName = users[0].Name
+		}
+	}
+	return data, true, nil
+}
+
+// Deliver sends a delivery to its endpoint in the endpoint's format and
+// records the response. Any status other than 2xx counts as a failure.
+// Deliveries that already succeeded, or whose endpoint has since been
+// disabled, are not sent. The payload is redacted again if the endpoint has
+// stopped being internal since it was published.
+func (s *WebhookService) Deliver(ctx context.Context, deliveryID uuid.UUID) error {
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if delivery == nil || delivery.Status == domain.WebhookDeliverySucceeded {
+		return nil
+	}
+	endpoint, err := s.repo.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	if endpoint == nil {
+		return nil
+	}
+	if !endpoint.Enabled {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.Error = "endpoint is disabled"
+		return s.repo.UpdateDeliveryAttempt(ctx, delivery)
+	}
+
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	if !endpoint.Internal {
+		payload.Data.Redact()
+	}
+
+	resp, sendErr := s.sender.Send(ctx, port.WebhookRequest{
+		URL:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		Format:     endpoint.Format,
+		DeliveryID: delivery.ID,
+		Payload:    payload,
+	})
+
+	delivery.ResponseCode = nil
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+	switch {
+	case sendErr != nil:
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.Error = sendErr.Error()
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.ResponseCode = &resp.StatusCode
+		delivery.ResponseBody = resp.Body
+		sendErr = fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+		delivery.Error = sendErr.Error()
+	default:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.ResponseCode = &resp.StatusCode
+		delivery.ResponseBody = resp.Body
+	}
+	if err := s.repo.UpdateDeliveryAttempt(ctx, delivery); err != nil {
+		return err
+	}
+	if sendErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+	return nil
+}
+
+// getEndpoint returns the endpoint if it belongs to the organization.
+func (s *WebhookService) getEndpoint(ctx context.Context, orgID, endpointID uuid.UUID) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil || endpoint.OrganizationID != orgID {
+		return nil, port.ErrWebhookNotFound
+	}
+	return endpoint, nil
+}
+
+// validateEndpoint checks an endpoint's fields and that its filters name
+// priorities and categories of its organization.
+func (s *WebhookService) validateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error {
+	if err := validateWebhookEndpoint(e); err != nil {
+		return err
+	}
+	if len(e.PriorityIDs) > 0 {
+		priorities, err := s.priorities.ListByOrganization(ctx, e.OrganizationID)
+		if err != nil {
+			return fmt.Errorf("failed to list ticket priorities: %w", err)
+		}
+		for _, id := range e.PriorityIDs {
+			if priorities.Find(id) == nil {
+				return fmt.Errorf("%w: unknown priority %q", port.ErrInvalidWebhook, id)
+			}
+		}
+	}
+	if len(e.CategoryIDs) > 0 {
+		categories, err := s.categories.ListByOrganization(ctx, e.OrganizationID)
+		if err != nil {
+			return fmt.Errorf("failed to list ticket categories: %w", err)
+		}
+		for _, id := range e.CategoryIDs {
+			if categories.Find(id) == nil {
+				return fmt.Errorf("%w: unknown category %q", port.ErrInvalidWebhook, id)
+			}
+		}
+	}
+	return nil
+}
+
+func validateWebhookEndpoint(e *domain.WebhookEndpoint) error {
+	if len(e.URL) > maxWebhookURLLength {
+		return fmt.Errorf("%w: url is too long", port.ErrInvalidWebhook)
+	}
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: url must be an http or https URL", port.ErrInvalidWebhook)
+	}
+	if !e.Format.Valid() {
+		return fmt.Errorf("%w: unknown format %q", port.ErrInvalidWebhook, e.Format)
+	}
+	if len(e.Description) > maxWebhookDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", port.ErrInvalidWebhook, maxWebhookDescriptionLength)
+	}
+	if len(e.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", port.ErrInvalidWebhook)
+	}
+	seen := make(map[domain.NotificationEvent]bool, len(e.Events))
+	events := make([]domain.NotificationEvent, 0, len(e.Events))
+	for _, ev := range e.Events {
+		if !ev.Valid() {
+			return fmt.Errorf("%w: unknown event %q", port.ErrInvalidWebhook, ev)
+		}
+		if !seen[ev] {
+			seen[ev] = true
+			events = append(events, ev)
+		}
+	}
+	e.Events = events
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
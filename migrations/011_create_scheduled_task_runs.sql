@@ -0,0 +1,14 @@
+CREATE TABLE scheduled_task_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scheduled_task_id UUID NOT NULL REFERENCES scheduled_tasks(id) ON DELETE CASCADE,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ticket_id UUID REFERENCES tickets(id) ON DELETE SET NULL,
+    outcome VARCHAR(50) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_task_runs_task ON scheduled_task_runs(scheduled_task_id, run_at DESC);
+
+ALTER TABLE tickets ADD COLUMN scheduled_task_id UUID REFERENCES scheduled_tasks(id) ON DELETE SET NULL;
+CREATE INDEX idx_tickets_scheduled_task ON tickets(scheduled_task_id) WHERE scheduled_task_id IS NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE scheduled_tasks ADD COLUMN rrule TEXT;
+
+UPDATE scheduled_tasks SET rrule = 'FREQ=' || UPPER(frequency);
+
+ALTER TABLE scheduled_tasks ALTER COLUMN rrule SET NOT NULL;
+ALTER TABLE scheduled_tasks DROP COLUMN frequency;
@@ -0,0 +1,28 @@
+CREATE TABLE ticket_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    event_type VARCHAR(50) NOT NULL,
+    field VARCHAR(50) NOT NULL DEFAULT '',
+    old_value JSONB NOT NULL DEFAULT 'null'::jsonb,
+    new_value JSONB NOT NULL DEFAULT 'null'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_ticket_events_ticket ON ticket_events(ticket_id, created_at);
+
+-- Events are append-only. Updates and deletes are rejected unless they come
+-- from a foreign key action (a ticket being deleted, or an actor's user
+-- row), which runs one trigger level deeper.
+CREATE FUNCTION ticket_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        RETURN CASE WHEN TG_OP = 'DELETE' THEN OLD ELSE NEW END;
+    END IF;
+    RAISE EXCEPTION 'ticket_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ticket_events_append_only
+    BEFORE UPDATE OR DELETE ON ticket_events
+    FOR EACH ROW EXECUTE FUNCTION ticket_events_append_only();
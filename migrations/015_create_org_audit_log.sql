@@ -0,0 +1,31 @@
+CREATE TABLE org_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id UUID NOT NULL,
+    before JSONB NOT NULL DEFAULT 'null'::jsonb,
+    after JSONB NOT NULL DEFAULT 'null'::jsonb,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_org_audit_log_org ON org_audit_log(organization_id, created_at DESC, id DESC);
+
+-- The log is append-only. Updates and deletes are rejected unless they come
+-- from a foreign key action (an organization being deleted, or an actor's
+-- user row), which runs one trigger level deeper.
+CREATE FUNCTION org_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        RETURN CASE WHEN TG_OP = 'DELETE' THEN OLD ELSE NEW END;
+    END IF;
+    RAISE EXCEPTION 'org_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER org_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON org_audit_log
+    FOR EACH ROW EXECUTE FUNCTION org_audit_log_append_only();
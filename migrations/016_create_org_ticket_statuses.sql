@@ -0,0 +1,37 @@
+-- The global ticket_statuses table becomes the default set copied into every
+-- organization.
+ALTER TABLE ticket_statuses
+    ADD COLUMN state VARCHAR(16) NOT NULL DEFAULT 'open',
+    ADD COLUMN color VARCHAR(7) NOT NULL DEFAULT '';
+
+UPDATE ticket_statuses SET state = 'resolved' WHERE id IN ('done', 'canceled');
+UPDATE ticket_statuses SET color = '#3b82f6' WHERE id = 'new';
+UPDATE ticket_statuses SET color = '#eab308' WHERE id = 'in_progress';
+UPDATE ticket_statuses SET color = '#f97316' WHERE id = 'on_hold';
+UPDATE ticket_statuses SET color = '#22c55e' WHERE id = 'done';
+UPDATE ticket_statuses SET color = '#6b7280' WHERE id = 'canceled';
+
+CREATE TABLE organization_ticket_statuses (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    label TEXT NOT NULL,
+    position INT NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    state VARCHAR(16) NOT NULL CHECK (state IN ('open', 'resolved')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, id)
+);
+
+INSERT INTO organization_ticket_statuses (organization_id, id, label, position, color, state)
+SELECT o.id, s.id, s.label, s.level, s.color, s.state
+FROM organizations o
+CROSS JOIN ticket_statuses s;
+
+-- Tickets now reference their organization's statuses, so a status cannot be
+-- deleted while tickets still use it.
+ALTER TABLE tickets DROP CONSTRAINT tickets_status_id_fkey;
+ALTER TABLE tickets
+    ADD CONSTRAINT tickets_org_status_fkey
+    FOREIGN KEY (organization_id, status_id)
+    REFERENCES organization_ticket_statuses(organization_id, id);
@@ -0,0 +1,44 @@
+-- The global ticket_priorities table becomes the default set copied into
+-- every organization.
+ALTER TABLE ticket_priorities
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN color VARCHAR(7) NOT NULL DEFAULT '';
+
+UPDATE ticket_priorities SET color = '#6b7280', description = 'Can wait until convenient.' WHERE id = 'low';
+UPDATE ticket_priorities SET color = '#3b82f6', description = 'Handle within a few days.' WHERE id = 'medium';
+UPDATE ticket_priorities SET color = '#f97316', description = 'Handle within one business day.' WHERE id = 'high';
+UPDATE ticket_priorities SET color = '#ef4444', description = 'Needs attention immediately.' WHERE id = 'critical';
+
+CREATE TABLE organization_ticket_priorities (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    label TEXT NOT NULL,
+    level INT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, id)
+);
+
+INSERT INTO organization_ticket_priorities (organization_id, id, label, level, description, color)
+SELECT o.id, p.id, p.label, p.level, p.description, p.color
+FROM organizations o
+CROSS JOIN ticket_priorities p;
+
+-- Tickets and scheduled tasks now reference their organization's priorities,
+-- so a priority cannot be deleted while either still uses it. Scheduled task
+-- priorities were never validated, so unknown values fall back to medium.
+ALTER TABLE tickets DROP CONSTRAINT tickets_priority_id_fkey;
+ALTER TABLE tickets
+    ADD CONSTRAINT tickets_org_priority_fkey
+    FOREIGN KEY (organization_id, priority_id)
+    REFERENCES organization_ticket_priorities(organization_id, id);
+
+UPDATE scheduled_tasks SET priority_id = 'medium'
+WHERE priority_id NOT IN (SELECT id FROM ticket_priorities);
+
+ALTER TABLE scheduled_tasks
+    ADD CONSTRAINT scheduled_tasks_org_priority_fkey
+    FOREIGN KEY (organization_id, priority_id)
+    REFERENCES organization_ticket_priorities(organization_id, id);
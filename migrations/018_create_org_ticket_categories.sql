@@ -0,0 +1,25 @@
+CREATE TABLE organization_ticket_categories (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    id TEXT NOT NULL,
+    label TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, id)
+);
+
+-- Categories are optional. The composite foreign keys are only checked when
+-- category_id is set, and keep a category from being deleted while in use.
+ALTER TABLE tickets ADD COLUMN category_id TEXT;
+ALTER TABLE tickets
+    ADD CONSTRAINT tickets_org_category_fkey
+    FOREIGN KEY (organization_id, category_id)
+    REFERENCES organization_ticket_categories(organization_id, id);
+
+CREATE INDEX idx_tickets_category ON tickets(organization_id, category_id);
+
+ALTER TABLE scheduled_tasks ADD COLUMN category_id TEXT;
+ALTER TABLE scheduled_tasks
+    ADD CONSTRAINT scheduled_tasks_org_category_fkey
+    FOREIGN KEY (organization_id, category_id)
+    REFERENCES organization_ticket_categories(organization_id, id);
@@ -0,0 +1,18 @@
+-- Notification preferences choose a delivery channel per event. A user's own
+-- preference overrides their organization's default; events with neither fall
+-- back to the application default.
+CREATE TABLE user_notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    channel TEXT NOT NULL CHECK (channel IN ('email', 'sms', 'none')),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, event)
+);
+
+CREATE TABLE organization_notification_preferences (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    channel TEXT NOT NULL CHECK (channel IN ('email', 'sms', 'none')),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, event)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE inbox_notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    event VARCHAR(32) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    summary TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inbox_notifications_user ON inbox_notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_inbox_notifications_unread ON inbox_notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,18 @@
+-- Organizations can replace the built-in wording of each notification email.
+-- Events without a row use the default templates embedded in the binary.
+CREATE TABLE organization_email_templates (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event VARCHAR(32) NOT NULL,
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, event)
+);
+
+CREATE TABLE organization_email_branding (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    logo_url TEXT NOT NULL DEFAULT '',
+    footer TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
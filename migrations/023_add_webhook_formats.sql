@@ -0,0 +1,4 @@
+ALTER TABLE webhook_endpoints
+    ADD COLUMN format VARCHAR(16) NOT NULL DEFAULT 'json',
+    ADD COLUMN priority_ids TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN category_ids TEXT[] NOT NULL DEFAULT '{}';
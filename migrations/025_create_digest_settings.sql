@@ -0,0 +1,11 @@
+-- Users who want a periodic summary instead of, or as well as, individual
+-- notifications. Users without a row receive no digest.
+CREATE TABLE digest_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(16) NOT NULL DEFAULT 'none',
+    timezone TEXT NOT NULL DEFAULT '',
+    last_sent_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_digest_settings_enabled ON digest_settings (user_id) WHERE frequency <> 'none';
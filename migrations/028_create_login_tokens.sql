@@ -0,0 +1,15 @@
+-- Single-use tokens emailed as sign-in links. Only the SHA-256 hash of each
+-- token is stored. Rows outlive their token so that requests can be counted
+-- per email and IP address for rate limiting. Emails are stored lower-cased.
+CREATE TABLE login_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email TEXT NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_login_tokens_email_created_at ON login_tokens (email, created_at);
+CREATE INDEX idx_login_tokens_ip_address_created_at ON login_tokens (ip_address, created_at);
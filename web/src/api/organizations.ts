@@ -1,5 +1,5 @@
 import { client } from './client';
-import type { Organization, Member } from '../types';
+import type { Organization, Member, TicketStatus, TicketPriority } from '../types';
 
 export const createOrganization = async (name: string): Promise<Organization> => {
   const response = await client.post('/organizations', { name });
@@ -52,3 +52,13 @@ export const regeneratePublicViewToken = async (orgID: string): Promise<{ public
   const response = await client.post(`/organizations/${orgID}/public-view/regenerate`);
   return response.data;
 };
+
+export const getStatuses = async (orgID: string): Promise<TicketStatus[]> => {
+  const response = await client.get(`/organizations/${orgID}/statuses`);
+  return response.data;
+};
+
+export const getPriorities = async (orgID: string): Promise<TicketPriority[]> => {
+  const response = await client.get(`/organizations/${orgID}/priorities`);
+  return response.data;
+};
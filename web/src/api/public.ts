@@ -1,5 +1,5 @@
 import { client } from './client';
-import type { Ticket } from '../types';
+import type { Ticket, TicketStatus, TicketPriority } from '../types';
 import type { Comment } from './comments';
 
 export interface PublicOrganization {
@@ -20,6 +20,16 @@ export async function getPublicTickets(token: string): Promise<PublicTicket[]> {
   return response.data;
 }
 
+export async function getPublicStatuses(token: string): Promise<TicketStatus[]> {
+  const response = await client.get(`/public/view/${token}/statuses`);
+  return response.data;
+}
+
+export async function getPublicPriorities(token: string): Promise<TicketPriority[]> {
+  const response = await client.get(`/public/view/${token}/priorities`);
+  return response.data;
+}
+
 export async function getPublicTicket(token: string, ticketID: string): Promise<PublicTicket> {
   const response = await client.get(`/public/view/${token}/tickets/${ticketID}`);
   return response.data;
@@ -1,36 +1,31 @@
 import clsx from 'clsx';
+import type { TicketStatus, TicketPriority } from '../types';
 
-export function StatusBadge({ status }: { status: string }) {
-  const colors: Record<string, string> = {
-    new: 'bg-blue-100 text-blue-800',
-    in_progress: 'bg-yellow-100 text-yellow-800',
-    on_hold: 'bg-orange-100 text-orange-800',
-    done: 'bg-green-100 text-green-800',
-    canceled: 'bg-gray-100 text-gray-800',
-  };
+// Statuses and priorities are defined per organization. Until they have
+// loaded, or for an ID the organization no longer has, the raw ID is shown.
 
-  const labels: Record<string, string> = {
-    new: 'New',
-    in_progress: 'In Progress',
-    on_hold: 'On Hold',
-    done: 'Done',
-    canceled: 'Canceled',
-  };
+export function StatusBadge({ status, statuses }: { status: string; statuses?: TicketStatus[] }) {
+  const s = statuses?.find((ts) => ts.id === status);
 
   return (
-    <span className={clsx("inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium", colors[status] || 'bg-gray-100 text-gray-800')}>
-      {labels[status] || status}
+    <span
+      className={clsx("inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium", !s?.color && 'bg-gray-100 text-gray-800')}
+      style={s?.color ? { backgroundColor: `${s.color}26`, color: s.color } : undefined}
+    >
+      {s?.label || status}
     </span>
   );
 }
 
-export function PriorityLabel({ priority }: { priority: string }) {
-  const colors: Record<string, string> = {
-    low: 'text-gray-500',
-    medium: 'text-blue-500',
-    high: 'text-orange-500 font-bold',
-    critical: 'text-red-600 font-bold uppercase',
-  };
+export function PriorityLabel({ priority, priorities }: { priority: string; priorities?: TicketPriority[] }) {
+  const p = priorities?.find((tp) => tp.id === priority);
 
-  return <span className={clsx("text-sm", colors[priority] || 'text-gray-500')}>{priority}</span>;
+  return (
+    <span
+      className={clsx("text-sm", !p?.color && 'text-gray-500')}
+      style={p?.color ? { color: p.color } : undefined}
+    >
+      {p?.label || priority}
+    </span>
+  );
 }
@@ -3,6 +3,7 @@ import { describe, it, expect, vi } from 'vitest';
 import CreateTicketModal from './CreateTicketModal';
 import { QueryClient, QueryClientProvider } from '@tanstack/react-query';
 import * as ticketsApi from '../../api/tickets';
+import * as organizationsApi from '../../api/organizations';
 import type { Ticket } from '../../types';
 
 const queryClient = new QueryClient({
@@ -31,6 +32,10 @@ describe('CreateTicketModal', () => {
             location: '',
         };
         const createTicketSpy = vi.spyOn(ticketsApi, 'createTicket').mockResolvedValue(mockTicket);
+        vi.spyOn(organizationsApi, 'getPriorities').mockResolvedValue([
+            { id: 'medium', label: 'Medium', level: 2, description: '', color: '' },
+            { id: 'urgent', label: 'Urgent', level: 5, description: '', color: '' },
+        ]);
         const onCloseMock = vi.fn();
 
         render(
@@ -39,6 +44,9 @@ describe('CreateTicketModal', () => {
             </QueryClientProvider>
         );
 
+        // Priorities come from the organization
+        expect(await screen.findByRole('option', { name: 'Urgent' })).toBeInTheDocument();
+
         // Fill out form
         fireEvent.change(screen.getByLabelText('Title'), { target: { value: 'Test Ticket' } });
         fireEvent.change(screen.getByLabelText('Description'), { target: { value: 'Test Description' } });
@@ -1,6 +1,7 @@
 import { useState } from 'react';
-import { useMutation, useQueryClient } from '@tanstack/react-query';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
 import { createTicket } from '../../api/tickets';
+import { getPriorities } from '../../api/organizations';
 import toast from 'react-hot-toast';
 import { Dialog, DialogPanel, DialogTitle, Transition, TransitionChild } from '@headlessui/react';
 import { Paperclip, Loader2 } from 'lucide-react';
@@ -21,6 +22,12 @@ export default function CreateTicketModal({ isOpen, onClose, organizationId }: C
   });
   const [files, setFiles] = useState<FileList | null>(null);
 
+  const { data: priorities } = useQuery({
+    queryKey: ['priorities', organizationId],
+    queryFn: () => getPriorities(organizationId),
+    enabled: !!organizationId,
+  });
+
   const mutation = useMutation({
     mutationFn: createTicket,
     onSuccess: () => {
@@ -125,10 +132,11 @@ export default function CreateTicketModal({ isOpen, onClose, organizationId }: C
                               value={newTicket.priority_id}
                               onChange={(e) => setNewTicket({ ...newTicket, priority_id: e.target.value })}
                             >
-                              <option value="low">Low</option>
-                              <option value="medium">Medium</option>
-                              <option value="high">High</option>
-                              <option value="critical">Critical</option>
+                              {priorities?.map((priority) => (
+                                <option key={priority.id} value={priority.id}>
+                                  {priority.label}
+                                </option>
+                              ))}
                             </select>
                           </div>
                           <div>
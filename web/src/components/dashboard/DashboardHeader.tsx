@@ -1,7 +1,7 @@
 import { Plus, List, Layout, Search } from 'lucide-react';
 import { type Density } from './TicketList';
 import clsx from 'clsx';
-import type { Organization } from '../../types';
+import type { Organization, TicketStatus, TicketPriority } from '../../types';
 import FilterPopover from './FilterPopover';
 
 interface DashboardHeaderProps {
@@ -17,6 +17,8 @@ interface DashboardHeaderProps {
   setPriority: (priority: string[] | undefined) => void;
   status: string[] | undefined;
   setStatus: (status: string[] | undefined) => void;
+  statuses: TicketStatus[];
+  priorities: TicketPriority[];
   sortBy: string;
   setSortBy: (sortBy: string) => void;
   sortOrder: 'asc' | 'desc';
@@ -36,6 +38,8 @@ export default function DashboardHeader({
   setPriority,
   status,
   setStatus,
+  statuses,
+  priorities,
   sortBy,
   setSortBy,
   sortOrder,
@@ -86,6 +90,8 @@ export default function DashboardHeader({
                 setStatus={setStatus}
                 priority={priority}
                 setPriority={setPriority}
+                statuses={statuses}
+                priorities={priorities}
             />
         </div>
 
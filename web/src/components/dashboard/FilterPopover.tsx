@@ -1,7 +1,7 @@
 import { Popover, Transition } from '@headlessui/react';
 import { Fragment } from 'react';
 import { Filter } from 'lucide-react';
-import { TICKET_STATUSES, TICKET_PRIORITIES } from '../../types';
+import type { TicketStatus, TicketPriority } from '../../types';
 import clsx from 'clsx';
 
 interface FilterPopoverProps {
@@ -9,6 +9,8 @@ interface FilterPopoverProps {
   setStatus: (status: string[] | undefined) => void;
   priority: string[] | undefined;
   setPriority: (priority: string[] | undefined) => void;
+  statuses: TicketStatus[];
+  priorities: TicketPriority[];
 }
 
 export default function FilterPopover({
@@ -16,13 +18,15 @@ export default function FilterPopover({
   setStatus,
   priority,
   setPriority,
+  statuses,
+  priorities,
 }: FilterPopoverProps) {
   // Helper: Is this status checked?
   const isStatusChecked = (id: string) => {
     if (status === undefined) {
-      // Default: Active statuses are checked
-      const s = TICKET_STATUSES.find((ts) => ts.id === id);
-      return s ? !s.isFinished : false;
+      // Default: Open statuses are checked
+      const s = statuses.find((ts) => ts.id === id);
+      return s ? s.state === 'open' : false;
     }
     return status.includes(id);
   };
@@ -36,9 +40,9 @@ export default function FilterPopover({
   const toggleStatus = (id: string) => {
     let newStatus: string[];
 
-    // If currently undefined (Default), start with Active set
+    // If currently undefined (Default), start with Open set
     if (status === undefined) {
-      newStatus = TICKET_STATUSES.filter((t) => !t.isFinished).map((t) => t.id);
+      newStatus = statuses.filter((t) => t.state === 'open').map((t) => t.id);
     } else {
       newStatus = [...status];
     }
@@ -52,7 +56,7 @@ export default function FilterPopover({
     // If empty, user probably wants to see "All" (cleared filter), so we select ALL explicitly
     // because sending nothing triggers "Active" default in backend.
     if (newStatus.length === 0) {
-       newStatus = TICKET_STATUSES.map(t => t.id);
+       newStatus = statuses.map(t => t.id);
     }
 
     setStatus(newStatus);
@@ -62,7 +66,7 @@ export default function FilterPopover({
     let newPriority: string[];
     // If undefined/empty (All), start with All
     if (!priority || priority.length === 0) {
-       newPriority = TICKET_PRIORITIES.map(p => p.id);
+       newPriority = priorities.map(p => p.id);
     } else {
        newPriority = [...priority];
     }
@@ -89,7 +93,7 @@ export default function FilterPopover({
     }
 
     const level = Number.parseInt(value, 10);
-    const newPrio = TICKET_PRIORITIES.filter((p) => p.level >= level).map((p) => p.id);
+    const newPrio = priorities.filter((p) => p.level >= level).map((p) => p.id);
     setPriority(newPrio);
   };
 
@@ -150,7 +154,7 @@ export default function FilterPopover({
                 <div>
                     <h4 className="text-xs font-semibold text-gray-500 uppercase tracking-wider mb-2">Status</h4>
                     <div className="space-y-2">
-                        {TICKET_STATUSES.map((s) => (
+                        {statuses.map((s) => (
                             <label key={s.id} className="flex items-center space-x-2 cursor-pointer">
                                 <input
                                     type="checkbox"
@@ -181,13 +185,13 @@ export default function FilterPopover({
                      >
                         <option value="" disabled>Select minimum priority...</option>
                         <option value="any">Any Priority</option>
-                        {TICKET_PRIORITIES.slice().reverse().map(p => (
+                        {priorities.map(p => (
                             <option key={p.id} value={p.level}>At least {p.label}</option>
                         ))}
                      </select>
 
                     <div className="space-y-2">
-                        {TICKET_PRIORITIES.map((p) => (
+                        {priorities.slice().reverse().map((p) => (
                             <label key={p.id} className="flex items-center space-x-2 cursor-pointer">
                                 <input
                                     type="checkbox"
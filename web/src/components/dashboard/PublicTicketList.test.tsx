@@ -3,6 +3,7 @@ import { describe, it, expect, vi } from 'vitest';
 import PublicTicketList from './PublicTicketList';
 import { BrowserRouter } from 'react-router-dom';
 import type { PublicTicket } from '../../api/public';
+import type { TicketStatus, TicketPriority } from '../../types';
 
 vi.mock('react-router-dom', async () => {
   const actual = await vi.importActual('react-router-dom');
@@ -31,20 +32,30 @@ const mockTickets: PublicTicket[] = [
   },
 ];
 
+const mockStatuses: TicketStatus[] = [
+  { id: 'new', label: 'Triage', position: 1, color: '#3b82f6', state: 'open' },
+];
+
+const mockPriorities: TicketPriority[] = [
+  { id: 'high', label: 'Urgent', level: 3, description: '', color: '#f97316' },
+];
+
 describe('PublicTicketList', () => {
   it('does not render Assignee column in desktop view', () => {
     render(
       <BrowserRouter>
-        <PublicTicketList tickets={mockTickets} isLoading={false} error={null} />
+        <PublicTicketList tickets={mockTickets} isLoading={false} error={null} statuses={mockStatuses} priorities={mockPriorities} />
       </BrowserRouter>
     );
     expect(screen.queryByText('Assignee')).not.toBeInTheDocument();
+    expect(screen.getAllByText('Triage').length).toBeGreaterThan(0);
+    expect(screen.getAllByText('Urgent').length).toBeGreaterThan(0);
   });
 
   it('navigates on row Enter key press', () => {
     render(
       <BrowserRouter>
-        <PublicTicketList tickets={mockTickets} isLoading={false} error={null} />
+        <PublicTicketList tickets={mockTickets} isLoading={false} error={null} statuses={mockStatuses} priorities={mockPriorities} />
       </BrowserRouter>
     );
 
@@ -1,5 +1,6 @@
 import { useNavigate, useParams } from 'react-router-dom';
 import type { PublicTicket } from '../../api/public';
+import type { TicketStatus, TicketPriority } from '../../types';
 import { StatusBadge, PriorityLabel } from '../TicketAttributes';
 import EmptyState from '../EmptyState';
 import { Inbox } from 'lucide-react';
@@ -8,9 +9,18 @@ interface PublicTicketListProps {
   tickets: PublicTicket[] | undefined;
   isLoading: boolean;
   error: Error | null;
+  statuses: TicketStatus[] | undefined;
+  priorities: TicketPriority[] | undefined;
 }
 
-function MobileTicketCard({ ticket, onClick }: { readonly ticket: PublicTicket; readonly onClick: () => void }) {
+interface MobileTicketCardProps {
+  readonly ticket: PublicTicket;
+  readonly statuses: TicketStatus[] | undefined;
+  readonly priorities: TicketPriority[] | undefined;
+  readonly onClick: () => void;
+}
+
+function MobileTicketCard({ ticket, statuses, priorities, onClick }: MobileTicketCardProps) {
   return (
     <li className="block bg-white hover:bg-gray-50 cursor-pointer">
       <button
@@ -19,8 +29,8 @@ function MobileTicketCard({ ticket, onClick }: { readonly ticket: PublicTicket;
       >
         <div className="flex items-center justify-between mb-2">
           <div className="flex items-center space-x-2">
-            <StatusBadge status={ticket.status_id} />
-            <PriorityLabel priority={ticket.priority_id} />
+            <StatusBadge status={ticket.status_id} statuses={statuses} />
+            <PriorityLabel priority={ticket.priority_id} priorities={priorities} />
           </div>
           <div className="text-xs text-gray-500">
             {new Date(ticket.created_at).toLocaleDateString()}
@@ -34,7 +44,7 @@ function MobileTicketCard({ ticket, onClick }: { readonly ticket: PublicTicket;
   );
 }
 
-export default function PublicTicketList({ tickets, isLoading, error }: PublicTicketListProps) {
+export default function PublicTicketList({ tickets, isLoading, error, statuses, priorities }: PublicTicketListProps) {
   const navigate = useNavigate();
   const { token } = useParams<{ token: string }>();
 
@@ -71,6 +81,8 @@ export default function PublicTicketList({ tickets, isLoading, error }: PublicTi
             <MobileTicketCard
               key={ticket.id}
               ticket={ticket}
+              statuses={statuses}
+              priorities={priorities}
               onClick={() => navigate(`/public/${token}/tickets/${ticket.id}`)}
             />
           ))}
@@ -107,13 +119,13 @@ export default function PublicTicketList({ tickets, isLoading, error }: PublicTi
                       aria-label={`View ticket: ${ticket.title}`}
                     >
                       <td className="whitespace-nowrap px-3 py-4 text-sm text-gray-500 text-left">
-                        <StatusBadge status={ticket.status_id} />
+                        <StatusBadge status={ticket.status_id} statuses={statuses} />
                       </td>
                       <td className="whitespace-nowrap px-3 py-4 text-sm font-bold text-gray-900 text-left">
                         {ticket.title}
                       </td>
                       <td className="whitespace-nowrap px-3 py-4 text-sm text-gray-500 text-left">
-                        <PriorityLabel priority={ticket.priority_id} />
+                        <PriorityLabel priority={ticket.priority_id} priorities={priorities} />
                       </td>
                       <td className="whitespace-nowrap px-3 py-4 text-sm text-gray-500 text-left">
                         {new Date(ticket.created_at).toLocaleDateString()}
@@ -2,7 +2,7 @@ import { render, screen } from '@testing-library/react';
 import { describe, it, expect, vi } from 'vitest';
 import TicketBoard from './TicketBoard';
 import { BrowserRouter } from 'react-router-dom';
-import type { Ticket } from '../../types';
+import type { Ticket, TicketStatus, TicketPriority } from '../../types';
 
 vi.mock('react-router-dom', async () => {
   const actual = await vi.importActual('react-router-dom');
@@ -46,6 +46,20 @@ const mockTickets: Ticket[] = [
   }
 ];
 
+const mockStatuses: TicketStatus[] = [
+  { id: 'new', label: 'New', position: 1, color: '#3b82f6', state: 'open' },
+  { id: 'in_progress', label: 'In Progress', position: 2, color: '#eab308', state: 'open' },
+  { id: 'on_hold', label: 'On Hold', position: 3, color: '#f97316', state: 'open' },
+  { id: 'done', label: 'Done', position: 4, color: '#22c55e', state: 'resolved' },
+  { id: 'canceled', label: 'Canceled', position: 5, color: '#6b7280', state: 'resolved' },
+];
+
+const mockPriorities: TicketPriority[] = [
+  { id: 'low', label: 'Low', level: 1, description: '', color: '#6b7280' },
+  { id: 'medium', label: 'Medium', level: 2, description: '', color: '#3b82f6' },
+  { id: 'high', label: 'High', level: 3, description: '', color: '#f97316' },
+];
+
 describe('TicketBoard', () => {
   it('renders tickets in correct columns', () => {
     render(
@@ -55,6 +69,8 @@ describe('TicketBoard', () => {
             isLoading={false}
             error={null}
             density="standard"
+            statuses={mockStatuses}
+            priorities={mockPriorities}
             onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
@@ -73,6 +89,8 @@ describe('TicketBoard', () => {
             isLoading={true}
             error={null}
             density="standard"
+            statuses={mockStatuses}
+            priorities={mockPriorities}
             onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
@@ -88,6 +106,8 @@ describe('TicketBoard', () => {
             isLoading={false}
             error={null}
             density="standard"
+            statuses={mockStatuses}
+            priorities={mockPriorities}
             onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
@@ -108,6 +128,8 @@ describe('TicketBoard', () => {
             isLoading={false}
             error={null}
             density="standard"
+            statuses={mockStatuses}
+            priorities={mockPriorities}
             visibleStatuses={['new', 'done']}
             onOpenNewTicket={() => {}}
         />
@@ -118,4 +140,28 @@ describe('TicketBoard', () => {
     expect(screen.getByText('Done')).toBeInTheDocument();
     expect(screen.queryByText('In Progress')).not.toBeInTheDocument();
   });
+
+  it('renders the organization\'s own statuses as columns', () => {
+    const statuses: TicketStatus[] = [
+      { id: 'triage', label: 'Triage', position: 1, color: '', state: 'open' },
+      { id: 'closed', label: 'Closed', position: 2, color: '', state: 'resolved' },
+    ];
+    render(
+      <BrowserRouter>
+        <TicketBoard
+            tickets={[]}
+            isLoading={false}
+            error={null}
+            density="standard"
+            statuses={statuses}
+            priorities={mockPriorities}
+            onOpenNewTicket={() => {}}
+        />
+      </BrowserRouter>
+    );
+
+    expect(screen.getByText('Triage')).toBeInTheDocument();
+    expect(screen.queryByText('Closed')).not.toBeInTheDocument();
+    expect(screen.queryByText('New')).not.toBeInTheDocument();
+  });
 });
@@ -1,6 +1,6 @@
 import { useMemo, memo } from 'react';
 import { useNavigate } from 'react-router-dom';
-import { type Ticket, TICKET_STATUSES } from '../../types';
+import type { Ticket, TicketStatus, TicketPriority } from '../../types';
 import { PriorityLabel } from '../TicketAttributes';
 import clsx from 'clsx';
 import { type Density } from './TicketList';
@@ -10,6 +10,8 @@ interface TicketBoardProps {
   isLoading: boolean;
   error: Error | null;
   density: Density;
+  statuses: TicketStatus[] | undefined;
+  priorities: TicketPriority[] | undefined;
   visibleStatuses?: string[];
   onOpenNewTicket: () => void;
 }
@@ -17,9 +19,10 @@ interface TicketBoardProps {
 interface TicketCardProps {
   ticket: Ticket;
   density: Density;
+  priorities: TicketPriority[] | undefined;
 }
 
-const TicketCard = memo(function TicketCard({ ticket, density }: TicketCardProps) {
+const TicketCard = memo(function TicketCard({ ticket, density, priorities }: TicketCardProps) {
   const navigate = useNavigate();
 
   const paddingClass = {
@@ -52,7 +55,7 @@ const TicketCard = memo(function TicketCard({ ticket, density }: TicketCardProps
       )}
     >
       <div className="flex justify-between items-start mb-2">
-          <PriorityLabel priority={ticket.priority_id} />
+          <PriorityLabel priority={ticket.priority_id} priorities={priorities} />
           <span className="text-xs text-gray-400">{new Date(ticket.created_at).toLocaleDateString()}</span>
       </div>
       <h4 className={clsx("font-medium text-gray-900 mb-2 line-clamp-2", fontSizeClass)}>
@@ -70,6 +73,8 @@ const TicketBoard = memo(function TicketBoard({
   isLoading,
   error,
   density,
+  statuses,
+  priorities,
   visibleStatuses,
 }: TicketBoardProps) {
   // Memoize grouping logic to prevent O(N) recalculation on every render (e.g. density change or modal open)
@@ -84,11 +89,11 @@ const TicketBoard = memo(function TicketBoard({
 
   const columns = useMemo(() => {
     if (visibleStatuses && visibleStatuses.length > 0) {
-      return TICKET_STATUSES.filter((status) => visibleStatuses.includes(status.id));
+      return (statuses || []).filter((status) => visibleStatuses.includes(status.id));
     }
-    // Default view: Show active statuses (not finished)
-    return TICKET_STATUSES.filter((status) => !status.isFinished);
-  }, [visibleStatuses]);
+    // Default view: Show open statuses (not resolved)
+    return (statuses || []).filter((status) => status.state === 'open');
+  }, [statuses, visibleStatuses]);
 
   const columnWidthClass = {
     compact: 'min-w-[14rem]',
@@ -117,7 +122,7 @@ const TicketBoard = memo(function TicketBoard({
           </div>
           <div className="p-2 overflow-y-auto flex-1 space-y-2">
             {ticketsByStatus[column.id]?.map((ticket) => (
-              <TicketCard key={ticket.id} ticket={ticket} density={density} />
+              <TicketCard key={ticket.id} ticket={ticket} density={density} priorities={priorities} />
             ))}
             {!ticketsByStatus[column.id]?.length && (
               <div className="text-center text-gray-400 text-sm py-4 italic">No tickets</div>
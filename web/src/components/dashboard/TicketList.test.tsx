@@ -2,7 +2,7 @@ import { render, screen, fireEvent } from '@testing-library/react';
 import { describe, it, expect, vi } from 'vitest';
 import TicketList from './TicketList';
 import { BrowserRouter } from 'react-router-dom';
-import type { Ticket } from '../../types';
+import type { Ticket, TicketStatus, TicketPriority } from '../../types';
 
 const mockNavigate = vi.fn();
 
@@ -48,6 +48,14 @@ const mockTickets: Ticket[] = [
   },
 ];
 
+const mockStatuses: TicketStatus[] = [
+  { id: 'new', label: 'Triage', position: 1, color: '#3b82f6', state: 'open' },
+];
+
+const mockPriorities: TicketPriority[] = [
+  { id: 'high', label: 'Urgent', level: 3, description: '', color: '#f97316' },
+];
+
 describe('TicketList', () => {
   it('renders ticket list correctly', () => {
     render(
@@ -57,6 +65,8 @@ describe('TicketList', () => {
           isLoading={false}
           error={null}
           density="standard"
+          statuses={mockStatuses}
+          priorities={mockPriorities}
           onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
@@ -64,6 +74,8 @@ describe('TicketList', () => {
     // Use getAllByText because it renders in both mobile and desktop views
     expect(screen.getAllByText('Test Ticket').length).toBeGreaterThan(0);
     expect(screen.getAllByText('John Doe').length).toBeGreaterThan(0);
+    expect(screen.getAllByText('Triage').length).toBeGreaterThan(0);
+    expect(screen.getAllByText('Urgent').length).toBeGreaterThan(0);
   });
 
   it('navigates on row click', () => {
@@ -74,6 +86,8 @@ describe('TicketList', () => {
           isLoading={false}
           error={null}
           density="standard"
+          statuses={mockStatuses}
+          priorities={mockPriorities}
           onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
@@ -106,6 +120,8 @@ describe('TicketList', () => {
             isLoading={false}
             error={null}
             density="standard"
+            statuses={mockStatuses}
+            priorities={mockPriorities}
             onOpenNewTicket={() => {}}
         />
       </BrowserRouter>
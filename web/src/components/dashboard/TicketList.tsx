@@ -1,5 +1,5 @@
 import { useNavigate } from 'react-router-dom';
-import type { Ticket } from '../../types';
+import type { Ticket, TicketStatus, TicketPriority } from '../../types';
 import { StatusBadge, PriorityLabel } from '../TicketAttributes';
 import EmptyState from '../EmptyState';
 import { Inbox, Plus } from 'lucide-react';
@@ -25,7 +25,12 @@ function useIsDesktop() {
   return isDesktop;
 }
 
-interface TicketListProps {
+interface TicketLookups {
+  statuses: TicketStatus[] | undefined;
+  priorities: TicketPriority[] | undefined;
+}
+
+interface TicketListProps extends TicketLookups {
   tickets: Ticket[] | undefined;
   isLoading: boolean;
   error: Error | null;
@@ -33,7 +38,7 @@ interface TicketListProps {
   onOpenNewTicket: () => void;
 }
 
-const MobileTicketCard = memo(function MobileTicketCard({ ticket }: { readonly ticket: Ticket }) {
+const MobileTicketCard = memo(function MobileTicketCard({ ticket, statuses, priorities }: { readonly ticket: Ticket } & TicketLookups) {
   const navigate = useNavigate();
 
   return (
@@ -44,8 +49,8 @@ const MobileTicketCard = memo(function MobileTicketCard({ ticket }: { readonly t
       >
         <div className="flex items-center justify-between mb-2">
           <div className="flex items-center space-x-2">
-            <StatusBadge status={ticket.status_id} />
-            <PriorityLabel priority={ticket.priority_id} />
+            <StatusBadge status={ticket.status_id} statuses={statuses} />
+            <PriorityLabel priority={ticket.priority_id} priorities={priorities} />
           </div>
           <div className="text-xs text-gray-500">
             {new Date(ticket.created_at).toLocaleDateString()}
@@ -62,7 +67,7 @@ const MobileTicketCard = memo(function MobileTicketCard({ ticket }: { readonly t
   );
 });
 
-const TicketRow = memo(function TicketRow({ ticket, density }: { ticket: Ticket; density: Density }) {
+const TicketRow = memo(function TicketRow({ ticket, density, statuses, priorities }: { ticket: Ticket; density: Density } & TicketLookups) {
   const navigate = useNavigate();
 
   const paddingClass = {
@@ -91,13 +96,13 @@ const TicketRow = memo(function TicketRow({ ticket, density }: { ticket: Ticket;
       aria-label={`View ticket: ${ticket.title}`}
     >
       <td className={clsx("whitespace-nowrap px-3 text-sm text-gray-500 text-left", paddingClass)}>
-        <StatusBadge status={ticket.status_id} />
+        <StatusBadge status={ticket.status_id} statuses={statuses} />
       </td>
       <td className={clsx("whitespace-nowrap px-3 font-bold text-gray-900 text-left", paddingClass, fontSizeClass)}>
         {ticket.title}
       </td>
       <td className={clsx("whitespace-nowrap px-3 text-gray-500 text-left", paddingClass, fontSizeClass)}>
-        <PriorityLabel priority={ticket.priority_id} />
+        <PriorityLabel priority={ticket.priority_id} priorities={priorities} />
       </td>
       <td className={clsx("whitespace-nowrap px-3 text-gray-500 text-left", paddingClass, fontSizeClass)}>
         {ticket.assignee_name || ticket.assignee_user_id || 'Unassigned'}
@@ -109,7 +114,7 @@ const TicketRow = memo(function TicketRow({ ticket, density }: { ticket: Ticket;
   );
 });
 
-const TicketList = memo(function TicketList({ tickets, isLoading, error, density, onOpenNewTicket }: TicketListProps) {
+const TicketList = memo(function TicketList({ tickets, isLoading, error, density, statuses, priorities, onOpenNewTicket }: TicketListProps) {
   // Optimization: Conditionally render mobile or desktop view to reduce DOM nodes by ~50%
   const isDesktop = useIsDesktop();
 
@@ -154,6 +159,8 @@ const TicketList = memo(function TicketList({ tickets, isLoading, error, density
               <MobileTicketCard
                 key={ticket.id}
                 ticket={ticket}
+                statuses={statuses}
+                priorities={priorities}
               />
             ))}
           </ul>
@@ -182,6 +189,8 @@ const TicketList = memo(function TicketList({ tickets, isLoading, error, density
                         key={ticket.id}
                         ticket={ticket}
                         density={density}
+                        statuses={statuses}
+                        priorities={priorities}
                       />
                     ))}
                   </tbody>
@@ -1,6 +1,7 @@
 import { useState } from 'react';
-import { useMutation, useQueryClient } from '@tanstack/react-query';
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query';
 import { createScheduledTask, updateScheduledTask } from '../../api/scheduled_tasks';
+import { getPriorities } from '../../api/organizations';
 import toast from 'react-hot-toast';
 import { Dialog, DialogPanel, DialogTitle, Transition, TransitionChild } from '@headlessui/react';
 import { Loader2 } from 'lucide-react';
@@ -42,6 +43,12 @@ export default function CreateScheduledTaskModal({ isOpen, onClose, organization
     };
   });
 
+  const { data: priorities } = useQuery({
+    queryKey: ['priorities', organizationId],
+    queryFn: () => getPriorities(organizationId),
+    enabled: !!organizationId,
+  });
+
   const createMutation = useMutation({
     mutationFn: createScheduledTask,
     onSuccess: () => {
@@ -172,10 +179,11 @@ export default function CreateScheduledTaskModal({ isOpen, onClose, organization
                               value={formData.priority_id}
                               onChange={(e) => setFormData({ ...formData, priority_id: e.target.value })}
                             >
-                              <option value="low">Low</option>
-                              <option value="medium">Medium</option>
-                              <option value="high">High</option>
-                              <option value="critical">Critical</option>
+                              {priorities?.map((priority) => (
+                                <option key={priority.id} value={priority.id}>
+                                  {priority.label}
+                                </option>
+                              ))}
                             </select>
                           </div>
                           <div>
@@ -2,6 +2,7 @@ import { useState, useEffect, useCallback } from 'react';
 import { useAuth } from '../context/AuthContext';
 import { useQuery } from '@tanstack/react-query';
 import { getTickets } from '../api/tickets';
+import { getStatuses, getPriorities } from '../api/organizations';
 import CreateTicketModal from '../components/dashboard/CreateTicketModal';
 import TicketList, { type Density } from '../components/dashboard/TicketList';
 import TicketBoard from '../components/dashboard/TicketBoard';
@@ -89,6 +90,18 @@ export default function Dashboard() {
     enabled: !!currentOrg,
   });
 
+  const { data: statuses } = useQuery({
+    queryKey: ['statuses', currentOrg?.id],
+    queryFn: () => getStatuses(currentOrg!.id),
+    enabled: !!currentOrg,
+  });
+
+  const { data: priorities } = useQuery({
+    queryKey: ['priorities', currentOrg?.id],
+    queryFn: () => getPriorities(currentOrg!.id),
+    enabled: !!currentOrg,
+  });
+
   if (!currentOrg) {
     return (
       <div className="p-8 text-center text-gray-500">
@@ -112,6 +125,8 @@ export default function Dashboard() {
         setPriority={setPriority}
         status={status}
         setStatus={setStatus}
+        statuses={statuses || []}
+        priorities={priorities || []}
         sortBy={sortBy}
         setSortBy={setSortBy}
         sortOrder={sortOrder}
@@ -126,6 +141,8 @@ export default function Dashboard() {
                 isLoading={isLoading}
                 error={error}
                 density={density}
+                statuses={statuses}
+                priorities={priorities}
                 onOpenNewTicket={handleOpenNewTicket}
             />
           </div>
@@ -136,6 +153,8 @@ export default function Dashboard() {
                 isLoading={isLoading}
                 error={error}
                 density={density}
+                statuses={statuses}
+                priorities={priorities}
                 visibleStatuses={status}
                 onOpenNewTicket={handleOpenNewTicket}
             />
@@ -1,6 +1,6 @@
 import { useParams } from 'react-router-dom';
 import { useQuery } from '@tanstack/react-query';
-import { getPublicOrganization, getPublicTickets } from '../api/public';
+import { getPublicOrganization, getPublicTickets, getPublicStatuses, getPublicPriorities } from '../api/public';
 import PublicTicketList from '../components/dashboard/PublicTicketList';
 import { LayoutDashboard } from 'lucide-react';
 
@@ -19,6 +19,18 @@ export default function PublicDashboard() {
     enabled: !!token,
   });
 
+  const { data: statuses } = useQuery({
+    queryKey: ['publicStatuses', token],
+    queryFn: () => getPublicStatuses(token!),
+    enabled: !!token,
+  });
+
+  const { data: priorities } = useQuery({
+    queryKey: ['publicPriorities', token],
+    queryFn: () => getPublicPriorities(token!),
+    enabled: !!token,
+  });
+
   if (orgLoading) {
     return (
       <div className="min-h-screen bg-gray-50 flex items-center justify-center">
@@ -51,6 +63,8 @@ export default function PublicDashboard() {
              tickets={tickets}
              isLoading={ticketsLoading}
              error={ticketsError}
+             statuses={statuses}
+             priorities={priorities}
            />
         </div>
       </main>
@@ -29,6 +29,12 @@ vi.mock('../api/public', () => ({
     updated_at: new Date().toISOString(),
     completed_at: null,
   }),
+  getPublicStatuses: vi.fn().mockResolvedValue([
+    { id: 'new', label: 'Triage', position: 1, color: '', state: 'open' },
+  ]),
+  getPublicPriorities: vi.fn().mockResolvedValue([
+    { id: 'high', label: 'Urgent', level: 3, description: '', color: '' },
+  ]),
 }));
 
 // Mock PublicTicketComments to avoid testing its internals
@@ -60,4 +66,17 @@ describe('PublicTicketDetail', () => {
     expect(screen.queryByText('Reporter')).not.toBeInTheDocument();
     expect(screen.queryByText('Assignee')).not.toBeInTheDocument();
   });
+
+  it('shows the organization\'s status and priority labels', async () => {
+    render(
+      <QueryClientProvider client={queryClient}>
+        <BrowserRouter>
+            <PublicTicketDetail />
+        </BrowserRouter>
+      </QueryClientProvider>
+    );
+
+    expect(await screen.findByText('Triage')).toBeInTheDocument();
+    expect(await screen.findByText('Urgent')).toBeInTheDocument();
+  });
 });
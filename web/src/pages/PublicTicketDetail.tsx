@@ -1,6 +1,6 @@
 import { useParams, useNavigate } from 'react-router-dom';
 import { useQuery } from '@tanstack/react-query';
-import { getPublicTicket } from '../api/public';
+import { getPublicTicket, getPublicStatuses, getPublicPriorities } from '../api/public';
 import PublicTicketComments from '../components/PublicTicketComments';
 import { ArrowLeft } from 'lucide-react';
 
@@ -14,6 +14,18 @@ export default function PublicTicketDetail() {
     enabled: !!token && !!ticketId,
   });
 
+  const { data: statuses } = useQuery({
+    queryKey: ['publicStatuses', token],
+    queryFn: () => getPublicStatuses(token!),
+    enabled: !!token,
+  });
+
+  const { data: priorities } = useQuery({
+    queryKey: ['publicPriorities', token],
+    queryFn: () => getPublicPriorities(token!),
+    enabled: !!token,
+  });
+
   if (isLoading) {
     return <div className="p-8 text-center text-gray-500">Loading ticket...</div>;
   }
@@ -48,11 +60,11 @@ export default function PublicTicketDetail() {
           <dl>
             <div className="bg-gray-50 px-4 py-5 sm:grid sm:grid-cols-3 sm:gap-4 sm:px-6">
               <dt className="text-sm font-medium text-gray-500">Status</dt>
-              <dd className="mt-1 text-sm text-gray-900 sm:mt-0 sm:col-span-2 capitalize">{ticket.status_id.replace('_', ' ')}</dd>
+              <dd className="mt-1 text-sm text-gray-900 sm:mt-0 sm:col-span-2">{statuses?.find((s) => s.id === ticket.status_id)?.label || ticket.status_id}</dd>
             </div>
             <div className="bg-white px-4 py-5 sm:grid sm:grid-cols-3 sm:gap-4 sm:px-6">
               <dt className="text-sm font-medium text-gray-500">Priority</dt>
-              <dd className="mt-1 text-sm text-gray-900 sm:mt-0 sm:col-span-2 capitalize">{ticket.priority_id}</dd>
+              <dd className="mt-1 text-sm text-gray-900 sm:mt-0 sm:col-span-2">{priorities?.find((p) => p.id === ticket.priority_id)?.label || ticket.priority_id}</dd>
             </div>
             <div className="bg-gray-50 px-4 py-5 sm:grid sm:grid-cols-3 sm:gap-4 sm:px-6">
               <dt className="text-sm font-medium text-gray-500">Created At</dt>
@@ -1,7 +1,7 @@
 import { useParams, useNavigate } from 'react-router-dom';
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { getTicket, updateTicket } from '../api/tickets';
-import { getMembers } from '../api/organizations';
+import { getMembers, getStatuses, getPriorities } from '../api/organizations';
 import TicketComments from '../components/TicketComments';
 import { ArrowLeft, Lock, Paperclip } from 'lucide-react';
 import type { Ticket } from '../types';
@@ -24,6 +24,18 @@ export default function TicketDetail() {
     enabled: !!ticket?.organization_id,
   });
 
+  const { data: statuses } = useQuery({
+    queryKey: ['statuses', ticket?.organization_id],
+    queryFn: () => getStatuses(ticket!.organization_id),
+    enabled: !!ticket?.organization_id,
+  });
+
+  const { data: priorities } = useQuery({
+    queryKey: ['priorities', ticket?.organization_id],
+    queryFn: () => getPriorities(ticket!.organization_id),
+    enabled: !!ticket?.organization_id,
+  });
+
   const mutation = useMutation({
     mutationFn: (data: { status_id?: string; priority_id?: string; sensitive?: boolean; assignee_id?: string | null }) =>
       updateTicket(id!, data),
@@ -92,11 +104,11 @@ export default function TicketDetail() {
             disabled={mutation.isPending}
             className="block w-40 rounded-md border-gray-300 shadow-sm focus:border-indigo-500 focus:ring-indigo-500 sm:text-sm border p-2"
           >
-            <option value="new">New</option>
-            <option value="in_progress">In Progress</option>
-            <option value="on_hold">On Hold</option>
-            <option value="done">Done</option>
-            <option value="canceled">Canceled</option>
+            {statuses?.map((status) => (
+              <option key={status.id} value={status.id}>
+                {status.label}
+              </option>
+            ))}
           </select>
         </div>
 
@@ -109,10 +121,11 @@ export default function TicketDetail() {
             disabled={mutation.isPending}
             className="block w-40 rounded-md border-gray-300 shadow-sm focus:border-indigo-500 focus:ring-indigo-500 sm:text-sm border p-2"
           >
-            <option value="low">Low</option>
-            <option value="medium">Medium</option>
-            <option value="high">High</option>
-            <option value="critical">Critical</option>
+            {priorities?.map((priority) => (
+              <option key={priority.id} value={priority.id}>
+                {priority.label}
+              </option>
+            ))}
           </select>
         </div>
 
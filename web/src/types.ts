@@ -67,20 +67,25 @@ export interface Member {
   role: string;
 }
 
-export const TICKET_STATUSES = [
-  { id: 'new', label: 'New', isFinished: false },
-  { id: 'in_progress', label: 'In Progress', isFinished: false },
-  { id: 'on_hold', label: 'On Hold', isFinished: false },
-  { id: 'done', label: 'Done', isFinished: true },
-  { id: 'canceled', label: 'Canceled', isFinished: true },
-] as const;
+// TicketStatus is one of an organization's statuses, ordered by position.
+// Resolved statuses count as finished.
+export interface TicketStatus {
+  id: string;
+  label: string;
+  position: number;
+  color: string;
+  state: 'open' | 'resolved';
+}
 
-export const TICKET_PRIORITIES = [
-  { id: 'critical', label: 'Critical', level: 4 },
-  { id: 'high', label: 'High', level: 3 },
-  { id: 'medium', label: 'Medium', level: 2 },
-  { id: 'low', label: 'Low', level: 1 },
-] as const;
+// TicketPriority is one of an organization's priorities, ordered by level
+// from lowest to highest.
+export interface TicketPriority {
+  id: string;
+  label: string;
+  level: number;
+  description: string;
+  color: string;
+}
 
 export interface ScheduledTask {
   id: string;